@@ -0,0 +1,78 @@
+package d2b
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/saturn4er/go-data-to-bytes.v2/internal/schema"
+)
+
+// naturalAlign returns alignment of value encoded with c, which C compiler would use for it
+// Values, which are stored as bytes (strings, varints, bit groups, custom types), are not aligned
+func naturalAlign(c codec) int {
+	switch c := c.(type) {
+	case numberCodec:
+		return c.length
+	case sizedIntCodec:
+		return c.length
+	case float16Codec:
+		return 2
+	case arrayCodec:
+		return naturalAlign(c.elem)
+	case fixedSliceCodec:
+		return naturalAlign(c.elem)
+	case *ptrCodec:
+		return naturalAlign(c.elem)
+	case *structCodec:
+		return c.widest
+	case prefixedCodec:
+		if c.prefix == schema.LenPrefixVarint {
+			return 1
+		}
+		return c.prefix
+	case *lengthFieldCodec:
+		return naturalAlign(c.value)
+	}
+	return 1
+}
+
+// alignPadding returns count of bytes, which should be added after offset to align it
+func alignPadding(offset, align int) int {
+	if align <= 1 {
+		return 0
+	}
+	return (align - offset%align) % align
+}
+
+// appendFill appends n fill bytes to buf
+func appendFill(buf []byte, fill byte, n int) []byte {
+	buf, b := extend(buf, n)
+	if fill != 0 {
+		for i := range b {
+			b[i] = fill
+		}
+	}
+	return buf
+}
+
+// skipReserved skips n reserved bytes. If strict is set, it checks, that they contain fill byte
+func skipReserved(in *input, n int, fill byte, strict bool) error {
+	bytes, err := in.next(n)
+	if err != nil {
+		return err
+	}
+	if strict {
+		for i, b := range bytes {
+			if b != fill {
+				return errors.Errorf("reserved byte %d is 0x%02x instead of 0x%02x", i, b, fill)
+			}
+		}
+	}
+	return nil
+}
+
+// offsetPadding returns count of bytes, which should be added after current position to place field at offset
+func offsetPadding(current, offset int) (int, error) {
+	if current > offset {
+		return 0, errors.Errorf("field at offset %d overlaps previous field, which ends at %d", offset, current)
+	}
+	return offset - current, nil
+}
@@ -0,0 +1,310 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testNaturalInner struct {
+	_ struct{} `d2b:"natural"`
+	A uint8
+	B uint64
+}
+
+type testPackedInner struct {
+	A uint8
+	B uint32
+}
+
+type testNaturalWithPacked struct {
+	_ struct{} `d2b:"natural"`
+	X uint8
+	I struct{ A uint32 }
+	Y uint8
+	P testPackedInner
+}
+
+type testNaturalOuter struct {
+	_ struct{} `d2b:"natural"`
+	X uint8
+	I testNaturalInner
+	Y uint16
+}
+
+func TestAlign(t *testing.T) {
+	Convey("Test fields alignment", t, func() {
+		Convey("Should align fields of natural layout like C compiler", func() {
+			type Struct struct {
+				_ struct{} `d2b:"natural"`
+				A uint8
+				B uint32
+				C uint16
+				D [2]uint16
+			}
+			size, err := SizeOf(reflect.TypeOf(Struct{}))
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, unsafe.Sizeof(Struct{}))
+
+			data := Struct{A: 1, B: 2, C: 3, D: [2]uint16{4, 5}}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 4, 0, 5, 0, 0, 0})
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+		Convey("Should align nested structs by their widest field", func() {
+			size, err := SizeOf(reflect.TypeOf(testNaturalOuter{}))
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, unsafe.Sizeof(testNaturalOuter{}))
+
+			data := testNaturalOuter{X: 1, I: testNaturalInner{A: 2, B: 3}, Y: 4}
+			encoded, err := Encode(data, binary.BigEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{
+				1, 0, 0, 0, 0, 0, 0, 0,
+				2, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 3,
+				0, 4, 0, 0, 0, 0, 0, 0,
+			})
+
+			var result testNaturalOuter
+			So(Decode(encoded, binary.BigEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+
+			err = Decode(encoded[:30], binary.BigEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Needed: 6, Available: 4})
+		})
+		Convey("Should align packed nested structs by their widest field", func() {
+			size, err := SizeOf(reflect.TypeOf(testNaturalWithPacked{}))
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, unsafe.Sizeof(testNaturalWithPacked{}))
+
+			data := testNaturalWithPacked{X: 1, I: struct{ A uint32 }{2}, Y: 3, P: testPackedInner{A: 4, B: 5}}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			// fields of packed struct aren't aligned
+			So(encoded, ShouldResemble, []byte{
+				1, 0, 0, 0, 2, 0, 0, 0,
+				3, 0, 0, 0, 4, 5, 0, 0,
+				0, 0, 0, 0,
+			})
+
+			var result testNaturalWithPacked
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+		Convey("Should align fields with align option after variable length fields", func() {
+			type Struct struct {
+				A uint8
+				B uint16 `d2b:"align:4"`
+				S string `d2b:"lenprefix:uint8"`
+				C uint32 `d2b:"align:4"`
+			}
+			data := Struct{A: 1, B: 2, S: "ab", C: 3}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{1, 0, 0, 0, 2, 0, 2, 'a', 'b', 0, 0, 0, 3, 0, 0, 0})
+			size, err := Size(data)
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, len(encoded))
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+
+			err = Decode(encoded[:10], binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "C", Needed: 3, Available: 1})
+		})
+		Convey("Should override natural alignment with align option", func() {
+			type Struct struct {
+				_ struct{} `d2b:"natural"`
+				A uint8
+				B uint32 `d2b:"align:2"`
+				C uint8  `d2b:"align:8"`
+			}
+			size, err := SizeOf(reflect.TypeOf(Struct{}))
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, 16)
+			encoded, err := Encode(Struct{A: 1, B: 2, C: 3}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{1, 0, 2, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0})
+		})
+		Convey("Should return error if alignment options are invalid", func() {
+			type NotPowerOfTwo struct {
+				A uint8 `d2b:"align:3"`
+			}
+			type Elem struct {
+				A [2]uint8 `d2b:"elem.align:2"`
+			}
+			type Both struct {
+				_ struct{} `d2b:"pack,natural"`
+			}
+			type BitField struct {
+				A uint8 `d2b:"bits:4"`
+				B uint8 `d2b:"bits:4,align:2"`
+			}
+			for _, data := range []interface{}{NotPowerOfTwo{}, Elem{}, Both{}, BitField{}} {
+				_, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func TestReserved(t *testing.T) {
+	Convey("Test reserved bytes", t, func() {
+		Convey("Should write reserved bytes and skip them while decoding", func() {
+			type Struct struct {
+				A uint8
+				_ struct{} `d2b:"reserved:3"`
+				B uint16   `d2b:"reserved:2,fill:0xFF"`
+				C [2]byte  `d2b:"reserved:1,reserved:strict"`
+			}
+			size, err := SizeOf(reflect.TypeOf(Struct{}))
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, 11)
+
+			data := Struct{A: 1, B: 2, C: [2]byte{3, 4}}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{1, 0, 0, 0, 0xFF, 0xFF, 2, 0, 0, 3, 4})
+
+			var result Struct
+			So(Decode([]byte{1, 7, 7, 7, 0, 0, 2, 0, 0, 3, 4}, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+			So(Decode([]byte{1, 0, 0, 0, 0xFF, 0xFF, 2, 0, 7, 3, 4}, binary.LittleEndian, &result), ShouldNotBeNil)
+
+			err = Decode(encoded[:5], binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "B", Needed: 2, Available: 1})
+		})
+		Convey("Should check reserved bytes and length of strings independently", func() {
+			type Fixed struct {
+				S string `d2b:"length:2,reserved:1,strict"`
+			}
+			type Prefixed struct {
+				S string `d2b:"lenprefix:uint8,reserved:1,reserved:strict"`
+			}
+			_, err := Encode(Fixed{S: "abc"}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			var fixed Fixed
+			So(Decode([]byte{1, 'a', 'b'}, binary.LittleEndian, &fixed), ShouldBeNil)
+			So(fixed.S, ShouldEqual, "ab")
+
+			encoded, err := Encode(Prefixed{S: "abc"}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{0, 3, 'a', 'b', 'c'})
+			var result Prefixed
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result.S, ShouldEqual, "abc")
+			So(Decode([]byte{1, 3, 'a', 'b', 'c'}, binary.LittleEndian, &result), ShouldNotBeNil)
+		})
+		Convey("Should return error if reserved options are invalid", func() {
+			type Zero struct {
+				_ struct{} `d2b:"reserved:0"`
+			}
+			type Negative struct {
+				_ struct{} `d2b:"reserved:-1"`
+			}
+			type FillWithoutReserved struct {
+				A uint8 `d2b:"fill:1"`
+			}
+			type BigFill struct {
+				_ struct{} `d2b:"reserved:1,fill:256"`
+			}
+			type Elem struct {
+				A [2]uint8 `d2b:"elem.reserved:1"`
+			}
+			type StrictWithoutReserved struct {
+				A uint8 `d2b:"reserved:strict"`
+			}
+			type PadCount struct {
+				_ struct{} `d2b:"pad:2"`
+			}
+			values := []interface{}{Zero{}, Negative{}, FillWithoutReserved{}, BigFill{}, Elem{}, StrictWithoutReserved{}, PadCount{}}
+			for _, data := range values {
+				_, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func TestOffset(t *testing.T) {
+	Convey("Test fields offsets", t, func() {
+		Convey("Should place fields at offsets from the start of struct", func() {
+			type Header struct {
+				Magic   [4]byte
+				Version uint16 `d2b:"offset:0x08"`
+				Count   uint32 `d2b:"offset:16"`
+			}
+			size, err := SizeOf(reflect.TypeOf(Header{}))
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, 20)
+
+			data := Header{Magic: [4]byte{'D', '2', 'B', 0}, Version: 2, Count: 3}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{
+				'D', '2', 'B', 0, 0, 0, 0, 0,
+				2, 0, 0, 0, 0, 0, 0, 0,
+				3, 0, 0, 0,
+			})
+
+			var result Header
+			garbage := append([]byte{}, encoded...)
+			garbage[5], garbage[12] = 7, 7
+			So(Decode(garbage, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+
+			err = Decode(encoded[:12], binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "Count", Needed: 6, Available: 2})
+		})
+		Convey("Should check overlapping after variable length fields while encoding and decoding", func() {
+			type Struct struct {
+				Name string `d2b:"lenprefix:uint8"`
+				A    uint8  `d2b:"offset:4"`
+			}
+			encoded, err := Encode(Struct{Name: "ab", A: 1}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{2, 'a', 'b', 0, 1})
+			size, err := Size(Struct{Name: "ab", A: 1})
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, 5)
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, Struct{Name: "ab", A: 1})
+
+			_, err = Encode(Struct{Name: "abcd"}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			_, err = Size(Struct{Name: "abcd"})
+			So(err, ShouldNotBeNil)
+			So(Decode([]byte{4, 'a', 'b', 'c', 'd', 1}, binary.LittleEndian, &result), ShouldNotBeNil)
+		})
+		Convey("Should return error if offset options are invalid", func() {
+			type Overlap struct {
+				A uint32
+				B uint8 `d2b:"offset:2"`
+			}
+			type Negative struct {
+				A uint8 `d2b:"offset:-1"`
+			}
+			type NotNumber struct {
+				A uint8 `d2b:"offset:x"`
+			}
+			type WithAlign struct {
+				A uint8 `d2b:"offset:4,align:4"`
+			}
+			for _, data := range []interface{}{Overlap{}, Negative{}, NotNumber{}, WithAlign{}} {
+				_, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
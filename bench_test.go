@@ -0,0 +1,183 @@
+package d2b
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+type benchHeader struct {
+	Version uint8 `d2b:"bits:4"`
+	Flags   uint8 `d2b:"bits:4"`
+	Type    uint8
+	Length  uint16 `d2b:"endian:big"`
+}
+
+type benchRecord struct {
+	ID     uint32
+	Value  int64
+	Scale  float32
+	Active bool
+	Name   string `d2b:"length:16"`
+	Points [4]int16
+	Tags   []uint16 `d2b:"length:4"`
+}
+
+type benchFrame struct {
+	Header  benchHeader
+	Count   uint16
+	Records []benchRecord `d2b:"lengthfrom:Count"`
+	Comment string        `d2b:"lenprefix:uint8"`
+}
+
+func newBenchFrame() *benchFrame {
+	records := make([]benchRecord, 16)
+	for i := range records {
+		records[i] = benchRecord{
+			ID:     uint32(i),
+			Value:  int64(i) * 1000,
+			Scale:  1.5,
+			Active: i%2 == 0,
+			Name:   "record",
+			Points: [4]int16{1, 2, 3, 4},
+			Tags:   []uint16{1, 2},
+		}
+	}
+	return &benchFrame{
+		Header:  benchHeader{Version: 1, Flags: 2, Type: 3, Length: 100},
+		Count:   uint16(len(records)),
+		Records: records,
+		Comment: "benchmark frame",
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	frame := newBenchFrame()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Encode(frame, binary.LittleEndian); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	data, err := Encode(newBenchFrame(), binary.LittleEndian)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		var frame benchFrame
+		if err := Decode(data, binary.LittleEndian, &frame); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecoderStrings(b *testing.B) {
+	type Struct struct {
+		Names []string `d2b:"lenprefix:uint16,elem.lenprefix:uint8"`
+	}
+	data := Struct{Names: make([]string, 10000)}
+	for i := range data.Names {
+		data.Names[i] = "name"
+	}
+	encoded, err := Encode(data, binary.LittleEndian)
+	if err != nil {
+		b.Fatal(err)
+	}
+	r := bytes.NewReader(encoded)
+	d := NewDecoder(r, binary.LittleEndian)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(encoded)))
+	for i := 0; i < b.N; i++ {
+		*r = *bytes.NewReader(encoded)
+		var result Struct
+		if err := d.Decode(&result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecoderCString(b *testing.B) {
+	type Struct struct {
+		Text string `d2b:"cstring"`
+	}
+	encoded, err := Encode(Struct{Text: strings.Repeat("a", 50000)}, binary.LittleEndian)
+	if err != nil {
+		b.Fatal(err)
+	}
+	r := bytes.NewReader(encoded)
+	d := NewDecoder(r, binary.LittleEndian)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(encoded)))
+	for i := 0; i < b.N; i++ {
+		*r = *bytes.NewReader(encoded)
+		var result Struct
+		if err := d.Decode(&result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncoder(b *testing.B) {
+	frame := newBenchFrame()
+	e := NewEncoder(ioutil.Discard, binary.LittleEndian)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := e.Encode(frame); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeFixedStruct(b *testing.B) {
+	record := newBenchFrame().Records[0]
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Encode(&record, binary.LittleEndian); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeFixedStruct(b *testing.B) {
+	data, err := Encode(newBenchFrame().Records[0], binary.LittleEndian)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	var record benchRecord
+	for i := 0; i < b.N; i++ {
+		if err := Decode(data, binary.LittleEndian, &record); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAppendEncode(b *testing.B) {
+	frame := newBenchFrame()
+	buf := make([]byte, 0, 4096)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := AppendEncode(buf[:0], frame, binary.LittleEndian); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeToFixedStruct(b *testing.B) {
+	record := newBenchFrame().Records[0]
+	buf := make([]byte, 128)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeTo(buf, &record, binary.LittleEndian); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"gopkg.in/saturn4er/go-data-to-bytes.v2/internal/schema"
+)
+
+// bitGroupCodec encodes group of bit fields, which starts from i-th field. It works with the whole struct value
+type bitGroupCodec struct {
+	fields []reflect.StructField
+	tags   []*schema.Tag
+	start  int
+}
+
+func newBitGroupCodec(info *structFields, i int) *bitGroupCodec {
+	return &bitGroupCodec{fields: info.fields, tags: info.tags, start: i}
+}
+
+func (c *bitGroupCodec) size() int {
+	return c.tags[c.start].BitGroupLength
+}
+
+func (c *bitGroupCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	group := c.tags[c.start]
+	var acc uint64
+	var offset uint
+	for j := c.start; j < group.BitGroupEnd; j++ {
+		bits := uint(c.tags[j].Bits)
+		value, err := bitFieldValue(structField(v, c.fields[j].Index), bits)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't encode %v bit field", c.fields[j].Name)
+		}
+		if group.BitOrder == schema.BitOrderLSB {
+			acc |= value << offset
+		} else {
+			acc = acc<<bits | value
+		}
+		offset += bits
+	}
+	var b [8]byte
+	if group.BitOrder == schema.BitOrderLSB {
+		binary.LittleEndian.PutUint64(b[:], acc)
+		return append(buf, b[:group.BitGroupLength]...), nil
+	}
+	binary.BigEndian.PutUint64(b[:], acc)
+	return append(buf, b[8-group.BitGroupLength:]...), nil
+}
+
+func (c *bitGroupCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	group := c.tags[c.start]
+	bytes, err := in.next(group.BitGroupLength)
+	if err != nil {
+		return err
+	}
+	var b [8]byte
+	var acc uint64
+	if group.BitOrder == schema.BitOrderLSB {
+		copy(b[:], bytes)
+		acc = binary.LittleEndian.Uint64(b[:])
+	} else {
+		copy(b[8-group.BitGroupLength:], bytes)
+		acc = binary.BigEndian.Uint64(b[:])
+	}
+	remaining := uint(group.BitGroupLength * 8)
+	for j := c.start; j < group.BitGroupEnd; j++ {
+		bits := uint(c.tags[j].Bits)
+		var value uint64
+		if group.BitOrder == schema.BitOrderLSB {
+			value = acc & (1<<bits - 1)
+			acc >>= bits
+		} else {
+			remaining -= bits
+			value = acc >> remaining & (1<<bits - 1)
+		}
+		// reserved "_" bits are not stored
+		if c.fields[j].Name != "_" {
+			setBitFieldValue(structField(v, c.fields[j].Index), value, bits)
+		}
+	}
+	return nil
+}
+
+// bitFieldValue returns bits of integer or bool field, checking, that value fits in them
+func bitFieldValue(v reflect.Value, bits uint) (uint64, error) {
+	mask := uint64(1)<<bits - 1
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		if bits < 64 && (n < -1<<(bits-1) || n >= 1<<(bits-1)) {
+			return 0, errors.Errorf("value %d doesn't fit in %d bits", n, bits)
+		}
+		return uint64(n) & mask, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := v.Uint()
+		if n&^mask != 0 {
+			return 0, errors.Errorf("value %d doesn't fit in %d bits", n, bits)
+		}
+		return n, nil
+	}
+	return 0, errors.Errorf("bit field can't be %v", v.Kind())
+}
+
+// setBitFieldValue sets integer or bool field, sign-extending signed integers
+func setBitFieldValue(v reflect.Value, value uint64, bits uint) {
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(value != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		shift := 64 - bits
+		v.SetInt(int64(value<<shift) >> shift)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(value)
+	}
+}
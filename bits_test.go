@@ -0,0 +1,118 @@
+package d2b
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBitFields(t *testing.T) {
+	Convey("Test bit fields", t, func() {
+		type Header struct {
+			Version uint8 `d2b:"bits:3"`
+			Flag    bool  `d2b:"bits:1"`
+			Type    uint8 `d2b:"bits:4"`
+			Length  uint16
+			Offset  int16  `d2b:"bits:13"`
+			Mode    uint32 `d2b:"bits:3"`
+		}
+		header := Header{Version: 5, Flag: true, Type: 0xA, Length: 0x102, Offset: -2, Mode: 3}
+		Convey("Should pack bit fields MSB first by default", func() {
+			b, err := Encode(header, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, []byte{
+				0xBA,
+				0x02, 0x01,
+				0xFF, 0xF3,
+			})
+			var result Header
+			So(Decode(b, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, header)
+		})
+		Convey("Should pack bit fields LSB first if struct has bitorder option", func() {
+			type LSBHeader struct {
+				_       struct{} `d2b:"bitorder:lsb"`
+				Version uint8    `d2b:"bits:3"`
+				Flag    bool     `d2b:"bits:1"`
+				Type    uint8    `d2b:"bits:4"`
+				Length  uint16
+				Offset  int16  `d2b:"bits:13"`
+				Mode    uint32 `d2b:"bits:3"`
+			}
+			lsbHeader := LSBHeader{Version: 5, Flag: true, Type: 0xA, Length: 0x102, Offset: -2, Mode: 3}
+			b, err := Encode(lsbHeader, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, []byte{
+				0xAD,
+				0x02, 0x01,
+				0xFE, 0x7F,
+			})
+			var result LSBHeader
+			So(Decode(b, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, lsbHeader)
+		})
+		Convey("Should calculate length of bit fields", func() {
+			length, err := getTypeBytesLength(reflect.TypeOf(Header{}), Options{})
+			So(err, ShouldBeNil)
+			So(length, ShouldEqual, 5)
+
+			var result Header
+			d := NewDecoder(bytes.NewReader([]byte{0xBA, 0x02, 0x01, 0xFF, 0xF3}), binary.LittleEndian)
+			So(d.Decode(&result), ShouldBeNil)
+			So(result, ShouldResemble, header)
+		})
+		Convey("Should return ShortBufferError if bit group is truncated", func() {
+			var result Header
+			err := Decode([]byte{0xBA, 0x02, 0x01, 0xFF}, binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "Offset", Needed: 2, Available: 1})
+		})
+		Convey("Should return error if value doesn't fit in bit field", func() {
+			values := []interface{}{
+				Header{Version: 8},
+				Header{Offset: 4096},
+				Header{Offset: -4097},
+			}
+			for _, value := range values {
+				b, err := Encode(value, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+				So(b, ShouldBeEmpty)
+			}
+		})
+		Convey("Should return error if bit fields are invalid", func() {
+			type NotWholeByte struct {
+				A uint8 `d2b:"bits:3"`
+				B uint8 `d2b:"bits:4"`
+				C uint8
+			}
+			type TooWide struct {
+				A uint8 `d2b:"bits:9"`
+			}
+			type WrongType struct {
+				A string `d2b:"bits:8"`
+			}
+			type WrongOrder struct {
+				_ struct{} `d2b:"bitorder:middle"`
+			}
+			type TooLongGroup struct {
+				A uint64 `d2b:"bits:64"`
+				B uint8  `d2b:"bits:8"`
+			}
+			type AutoFilled struct {
+				Count uint8  `d2b:"bits:4"`
+				Flags uint8  `d2b:"bits:4"`
+				Data  []byte `d2b:"lengthfrom:Count,autofill"`
+			}
+			values := []interface{}{NotWholeByte{}, TooWide{}, WrongType{}, WrongOrder{}, TooLongGroup{}, AutoFilled{Data: []byte{1, 2}}}
+			for _, value := range values {
+				b, err := Encode(value, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+				So(b, ShouldBeEmpty)
+				_, err = getTypeBytesLength(reflect.TypeOf(value), Options{})
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -0,0 +1,331 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"gopkg.in/saturn4er/go-data-to-bytes.v2/internal/schema"
+)
+
+// Charset converts characters between Go strings and text encoding of string fields
+// Charsets are chosen with charset tag option, e.g. d2b:"length:16,charset:utf16le"
+type Charset interface {
+	// UnitSize returns size of code unit in bytes. Lengths of strings are counted in code units by default
+	UnitSize() int
+	// AppendRune appends encoded r to buf. It returns buf unchanged and false if r can't be represented
+	AppendRune(buf []byte, r rune) ([]byte, bool)
+	// DecodeRune decodes character from the beginning of b and returns count of bytes it takes
+	// ok is false if bytes are invalid, size is count of bytes to skip then
+	DecodeRune(b []byte) (r rune, size int, ok bool)
+	// Replacement returns character, which is written instead of characters, that can't be represented
+	Replacement() rune
+}
+
+var charsetsMx sync.RWMutex
+var charsets = map[string]Charset{
+	"utf8":    utf8Charset{},
+	"utf16le": utf16Charset{endian: binary.LittleEndian},
+	"utf16be": utf16Charset{endian: binary.BigEndian},
+	"latin1":  latin1,
+	"cp1252":  cp1252,
+	"cp037":   cp037,
+	"ebcdic":  cp037,
+}
+
+// RegisterCharset makes charset available for charset tag option with name
+// Structs, which use it, should be encoded after registration
+func RegisterCharset(name string, charset Charset) {
+	charsetsMx.Lock()
+	charsets[name] = charset
+	charsetsMx.Unlock()
+}
+
+// LookupCharset returns charset, registered with name
+func LookupCharset(name string) (Charset, bool) {
+	charsetsMx.RLock()
+	defer charsetsMx.RUnlock()
+	charset, ok := charsets[name]
+	return charset, ok
+}
+
+// utf8Charset keeps strings as is, but checks, that they are valid UTF-8
+type utf8Charset struct{}
+
+func (c utf8Charset) UnitSize() int {
+	return 1
+}
+
+func (c utf8Charset) AppendRune(buf []byte, r rune) ([]byte, bool) {
+	if !utf8.ValidRune(r) {
+		return buf, false
+	}
+	buf, b := extend(buf, utf8.RuneLen(r))
+	utf8.EncodeRune(b, r)
+	return buf, true
+}
+
+func (c utf8Charset) DecodeRune(b []byte) (rune, int, bool) {
+	r, size := utf8.DecodeRune(b)
+	return r, size, r != utf8.RuneError || size > 1
+}
+
+func (c utf8Charset) Replacement() rune {
+	return utf8.RuneError
+}
+
+// utf16Charset encodes characters as UTF-16 code units, using surrogate pairs for characters outside of BMP
+type utf16Charset struct {
+	endian binary.ByteOrder
+}
+
+func (c utf16Charset) UnitSize() int {
+	return 2
+}
+
+func (c utf16Charset) AppendRune(buf []byte, r rune) ([]byte, bool) {
+	switch {
+	case !utf8.ValidRune(r):
+		return buf, false
+	case r < 0x10000:
+		buf, b := extend(buf, 2)
+		c.endian.PutUint16(b, uint16(r))
+		return buf, true
+	}
+	r1, r2 := utf16.EncodeRune(r)
+	buf, b := extend(buf, 4)
+	c.endian.PutUint16(b, uint16(r1))
+	c.endian.PutUint16(b[2:], uint16(r2))
+	return buf, true
+}
+
+func (c utf16Charset) DecodeRune(b []byte) (rune, int, bool) {
+	if len(b) < 2 {
+		return utf8.RuneError, len(b), false
+	}
+	r1 := rune(c.endian.Uint16(b))
+	if !utf16.IsSurrogate(r1) {
+		return r1, 2, true
+	}
+	if len(b) >= 4 {
+		if r := utf16.DecodeRune(r1, rune(c.endian.Uint16(b[2:]))); r != utf8.RuneError {
+			return r, 4, true
+		}
+	}
+	return utf8.RuneError, 2, false
+}
+
+func (c utf16Charset) Replacement() rune {
+	return utf8.RuneError
+}
+
+// SingleByteCharset encodes every character as one byte, using table of characters
+type SingleByteCharset struct {
+	decode      [256]rune
+	encode      map[rune]byte
+	replacement rune
+}
+
+// NewSingleByteCharset returns charset, which encodes table[b] character as byte b
+// Bytes, which are not used, should have negative values in table. replacement should be present in table
+func NewSingleByteCharset(table [256]rune, replacement rune) *SingleByteCharset {
+	c := &SingleByteCharset{decode: table, encode: make(map[rune]byte, 256), replacement: replacement}
+	for b := len(table) - 1; b >= 0; b-- {
+		if table[b] >= 0 {
+			c.encode[table[b]] = byte(b)
+		}
+	}
+	return c
+}
+
+func (c *SingleByteCharset) UnitSize() int {
+	return 1
+}
+
+func (c *SingleByteCharset) AppendRune(buf []byte, r rune) ([]byte, bool) {
+	if r >= 0 && r < 0x80 && c.decode[r] == r {
+		return append(buf, byte(r)), true
+	}
+	b, ok := c.encode[r]
+	if !ok {
+		return buf, false
+	}
+	return append(buf, b), true
+}
+
+func (c *SingleByteCharset) DecodeRune(b []byte) (rune, int, bool) {
+	if len(b) == 0 {
+		return utf8.RuneError, 0, false
+	}
+	r := c.decode[b[0]]
+	if r < 0 {
+		return utf8.RuneError, 1, false
+	}
+	return r, 1, true
+}
+
+func (c *SingleByteCharset) Replacement() rune {
+	return c.replacement
+}
+
+// latin1Table returns ISO 8859-1 table, where every byte is the code point of character
+func latin1Table() [256]rune {
+	var table [256]rune
+	for i := range table {
+		table[i] = rune(i)
+	}
+	return table
+}
+
+var latin1 = NewSingleByteCharset(latin1Table(), '?')
+
+// cp1252 is Windows-1252, which differs from ISO 8859-1 in 0x80-0x9F range
+var cp1252 = func() *SingleByteCharset {
+	table := latin1Table()
+	copy(table[0x80:0xA0], []rune{
+		0x20AC, -1, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+		0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, -1, 0x017D, -1,
+		-1, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+		0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, -1, 0x017E, 0x0178,
+	})
+	return NewSingleByteCharset(table, '?')
+}()
+
+// cp037 is EBCDIC code page, used by IBM mainframes in US and Canada
+var cp037 = NewSingleByteCharset([256]rune{
+	0x0000, 0x0001, 0x0002, 0x0003, 0x009C, 0x0009, 0x0086, 0x007F,
+	0x0097, 0x008D, 0x008E, 0x000B, 0x000C, 0x000D, 0x000E, 0x000F,
+	0x0010, 0x0011, 0x0012, 0x0013, 0x009D, 0x0085, 0x0008, 0x0087,
+	0x0018, 0x0019, 0x0092, 0x008F, 0x001C, 0x001D, 0x001E, 0x001F,
+	0x0080, 0x0081, 0x0082, 0x0083, 0x0084, 0x000A, 0x0017, 0x001B,
+	0x0088, 0x0089, 0x008A, 0x008B, 0x008C, 0x0005, 0x0006, 0x0007,
+	0x0090, 0x0091, 0x0016, 0x0093, 0x0094, 0x0095, 0x0096, 0x0004,
+	0x0098, 0x0099, 0x009A, 0x009B, 0x0014, 0x0015, 0x009E, 0x001A,
+	0x0020, 0x00A0, 0x00E2, 0x00E4, 0x00E0, 0x00E1, 0x00E3, 0x00E5,
+	0x00E7, 0x00F1, 0x00A2, 0x002E, 0x003C, 0x0028, 0x002B, 0x007C,
+	0x0026, 0x00E9, 0x00EA, 0x00EB, 0x00E8, 0x00ED, 0x00EE, 0x00EF,
+	0x00EC, 0x00DF, 0x0021, 0x0024, 0x002A, 0x0029, 0x003B, 0x00AC,
+	0x002D, 0x002F, 0x00C2, 0x00C4, 0x00C0, 0x00C1, 0x00C3, 0x00C5,
+	0x00C7, 0x00D1, 0x00A6, 0x002C, 0x0025, 0x005F, 0x003E, 0x003F,
+	0x00F8, 0x00C9, 0x00CA, 0x00CB, 0x00C8, 0x00CD, 0x00CE, 0x00CF,
+	0x00CC, 0x0060, 0x003A, 0x0023, 0x0040, 0x0027, 0x003D, 0x0022,
+	0x00D8, 0x0061, 0x0062, 0x0063, 0x0064, 0x0065, 0x0066, 0x0067,
+	0x0068, 0x0069, 0x00AB, 0x00BB, 0x00F0, 0x00FD, 0x00FE, 0x00B1,
+	0x00B0, 0x006A, 0x006B, 0x006C, 0x006D, 0x006E, 0x006F, 0x0070,
+	0x0071, 0x0072, 0x00AA, 0x00BA, 0x00E6, 0x00B8, 0x00C6, 0x00A4,
+	0x00B5, 0x007E, 0x0073, 0x0074, 0x0075, 0x0076, 0x0077, 0x0078,
+	0x0079, 0x007A, 0x00A1, 0x00BF, 0x00D0, 0x00DD, 0x00DE, 0x00AE,
+	0x005E, 0x00A3, 0x00A5, 0x00B7, 0x00A9, 0x00A7, 0x00B6, 0x00BC,
+	0x00BD, 0x00BE, 0x005B, 0x005D, 0x00AF, 0x00A8, 0x00B4, 0x00D7,
+	0x007B, 0x0041, 0x0042, 0x0043, 0x0044, 0x0045, 0x0046, 0x0047,
+	0x0048, 0x0049, 0x00AD, 0x00F4, 0x00F6, 0x00F2, 0x00F3, 0x00F5,
+	0x007D, 0x004A, 0x004B, 0x004C, 0x004D, 0x004E, 0x004F, 0x0050,
+	0x0051, 0x0052, 0x00B9, 0x00FB, 0x00FC, 0x00F9, 0x00FA, 0x00FF,
+	0x005C, 0x00F7, 0x0053, 0x0054, 0x0055, 0x0056, 0x0057, 0x0058,
+	0x0059, 0x005A, 0x00B2, 0x00D4, 0x00D6, 0x00D2, 0x00D3, 0x00D5,
+	0x0030, 0x0031, 0x0032, 0x0033, 0x0034, 0x0035, 0x0036, 0x0037,
+	0x0038, 0x0039, 0x00B3, 0x00DB, 0x00DC, 0x00D9, 0x00DA, 0x009F,
+}, '?')
+
+// textCodec converts strings between UTF-8 and charset
+type textCodec struct {
+	name    string
+	charset Charset
+	unit    int
+	replace string
+}
+
+// appendString appends encoded s to buf. Encoding stops before character, which doesn't fit in max bytes,
+// truncated is true then. Negative max means that length isn't limited
+func (t *textCodec) appendString(buf []byte, s string, max int) (result []byte, truncated bool, err error) {
+	start := len(buf)
+	for _, r := range s {
+		l := len(buf)
+		var ok bool
+		buf, ok = t.charset.AppendRune(buf, r)
+		if !ok {
+			switch t.replace {
+			case schema.ReplaceError:
+				return nil, false, errors.Errorf("character %q can't be represented in %s", r, t.name)
+			case schema.ReplaceSkip:
+				continue
+			}
+			if buf, ok = t.charset.AppendRune(buf, t.charset.Replacement()); !ok {
+				return nil, false, errors.Errorf("replacement character can't be represented in %s", t.name)
+			}
+		}
+		if max >= 0 && len(buf)-start > max {
+			return buf[:l], true, nil
+		}
+	}
+	return buf, false, nil
+}
+
+// size returns length of encoded s in bytes
+func (t *textCodec) size(s string) (int, error) {
+	var scratch [8]byte
+	var result int
+	for _, r := range s {
+		b, ok := t.charset.AppendRune(scratch[:0], r)
+		if !ok {
+			switch t.replace {
+			case schema.ReplaceError:
+				return 0, errors.Errorf("character %q can't be represented in %s", r, t.name)
+			case schema.ReplaceSkip:
+				continue
+			}
+			b, _ = t.charset.AppendRune(scratch[:0], t.charset.Replacement())
+		}
+		result += len(b)
+	}
+	return result, nil
+}
+
+// decode converts bytes in charset to string. Invalid bytes are replaced with U+FFFD
+func (t *textCodec) decode(bytes []byte) (string, error) {
+	result := make([]byte, 0, len(bytes))
+	var scratch [utf8.UTFMax]byte
+	for len(bytes) > 0 {
+		r, size, ok := t.charset.DecodeRune(bytes)
+		if size <= 0 {
+			size = 1
+		}
+		if size > len(bytes) {
+			// charset may be registered by user, so it's result isn't trusted
+			return "", errors.Errorf("%s charset decoded %d bytes, but only %d are left", t.name, size, len(bytes))
+		}
+		if !ok {
+			switch t.replace {
+			case schema.ReplaceError:
+				return "", errors.Errorf("invalid %s bytes % x", t.name, bytes[:size])
+			case schema.ReplaceSkip:
+				bytes = bytes[size:]
+				continue
+			}
+			r = utf8.RuneError
+		}
+		n := utf8.EncodeRune(scratch[:], r)
+		result = append(result, scratch[:n]...)
+		bytes = bytes[size:]
+	}
+	return string(result), nil
+}
+
+// zeroUnitIndex returns index of the first code unit, which bytes are zero, or -1
+func zeroUnitIndex(bytes []byte, unit int) int {
+	for i := 0; i+unit <= len(bytes); i += unit {
+		zero := true
+		for _, b := range bytes[i : i+unit] {
+			if b != 0 {
+				zero = false
+				break
+			}
+		}
+		if zero {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,204 @@
+package d2b
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCharsets(t *testing.T) {
+	Convey("Test string charsets", t, func() {
+		Convey("Should encode and decode UTF-16 strings", func() {
+			type Struct struct {
+				A string  `d2b:"length:4,charset:utf16le"`
+				B string  `d2b:"lenprefix:uint8,charset:utf16be"`
+				C *string `d2b:"cstring,charset:utf16le"`
+				D string  `d2b:"lenprefix:uint16,charset:utf16le,lenunit:bytes"`
+			}
+			c := "ok"
+			data := Struct{A: "héé", B: "\U0001F600", C: &c, D: "é"}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{
+				'h', 0, 0xE9, 0, 0xE9, 0, 0, 0,
+				2, 0xD8, 0x3D, 0xDE, 0x00,
+				'o', 0, 'k', 0, 0, 0,
+				2, 0, 0xE9, 0,
+			})
+			size, err := Size(data)
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, len(encoded))
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+
+			err = Decode(encoded[:15], binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "C", Needed: 4, Available: 2})
+			err = Decode(encoded[:21], binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "D", Needed: 2, Available: 0})
+		})
+		Convey("Should fill length of UTF-16 strings in code units", func() {
+			type Struct struct {
+				N uint8
+				A string `d2b:"lengthfrom:N,autofill,charset:utf16le"`
+			}
+			encoded, err := Encode(Struct{A: "a\U0001F600"}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{3, 'a', 0, 0x3D, 0xD8, 0x00, 0xDE})
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, Struct{N: 3, A: "a\U0001F600"})
+		})
+		Convey("Should truncate UTF-16 strings by characters and pad them with code units", func() {
+			type Struct struct {
+				A string `d2b:"length:2,charset:utf16le"`
+				B string `d2b:"length:3,charset:utf16le,pad:space,trim:right"`
+				C string `d2b:"length:2,charset:utf16le,strict"`
+			}
+			encoded, err := Encode(Struct{A: "a\U0001F600", B: "b", C: "cd"}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{'a', 0, 0, 0, 'b', 0, ' ', 0, ' ', 0, 'c', 0, 'd', 0})
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, Struct{A: "a", B: "b", C: "cd"})
+
+			_, err = Encode(Struct{C: "cde"}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should encode single byte charsets", func() {
+			type Struct struct {
+				A string `d2b:"length:3,charset:cp1252"`
+				B string `d2b:"length:3,charset:latin1"`
+				C string `d2b:"length:6,charset:ebcdic,pad:space,trim:right"`
+			}
+			data := Struct{A: "€1", B: "ÿ", C: "HELLO"}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{0x80, '1', 0, 0xFF, 0, 0, 0xC8, 0xC5, 0xD3, 0xD3, 0xD6, 0x40})
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+		Convey("Should replace characters, which can't be represented, according to policy", func() {
+			type Replace struct {
+				A string `d2b:"length:3,charset:latin1"`
+			}
+			type Skip struct {
+				A string `d2b:"length:3,charset:latin1,replace:skip"`
+			}
+			type Error struct {
+				A string `d2b:"length:3,charset:latin1,replace:error"`
+			}
+			encoded, err := Encode(Replace{A: "a€b"}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{'a', '?', 'b'})
+			encoded, err = Encode(Skip{A: "a€b"}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{'a', 'b', 0})
+			_, err = Encode(Error{A: "a€b"}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should replace invalid bytes while decoding according to policy", func() {
+			type Replace struct {
+				A string `d2b:"length:2,charset:cp1252"`
+			}
+			type Skip struct {
+				A string `d2b:"length:2,charset:cp1252,replace:skip"`
+			}
+			type Error struct {
+				A string `d2b:"length:2,charset:cp1252,replace:error"`
+			}
+			var replace Replace
+			So(Decode([]byte{'a', 0x81}, binary.LittleEndian, &replace), ShouldBeNil)
+			So(replace.A, ShouldEqual, "a�")
+			var skip Skip
+			So(Decode([]byte{'a', 0x81}, binary.LittleEndian, &skip), ShouldBeNil)
+			So(skip.A, ShouldEqual, "a")
+			So(Decode([]byte{'a', 0x81}, binary.LittleEndian, &Error{}), ShouldNotBeNil)
+		})
+		Convey("Should use registered charsets", func() {
+			var table [256]rune
+			for i := range table {
+				table[i] = -1
+			}
+			table['0'], table['1'], table['?'] = '0', '1', '?'
+			RegisterCharset("test-binary", NewSingleByteCharset(table, '?'))
+			type Struct struct {
+				A string `d2b:"length:3,charset:test-binary"`
+			}
+			encoded, err := Encode(Struct{A: "102"}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{'1', '0', '?'})
+		})
+		Convey("Should return error if registered charset is broken", func() {
+			RegisterCharset("test-overread", testBrokenCharset{unit: 1})
+			RegisterCharset("test-zero-unit", testBrokenCharset{})
+			type Overread struct {
+				A string `d2b:"length:2,charset:test-overread"`
+			}
+			type ZeroUnit struct {
+				A string `d2b:"cstring,charset:test-zero-unit"`
+			}
+			So(Decode([]byte{'a', 'b'}, binary.LittleEndian, &Overread{}), ShouldNotBeNil)
+			So(Decode([]byte{'a', 0}, binary.LittleEndian, &ZeroUnit{}), ShouldNotBeNil)
+		})
+		Convey("Should decode strings with charset with Decoder", func() {
+			type Struct struct {
+				A string `d2b:"cstring,charset:utf16le"`
+				B uint8
+			}
+			r := bytes.NewReader([]byte{'a', 0, 'b', 0, 0, 0, 7})
+			var result Struct
+			So(NewDecoder(r, binary.LittleEndian).Decode(&result), ShouldBeNil)
+			So(result, ShouldResemble, Struct{A: "ab", B: 7})
+		})
+		Convey("Should return error if charset options are invalid", func() {
+			type Unknown struct {
+				A string `d2b:"length:2,charset:koi8"`
+			}
+			type NotString struct {
+				A []byte `d2b:"length:2,charset:utf16le"`
+			}
+			type WithoutCharset struct {
+				A string `d2b:"length:2,replace:skip"`
+			}
+			type OddLength struct {
+				A string `d2b:"length:3,charset:utf16le,lenunit:bytes"`
+			}
+			type UnknownPolicy struct {
+				A string `d2b:"length:2,charset:utf16le,replace:drop"`
+			}
+			for _, data := range []interface{}{Unknown{}, NotString{}, WithoutCharset{}, OddLength{}, UnknownPolicy{}} {
+				_, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+// testBrokenCharset decodes more bytes, than it gets
+type testBrokenCharset struct {
+	unit int
+}
+
+func (c testBrokenCharset) UnitSize() int {
+	return c.unit
+}
+
+func (c testBrokenCharset) AppendRune(buf []byte, r rune) ([]byte, bool) {
+	return append(buf, byte(r)), true
+}
+
+func (c testBrokenCharset) DecodeRune(b []byte) (rune, int, bool) {
+	return rune(b[0]), len(b) + 1, true
+}
+
+func (c testBrokenCharset) Replacement() rune {
+	return '?'
+}
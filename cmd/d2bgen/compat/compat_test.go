@@ -0,0 +1,317 @@
+package compat
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+	d2b "gopkg.in/saturn4er/go-data-to-bytes.v2"
+)
+
+type generated interface {
+	MarshalD2B(endian binary.ByteOrder) ([]byte, error)
+	UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error)
+	AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error)
+}
+
+// convert copies src to dst, which should have the same structure
+func convert(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+		dst.Set(reflect.New(dst.Type().Elem()))
+		convert(dst.Elem(), src.Elem())
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+		dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			convert(dst.Index(i), src.Index(i))
+		}
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			convert(dst.Index(i), src.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			convert(field(dst, i), field(src, i))
+		}
+	default:
+		dst.Set(src.Convert(dst.Type()))
+	}
+}
+
+// field returns i-th field of addressable struct v, which can be set even if it's unexported
+func field(v reflect.Value, i int) reflect.Value {
+	f := v.Field(i)
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+// toPlain returns pointer to the plain twin of value, v points to
+func toPlain(f fixture, v interface{}) interface{} {
+	plain := reflect.New(reflect.TypeOf(f.plain).Elem())
+	convert(plain.Elem(), reflect.ValueOf(v).Elem())
+	return plain.Interface()
+}
+
+// fromPlain returns pointer to fixture value, which is converted from plain twin, v points to
+func fromPlain(f fixture, v interface{}) generated {
+	value := reflect.New(reflect.TypeOf(f.value).Elem())
+	convert(value.Elem(), reflect.ValueOf(v).Elem())
+	return value.Interface().(generated)
+}
+
+var randomStrings = []string{"", "a", "ab", "abc\x00", "é", " pad ", "long string"}
+
+var randomFloats = []float64{0, 1.5, -2, 65504, 1e-6, 1e10}
+
+// randomize fills v with random value. Small numbers and short strings and slices are more frequent,
+// so lengths of values fit into length fields and prefixes more often
+func randomize(r *rand.Rand, v reflect.Value, depth int) {
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(randomInt(r))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(randomInt(r)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(randomFloats[r.Intn(len(randomFloats))])
+	case reflect.String:
+		v.SetString(randomStrings[r.Intn(len(randomStrings))])
+	case reflect.Ptr:
+		if depth > 3 || r.Intn(4) == 0 {
+			return
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		randomize(r, v.Elem(), depth+1)
+	case reflect.Slice:
+		if depth > 3 || r.Intn(4) == 0 {
+			return
+		}
+		n := r.Intn(5)
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			randomize(r, v.Index(i), depth+1)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			randomize(r, v.Index(i), depth)
+		}
+	case reflect.Struct:
+		// blank fields can't be set in Go code
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Name != "_" {
+				randomize(r, field(v, i), depth)
+			}
+		}
+	}
+}
+
+func randomInt(r *rand.Rand) int64 {
+	switch r.Intn(3) {
+	case 0:
+		return int64(r.Intn(5))
+	case 1:
+		return -int64(r.Intn(3))
+	}
+	return int64(r.Uint64())
+}
+
+func TestCompatibility(t *testing.T) {
+	Convey("Test generated methods are compatible with reflective d2b", t, func() {
+		Convey("d2bgen should reject only unsupported options and types, which d2b rejects too", func() {
+			var unexpected []string
+			for _, f := range fixtures {
+				if f.rejected == "" {
+					So(f.value, ShouldImplement, (*generated)(nil))
+					continue
+				}
+				if strings.Contains(f.rejected, "not supported by d2bgen") {
+					continue
+				}
+				if _, err := d2b.Encode(f.plain, binary.LittleEndian); err == nil {
+					unexpected = append(unexpected, f.name+": "+f.rejected)
+				}
+			}
+			So(unexpected, ShouldBeEmpty)
+		})
+		for _, f := range fixtures {
+			if f.rejected != "" {
+				continue
+			}
+			f := f
+			Convey(f.name+" should be encoded and decoded like d2b does", func() {
+				r := rand.New(rand.NewSource(1))
+				for _, endian := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+					for i := 0; i < 50; i++ {
+						plain := reflect.New(reflect.TypeOf(f.plain).Elem())
+						if i > 0 {
+							randomize(r, plain.Elem(), 0)
+						}
+						checkEncode(f, plain.Interface(), endian)
+
+						data := make([]byte, r.Intn(64))
+						r.Read(data)
+						checkDecode(f, data, endian)
+					}
+				}
+			})
+		}
+	})
+}
+
+// checkEncode encodes plain value with Encode and it's fixture twin with generated methods and compares results
+// Encoded bytes and their prefixes should be decoded the same way too
+func checkEncode(f fixture, plain interface{}, endian binary.ByteOrder) {
+	v := fromPlain(f, plain)
+	expected, expectedErr := d2b.Encode(plain, endian)
+	actual, err := v.MarshalD2B(endian)
+	So(err == nil, ShouldEqual, expectedErr == nil)
+	So(actual, ShouldResemble, expected)
+
+	buf, err := v.AppendD2B([]byte{0xFF}, endian)
+	So(err == nil, ShouldEqual, expectedErr == nil)
+	if expectedErr != nil {
+		return
+	}
+	So(buf, ShouldResemble, append([]byte{0xFF}, expected...))
+
+	// Encode should use generated methods
+	encoded, err := d2b.Encode(v, endian)
+	So(err, ShouldBeNil)
+	So(encoded, ShouldResemble, expected)
+
+	for l := 0; l <= len(expected); l++ {
+		checkDecode(f, expected[:l], endian)
+	}
+}
+
+// checkDecode decodes data to new fixture value with UnmarshalD2B and to plain value with Decode and compares results
+func checkDecode(f fixture, data []byte, endian binary.ByteOrder) {
+	actual := reflect.New(reflect.TypeOf(f.value).Elem()).Interface().(generated)
+	n, err := actual.UnmarshalD2B(data, endian)
+	expected := reflect.New(reflect.TypeOf(f.plain).Elem()).Interface()
+	expectedErr := d2b.Decode(data, endian, expected)
+	if sbErr, ok := expectedErr.(*d2b.ShortBufferError); ok {
+		So(err, ShouldResemble, sbErr)
+		return
+	}
+	So(err == nil, ShouldEqual, expectedErr == nil)
+	if err != nil {
+		return
+	}
+	So(n, ShouldBeBetweenOrEqual, 0, len(data))
+	So(toPlain(f, actual), shouldResembleValue, expected)
+}
+
+// shouldResembleValue is like ShouldResemble, but NaN floats are equal, because random bytes are decoded to them
+func shouldResembleValue(actual interface{}, expected ...interface{}) string {
+	if equalValues(reflect.ValueOf(actual), reflect.ValueOf(expected[0])) {
+		return ""
+	}
+	return ShouldResemble(actual, expected...)
+}
+
+// equalValues compares values like reflect.DeepEqual, but NaN floats are equal. Structs should be addressable
+func equalValues(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float() || math.IsNaN(a.Float()) && math.IsNaN(b.Float())
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return equalValues(a.Elem(), b.Elem())
+	case reflect.Slice:
+		if a.IsNil() != b.IsNil() || a.Len() != b.Len() {
+			return false
+		}
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if !equalValues(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !equalValues(field(a, i), field(b, i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+func BenchmarkGeneratedEncode(b *testing.B) {
+	frame := newBenchFrame()
+	buf := make([]byte, 0, 1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := frame.AppendD2B(buf[:0], binary.LittleEndian); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGeneratedDecode(b *testing.B) {
+	data, err := newBenchFrame().MarshalD2B(binary.LittleEndian)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		var frame benchFrame
+		if _, err := frame.UnmarshalD2B(data, binary.LittleEndian); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReflectiveEncode(b *testing.B) {
+	frame := new(plainBenchFrame)
+	convert(reflect.ValueOf(frame).Elem(), reflect.ValueOf(newBenchFrame()).Elem())
+	buf := make([]byte, 0, 1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := d2b.AppendEncode(buf[:0], frame, binary.LittleEndian); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// newBenchFrame returns the frame of d2b benchmarks
+func newBenchFrame() *benchFrame {
+	records := make([]benchRecord, 16)
+	for i := range records {
+		records[i] = benchRecord{
+			ID:     uint32(i),
+			Value:  int64(i) * 1000,
+			Scale:  1.5,
+			Active: i%2 == 0,
+			Name:   "record",
+			Points: [4]int16{1, 2, 3, 4},
+			Tags:   []uint16{1, 2},
+		}
+	}
+	return &benchFrame{
+		Header:  benchHeader{Version: 1, Flags: 2, Type: 3, Length: 100},
+		Count:   uint16(len(records)),
+		Records: records,
+		Comment: "benchmark frame",
+	}
+}
@@ -0,0 +1,5 @@
+// Package compat contains structs from d2b tests, which methods are generated by d2bgen.
+// It's tests check, that generated methods encode and decode them like d2b does
+package compat
+
+//go:generate go test .. -run TestFixtures -update
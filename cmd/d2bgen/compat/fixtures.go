@@ -0,0 +1,2531 @@
+// Code generated by TestFixtures from d2b tests. DO NOT EDIT.
+
+package compat
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Structs, which can't be copied from d2b tests:
+//   - DecoderItem: testCountedByte: method UnmarshalD2B: testCountedBytes is not found
+//   - DecoderStruct3: DecoderItem: testCountedByte: method UnmarshalD2B: testCountedBytes is not found
+//   - EmbeddedStructsPacket3: embedded struct testHeader is used as usual field
+
+// fixture is a struct from d2b tests. Methods of value are generated, unless d2bgen rejected it
+// plain has the same fields, but d2b encodes it with reflection
+type fixture struct {
+	name     string
+	value    interface{}
+	plain    interface{}
+	rejected string
+}
+
+var fixtures = []fixture{
+	{name: "testNaturalInner", value: new(testNaturalInner), plain: new(plainTestNaturalInner), rejected: "parsing testNaturalInner struct tags error: _ field tag error: tag option natural is not supported by d2bgen"},
+	{name: "testPackedInner", value: new(testPackedInner), plain: new(plainTestPackedInner)},
+	{name: "testNaturalWithPacked", value: new(testNaturalWithPacked), plain: new(plainTestNaturalWithPacked), rejected: "parsing testNaturalWithPacked struct tags error: _ field tag error: tag option natural is not supported by d2bgen"},
+	{name: "testNaturalOuter", value: new(testNaturalOuter), plain: new(plainTestNaturalOuter), rejected: "parsing testNaturalOuter struct tags error: _ field tag error: tag option natural is not supported by d2bgen"},
+	{name: "benchHeader", value: new(benchHeader), plain: new(plainBenchHeader)},
+	{name: "benchRecord", value: new(benchRecord), plain: new(plainBenchRecord)},
+	{name: "benchFrame", value: new(benchFrame), plain: new(plainBenchFrame)},
+	{name: "testMagicHeader", value: new(testMagicHeader), plain: new(plainTestMagicHeader), rejected: "parsing testMagicHeader struct tags error: Magic field tag error: tag option const is not supported by d2bgen"},
+	{name: "testUnexported", value: new(testUnexported), plain: new(plainTestUnexported)},
+	{name: "AlignStruct", value: new(AlignStruct), plain: new(plainAlignStruct), rejected: "parsing AlignStruct struct tags error: _ field tag error: tag option natural is not supported by d2bgen"},
+	{name: "AlignStruct2", value: new(AlignStruct2), plain: new(plainAlignStruct2), rejected: "parsing AlignStruct2 struct tags error: B field tag error: tag option align is not supported by d2bgen"},
+	{name: "AlignStruct3", value: new(AlignStruct3), plain: new(plainAlignStruct3), rejected: "parsing AlignStruct3 struct tags error: _ field tag error: tag option natural is not supported by d2bgen"},
+	{name: "AlignNotPowerOfTwo", value: new(AlignNotPowerOfTwo), plain: new(plainAlignNotPowerOfTwo), rejected: "parsing AlignNotPowerOfTwo struct tags error: A field tag error: invalid alignment 3, it should be power of two up to 4096"},
+	{name: "AlignElem", value: new(AlignElem), plain: new(plainAlignElem), rejected: "parsing AlignElem struct tags error: A field tag error: -, lengthfrom, autofill, bits, bitorder, endian, align, pack, natural, reserved and offset can't be used with elem options"},
+	{name: "AlignBoth", value: new(AlignBoth), plain: new(plainAlignBoth), rejected: "parsing AlignBoth struct tags error: _ field tag error: pack and natural can't be used together"},
+	{name: "AlignBitField", value: new(AlignBitField), plain: new(plainAlignBitField), rejected: "parsing AlignBitField struct tags error: B field tag error: tag option align is not supported by d2bgen"},
+	{name: "ReservedStruct", value: new(ReservedStruct), plain: new(plainReservedStruct), rejected: "parsing ReservedStruct struct tags error: _ field tag error: tag option reserved is not supported by d2bgen"},
+	{name: "ReservedFixed", value: new(ReservedFixed), plain: new(plainReservedFixed), rejected: "parsing ReservedFixed struct tags error: S field tag error: tag option reserved is not supported by d2bgen"},
+	{name: "ReservedPrefixed", value: new(ReservedPrefixed), plain: new(plainReservedPrefixed), rejected: "parsing ReservedPrefixed struct tags error: S field tag error: tag option reserved is not supported by d2bgen"},
+	{name: "ReservedZero", value: new(ReservedZero), plain: new(plainReservedZero), rejected: "parsing ReservedZero struct tags error: _ field tag error: invalid count of reserved bytes 0"},
+	{name: "ReservedNegative", value: new(ReservedNegative), plain: new(plainReservedNegative), rejected: "parsing ReservedNegative struct tags error: _ field tag error: invalid count of reserved bytes -1"},
+	{name: "ReservedFillWithoutReserved", value: new(ReservedFillWithoutReserved), plain: new(plainReservedFillWithoutReserved), rejected: "parsing ReservedFillWithoutReserved struct tags error: A field tag error: fill and reserved:strict can be used only with reserved bytes"},
+	{name: "ReservedBigFill", value: new(ReservedBigFill), plain: new(plainReservedBigFill), rejected: "parsing ReservedBigFill struct tags error: _ field tag error: invalid fill byte: strconv.ParseUint: parsing \"256\": value out of range"},
+	{name: "ReservedElem", value: new(ReservedElem), plain: new(plainReservedElem), rejected: "parsing ReservedElem struct tags error: A field tag error: -, lengthfrom, autofill, bits, bitorder, endian, align, pack, natural, reserved and offset can't be used with elem options"},
+	{name: "ReservedStrictWithoutReserved", value: new(ReservedStrictWithoutReserved), plain: new(plainReservedStrictWithoutReserved), rejected: "parsing ReservedStrictWithoutReserved struct tags error: A field tag error: fill and reserved:strict can be used only with reserved bytes"},
+	{name: "ReservedPadCount", value: new(ReservedPadCount), plain: new(plainReservedPadCount), rejected: "parsing ReservedPadCount struct tags error: _ field tag error: unsupported padding 2"},
+	{name: "OffsetHeader", value: new(OffsetHeader), plain: new(plainOffsetHeader), rejected: "parsing OffsetHeader struct tags error: Version field tag error: tag option offset is not supported by d2bgen"},
+	{name: "OffsetStruct", value: new(OffsetStruct), plain: new(plainOffsetStruct), rejected: "parsing OffsetStruct struct tags error: A field tag error: tag option offset is not supported by d2bgen"},
+	{name: "OffsetOverlap", value: new(OffsetOverlap), plain: new(plainOffsetOverlap), rejected: "parsing OffsetOverlap struct tags error: B field tag error: tag option offset is not supported by d2bgen"},
+	{name: "OffsetNegative", value: new(OffsetNegative), plain: new(plainOffsetNegative), rejected: "parsing OffsetNegative struct tags error: A field tag error: invalid offset -1"},
+	{name: "OffsetNotNumber", value: new(OffsetNotNumber), plain: new(plainOffsetNotNumber), rejected: "parsing OffsetNotNumber struct tags error: A field tag error: invalid offset x"},
+	{name: "OffsetWithAlign", value: new(OffsetWithAlign), plain: new(plainOffsetWithAlign), rejected: "parsing OffsetWithAlign struct tags error: A field tag error: offset can't be used with align or reserved"},
+	{name: "BenchmarkDecoderStringsStruct", value: new(BenchmarkDecoderStringsStruct), plain: new(plainBenchmarkDecoderStringsStruct)},
+	{name: "BenchmarkDecoderCStringStruct", value: new(BenchmarkDecoderCStringStruct), plain: new(plainBenchmarkDecoderCStringStruct)},
+	{name: "BitFieldsHeader", value: new(BitFieldsHeader), plain: new(plainBitFieldsHeader)},
+	{name: "BitFieldsLSBHeader", value: new(BitFieldsLSBHeader), plain: new(plainBitFieldsLSBHeader)},
+	{name: "BitFieldsNotWholeByte", value: new(BitFieldsNotWholeByte), plain: new(plainBitFieldsNotWholeByte), rejected: "parsing BitFieldsNotWholeByte struct tags error: bit fields from A to B take 7 bits, which is not a whole number of bytes"},
+	{name: "BitFieldsTooWide", value: new(BitFieldsTooWide), plain: new(plainBitFieldsTooWide), rejected: "parsing BitFieldsTooWide struct tags error: A field tag error: 9 bits don't fit in uint8"},
+	{name: "BitFieldsWrongType", value: new(BitFieldsWrongType), plain: new(plainBitFieldsWrongType), rejected: "parsing BitFieldsWrongType struct tags error: A field tag error: bit field can't be string"},
+	{name: "BitFieldsWrongOrder", value: new(BitFieldsWrongOrder), plain: new(plainBitFieldsWrongOrder), rejected: "parsing BitFieldsWrongOrder struct tags error: _ field tag error: unsupported bit order middle"},
+	{name: "BitFieldsTooLongGroup", value: new(BitFieldsTooLongGroup), plain: new(plainBitFieldsTooLongGroup), rejected: "parsing BitFieldsTooLongGroup struct tags error: bit fields from A to B take 72 bits, but group can't be longer than 64 bits"},
+	{name: "BitFieldsAutoFilled", value: new(BitFieldsAutoFilled), plain: new(plainBitFieldsAutoFilled), rejected: "parsing BitFieldsAutoFilled struct tags error: Data field tag error: field Count is bit field, so it can't be autofilled"},
+	{name: "CharsetsStruct", value: new(CharsetsStruct), plain: new(plainCharsetsStruct), rejected: "parsing CharsetsStruct struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsStruct2", value: new(CharsetsStruct2), plain: new(plainCharsetsStruct2), rejected: "parsing CharsetsStruct2 struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsStruct3", value: new(CharsetsStruct3), plain: new(plainCharsetsStruct3), rejected: "parsing CharsetsStruct3 struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsStruct4", value: new(CharsetsStruct4), plain: new(plainCharsetsStruct4), rejected: "parsing CharsetsStruct4 struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsReplace", value: new(CharsetsReplace), plain: new(plainCharsetsReplace), rejected: "parsing CharsetsReplace struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsSkip", value: new(CharsetsSkip), plain: new(plainCharsetsSkip), rejected: "parsing CharsetsSkip struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsError", value: new(CharsetsError), plain: new(plainCharsetsError), rejected: "parsing CharsetsError struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsReplace2", value: new(CharsetsReplace2), plain: new(plainCharsetsReplace2), rejected: "parsing CharsetsReplace2 struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsSkip2", value: new(CharsetsSkip2), plain: new(plainCharsetsSkip2), rejected: "parsing CharsetsSkip2 struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsError2", value: new(CharsetsError2), plain: new(plainCharsetsError2), rejected: "parsing CharsetsError2 struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsStruct5", value: new(CharsetsStruct5), plain: new(plainCharsetsStruct5), rejected: "parsing CharsetsStruct5 struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsOverread", value: new(CharsetsOverread), plain: new(plainCharsetsOverread), rejected: "parsing CharsetsOverread struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsZeroUnit", value: new(CharsetsZeroUnit), plain: new(plainCharsetsZeroUnit), rejected: "parsing CharsetsZeroUnit struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsStruct6", value: new(CharsetsStruct6), plain: new(plainCharsetsStruct6), rejected: "parsing CharsetsStruct6 struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsUnknown", value: new(CharsetsUnknown), plain: new(plainCharsetsUnknown), rejected: "parsing CharsetsUnknown struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsNotString", value: new(CharsetsNotString), plain: new(plainCharsetsNotString), rejected: "parsing CharsetsNotString struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsWithoutCharset", value: new(CharsetsWithoutCharset), plain: new(plainCharsetsWithoutCharset), rejected: "parsing CharsetsWithoutCharset struct tags error: A field tag error: replace and lenunit can be used only with charset"},
+	{name: "CharsetsOddLength", value: new(CharsetsOddLength), plain: new(plainCharsetsOddLength), rejected: "parsing CharsetsOddLength struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "CharsetsUnknownPolicy", value: new(CharsetsUnknownPolicy), plain: new(plainCharsetsUnknownPolicy), rejected: "parsing CharsetsUnknownPolicy struct tags error: A field tag error: unsupported replacement policy drop"},
+	{name: "CompileStruct", value: new(CompileStruct), plain: new(plainCompileStruct)},
+	{name: "CompileFixed", value: new(CompileFixed), plain: new(plainCompileFixed)},
+	{name: "CompileNode", value: new(CompileNode), plain: new(plainCompileNode), rejected: "can't generate CompileNode methods: CompileNode.Next field error: recursive type CompileNode is not supported by d2bgen"},
+	{name: "ConstStruct", value: new(ConstStruct), plain: new(plainConstStruct), rejected: "parsing ConstStruct struct tags error: A field tag error: tag option const is not supported by d2bgen"},
+	{name: "ConstStruct2", value: new(ConstStruct2), plain: new(plainConstStruct2), rejected: "parsing testMagicHeader struct tags error: Magic field tag error: tag option const is not supported by d2bgen"},
+	{name: "ConstStruct3", value: new(ConstStruct3), plain: new(plainConstStruct3), rejected: "parsing ConstStruct3 struct tags error: A field tag error: tag option const is not supported by d2bgen"},
+	{name: "ConstOverflow", value: new(ConstOverflow), plain: new(plainConstOverflow), rejected: "parsing ConstOverflow struct tags error: A field tag error: tag option const is not supported by d2bgen"},
+	{name: "ConstNotQuoted", value: new(ConstNotQuoted), plain: new(plainConstNotQuoted), rejected: "parsing ConstNotQuoted struct tags error: A field tag error: tag option const is not supported by d2bgen"},
+	{name: "ConstWrongLength", value: new(ConstWrongLength), plain: new(plainConstWrongLength), rejected: "parsing ConstWrongLength struct tags error: A field tag error: tag option const is not supported by d2bgen"},
+	{name: "ConstFloat", value: new(ConstFloat), plain: new(plainConstFloat), rejected: "parsing ConstFloat struct tags error: A field tag error: tag option const is not supported by d2bgen"},
+	{name: "ConstWithLength", value: new(ConstWithLength), plain: new(plainConstWithLength), rejected: "parsing ConstWithLength struct tags error: A field tag error: length of constant is set by it's value, so length and string options can't be used with it"},
+	{name: "ConstWithBits", value: new(ConstWithBits), plain: new(plainConstWithBits), rejected: "parsing ConstWithBits struct tags error: A field tag error: const can't be used with bits"},
+	{name: "ConstAutoFilled", value: new(ConstAutoFilled), plain: new(plainConstAutoFilled), rejected: "parsing ConstAutoFilled struct tags error: A field tag error: field N is constant, so it can't be autofilled"},
+	{name: "DecodeStruct", value: new(DecodeStruct), plain: new(plainDecodeStruct)},
+	{name: "DecodeStruct2", value: new(DecodeStruct2), plain: new(plainDecodeStruct2)},
+	{name: "DecodeItem", value: new(DecodeItem), plain: new(plainDecodeItem)},
+	{name: "DecodeStruct3", value: new(DecodeStruct3), plain: new(plainDecodeStruct3)},
+	{name: "DecodeItem2", value: new(DecodeItem2), plain: new(plainDecodeItem2)},
+	{name: "DecodeStruct4", value: new(DecodeStruct4), plain: new(plainDecodeStruct4)},
+	{name: "DecodeStruct5", value: new(DecodeStruct5), plain: new(plainDecodeStruct5)},
+	{name: "DecodeStruct6", value: new(DecodeStruct6), plain: new(plainDecodeStruct6)},
+	{name: "DecodeStruct7", value: new(DecodeStruct7), plain: new(plainDecodeStruct7)},
+	{name: "DecodeStruct8", value: new(DecodeStruct8), plain: new(plainDecodeStruct8)},
+	{name: "DecodePrefixed", value: new(DecodePrefixed), plain: new(plainDecodePrefixed), rejected: "can't generate DecodePrefixed methods: DecodePrefixed.A field error: length of slice with zero size elements struct{} can't be stored in bytes"},
+	{name: "DecodeLengthFrom", value: new(DecodeLengthFrom), plain: new(plainDecodeLengthFrom), rejected: "can't generate DecodeLengthFrom methods: DecodeLengthFrom.A field error: length of slice with zero size elements struct{} can't be stored in bytes"},
+	{name: "DecodeMap", value: new(DecodeMap), plain: new(plainDecodeMap), rejected: "can't generate DecodeMap methods: DecodeMap.A field error: maps are not supported by d2bgen"},
+	{name: "DecodePayload", value: new(DecodePayload), plain: new(plainDecodePayload)},
+	{name: "DecodeFrame", value: new(DecodeFrame), plain: new(plainDecodeFrame)},
+	{name: "DecodeStruct9", value: new(DecodeStruct9), plain: new(plainDecodeStruct9)},
+	{name: "DecodeStruct10", value: new(DecodeStruct10), plain: new(plainDecodeStruct10)},
+	{name: "DecodeBadTag", value: new(DecodeBadTag), plain: new(plainDecodeBadTag), rejected: "parsing DecodeBadTag struct tags error: A field tag error: bool:strict can't be used with uint8"},
+	{name: "DecodeStruct11", value: new(DecodeStruct11), plain: new(plainDecodeStruct11)},
+	{name: "DecodeStruct12", value: new(DecodeStruct12), plain: new(plainDecodeStruct12)},
+	{name: "DecodeStruct13", value: new(DecodeStruct13), plain: new(plainDecodeStruct13)},
+	{name: "DecodeStruct14", value: new(DecodeStruct14), plain: new(plainDecodeStruct14), rejected: "can't generate DecodeStruct14 methods: DecodeStruct14.A field error: int needs size tag"},
+	{name: "DecodeStruct15", value: new(DecodeStruct15), plain: new(plainDecodeStruct15), rejected: "parsing DecodeStruct15 struct tags error: A field tag error: strconv.Atoi: parsing \"hello\": invalid syntax"},
+	{name: "DecodeStruct16", value: new(DecodeStruct16), plain: new(plainDecodeStruct16), rejected: "can't generate DecodeStruct16 methods: DecodeStruct16.A field error: need to specify length"},
+	{name: "DecodeStruct17", value: new(DecodeStruct17), plain: new(plainDecodeStruct17), rejected: "can't generate DecodeStruct17 methods: DecodeStruct17.A field error: need to specify length"},
+	{name: "DecodeStruct18", value: new(DecodeStruct18), plain: new(plainDecodeStruct18), rejected: "can't generate DecodeStruct18 methods: DecodeStruct18.A field error: can't generate slice element: int needs size tag"},
+	{name: "DecodeStruct19", value: new(DecodeStruct19), plain: new(plainDecodeStruct19), rejected: "can't generate DecodeStruct19 methods: DecodeStruct19.A field error: can't generate slice element: int needs size tag"},
+	{name: "DecodeStruct20", value: new(DecodeStruct20), plain: new(plainDecodeStruct20), rejected: "can't generate DecodeStruct20 methods: DecodeStruct20.A field error: can't generate array element: int needs size tag"},
+	{name: "DecoderRecord", value: new(DecoderRecord), plain: new(plainDecoderRecord)},
+	{name: "DecoderMessage", value: new(DecoderMessage), plain: new(plainDecoderMessage)},
+	{name: "DecoderStruct", value: new(DecoderStruct), plain: new(plainDecoderStruct)},
+	{name: "DecoderStruct2", value: new(DecoderStruct2), plain: new(plainDecoderStruct2), rejected: "parsing DecoderStruct2 struct tags error: A field tag error: tag option charset is not supported by d2bgen"},
+	{name: "DecoderStruct4", value: new(DecoderStruct4), plain: new(plainDecoderStruct4), rejected: "can't generate DecoderStruct4 methods: DecoderStruct4.A field error: need to specify length"},
+	{name: "EncodeTestStruct1", value: new(EncodeTestStruct1), plain: new(plainEncodeTestStruct1)},
+	{name: "EncodeStruct", value: new(EncodeStruct), plain: new(plainEncodeStruct)},
+	{name: "EncodeStruct2", value: new(EncodeStruct2), plain: new(plainEncodeStruct2)},
+	{name: "EncodeStruct22", value: new(EncodeStruct22), plain: new(plainEncodeStruct22)},
+	{name: "EncodeStruct3", value: new(EncodeStruct3), plain: new(plainEncodeStruct3), rejected: "parsing EncodeStruct3 struct tags error: A field tag error: unsupported length prefix type int128"},
+	{name: "EncodeStruct23", value: new(EncodeStruct23), plain: new(plainEncodeStruct23), rejected: "parsing EncodeStruct23 struct tags error: A field tag error: length prefix can't be used with int8"},
+	{name: "EncodeStruct4", value: new(EncodeStruct4), plain: new(plainEncodeStruct4)},
+	{name: "EncodeStruct5", value: new(EncodeStruct5), plain: new(plainEncodeStruct5)},
+	{name: "EncodeMissing", value: new(EncodeMissing), plain: new(plainEncodeMissing), rejected: "parsing EncodeMissing struct tags error: A field tag error: field Count doesn't exist"},
+	{name: "EncodeAfter", value: new(EncodeAfter), plain: new(plainEncodeAfter), rejected: "parsing EncodeAfter struct tags error: A field tag error: field Count should be declared before"},
+	{name: "EncodeNotInteger", value: new(EncodeNotInteger), plain: new(plainEncodeNotInteger), rejected: "parsing EncodeNotInteger struct tags error: A field tag error: field Count has non integer type string"},
+	{name: "EncodeNotSlice", value: new(EncodeNotSlice), plain: new(plainEncodeNotSlice), rejected: "parsing EncodeNotSlice struct tags error: A field tag error: length from another field can't be used with int8"},
+	{name: "EncodeWithPrefix", value: new(EncodeWithPrefix), plain: new(plainEncodeWithPrefix), rejected: "parsing EncodeWithPrefix struct tags error: A field tag error: lenprefix and lengthfrom can't be used together"},
+	{name: "EncodeWithoutLengthFrom", value: new(EncodeWithoutLengthFrom), plain: new(plainEncodeWithoutLengthFrom), rejected: "parsing EncodeWithoutLengthFrom struct tags error: A field tag error: autofill can be used only with lengthfrom"},
+	{name: "EncodePayload", value: new(EncodePayload), plain: new(plainEncodePayload)},
+	{name: "EncodeFrame", value: new(EncodeFrame), plain: new(plainEncodeFrame)},
+	{name: "EncodeStruct6", value: new(EncodeStruct6), plain: new(plainEncodeStruct6), rejected: "parsing EncodeStruct6 struct tags error: A field tag error: unsupported byte order middle"},
+	{name: "EncodeStruct7", value: new(EncodeStruct7), plain: new(plainEncodeStruct7)},
+	{name: "EncodeStruct8", value: new(EncodeStruct8), plain: new(plainEncodeStruct8), rejected: "parsing EncodeStruct8 struct tags error: A field tag error: float16 can't be used with int32"},
+	{name: "EncodeStructWithBool", value: new(EncodeStructWithBool), plain: new(plainEncodeStructWithBool)},
+	{name: "EncodeStruct9", value: new(EncodeStruct9), plain: new(plainEncodeStruct9)},
+	{name: "EncodeStruct10", value: new(EncodeStruct10), plain: new(plainEncodeStruct10)},
+	{name: "EncodeWrongSize", value: new(EncodeWrongSize), plain: new(plainEncodeWrongSize), rejected: "parsing EncodeWrongSize struct tags error: A field tag error: invalid int size 3"},
+	{name: "EncodeWrongType", value: new(EncodeWrongType), plain: new(plainEncodeWrongType), rejected: "parsing EncodeWrongType struct tags error: A field tag error: size can't be used with int16"},
+	{name: "EncodeStruct11", value: new(EncodeStruct11), plain: new(plainEncodeStruct11)},
+	{name: "EncodeStruct12", value: new(EncodeStruct12), plain: new(plainEncodeStruct12)},
+	{name: "EncodeNotString", value: new(EncodeNotString), plain: new(plainEncodeNotString), rejected: "parsing EncodeNotString struct tags error: A field tag error: string options can't be used with slice"},
+	{name: "EncodeUnknownPad", value: new(EncodeUnknownPad), plain: new(plainEncodeUnknownPad), rejected: "parsing EncodeUnknownPad struct tags error: A field tag error: unsupported padding tab"},
+	{name: "EncodePrefixedCString", value: new(EncodePrefixedCString), plain: new(plainEncodePrefixedCString), rejected: "parsing EncodePrefixedCString struct tags error: A field tag error: cstring can't be used with lenprefix or lengthfrom"},
+	{name: "EncodePaddedCString", value: new(EncodePaddedCString), plain: new(plainEncodePaddedCString), rejected: "parsing EncodePaddedCString struct tags error: A field tag error: cstring can't be padded with non zero bytes"},
+	{name: "EncodeVariableTrim", value: new(EncodeVariableTrim), plain: new(plainEncodeVariableTrim), rejected: "parsing EncodeVariableTrim struct tags error: A field tag error: pad, trim:right and strict can be used only with fixed length strings"},
+	{name: "EncodeErrTestStruct", value: new(EncodeErrTestStruct), plain: new(plainEncodeErrTestStruct), rejected: "parsing EncodeErrTestStruct struct tags error: Field field tag error: strconv.Atoi: parsing \"1qwe\": invalid syntax"},
+	{name: "EncodeErrTestStruct2", value: new(EncodeErrTestStruct2), plain: new(plainEncodeErrTestStruct2), rejected: "can't generate EncodeErrTestStruct2 methods: EncodeErrTestStruct2.Field field error: can't generate slice element: int needs size tag"},
+	{name: "EncodeErrTestStruct3", value: new(EncodeErrTestStruct3), plain: new(plainEncodeErrTestStruct3), rejected: "can't generate EncodeErrTestStruct3 methods: EncodeErrTestStruct3.Field field error: can't generate slice element: int needs size tag"},
+	{name: "EncodeErrTestStruct4", value: new(EncodeErrTestStruct4), plain: new(plainEncodeErrTestStruct4), rejected: "can't generate EncodeErrTestStruct4 methods: EncodeErrTestStruct4.Field field error: can't generate array element: int needs size tag"},
+	{name: "EncodeErrTestStruct5", value: new(EncodeErrTestStruct5), plain: new(plainEncodeErrTestStruct5), rejected: "can't generate EncodeErrTestStruct5 methods: EncodeErrTestStruct5.Field field error: need to specify length"},
+	{name: "EncodeErrTestStruct6", value: new(EncodeErrTestStruct6), plain: new(plainEncodeErrTestStruct6), rejected: "can't generate EncodeErrTestStruct6 methods: EncodeErrTestStruct6.Field field error: need to specify length"},
+	{name: "EncodeErrTestStruct7", value: new(EncodeErrTestStruct7), plain: new(plainEncodeErrTestStruct7), rejected: "parsing EncodeErrTestStruct7 struct tags error: Field field tag error: strconv.Atoi: parsing \"1qwe\": invalid syntax"},
+	{name: "EncodeErrTestStruct8", value: new(EncodeErrTestStruct8), plain: new(plainEncodeErrTestStruct8), rejected: "can't generate EncodeErrTestStruct8 methods: EncodeErrTestStruct8.Field field error: can't generate array element: int needs size tag"},
+	{name: "EncodeErrTestStruct9", value: new(EncodeErrTestStruct9), plain: new(plainEncodeErrTestStruct9), rejected: "can't generate EncodeErrTestStruct9 methods: EncodeErrTestStruct9.Field field error: need to specify length"},
+	{name: "EncodeErrTestStruct10", value: new(EncodeErrTestStruct10), plain: new(plainEncodeErrTestStruct10), rejected: "can't generate EncodeErrTestStruct10 methods: EncodeErrTestStruct10.Field field error: int needs size tag"},
+	{name: "EncodeErrTestStruct11", value: new(EncodeErrTestStruct11), plain: new(plainEncodeErrTestStruct11), rejected: "parsing EncodeErrTestStruct11 struct tags error: Field field tag error: strconv.Atoi: parsing \"hello\": invalid syntax"},
+	{name: "EncodeErrTestStruct12", value: new(EncodeErrTestStruct12), plain: new(plainEncodeErrTestStruct12), rejected: "can't generate EncodeErrTestStruct12 methods: EncodeErrTestStruct12.Field field error: need to specify length"},
+	{name: "EncodeErrTestStruct13", value: new(EncodeErrTestStruct13), plain: new(plainEncodeErrTestStruct13), rejected: "can't generate EncodeErrTestStruct13 methods: EncodeErrTestStruct13.Field field error: can't generate slice element: int needs size tag"},
+	{name: "EncodeToStruct", value: new(EncodeToStruct), plain: new(plainEncodeToStruct)},
+	{name: "EncodeToLimited", value: new(EncodeToLimited), plain: new(plainEncodeToLimited)},
+	{name: "EncoderRecord", value: new(EncoderRecord), plain: new(plainEncoderRecord)},
+	{name: "UnexportedFieldsStruct", value: new(UnexportedFieldsStruct), plain: new(plainUnexportedFieldsStruct)},
+	{name: "UnexportedFieldsStruct2", value: new(UnexportedFieldsStruct2), plain: new(plainUnexportedFieldsStruct2), rejected: "can't generate UnexportedFieldsStruct2 methods: UnexportedFieldsStruct2.B field error: maps are not supported by d2bgen"},
+	{name: "UnexportedFieldsStruct3", value: new(UnexportedFieldsStruct3), plain: new(plainUnexportedFieldsStruct3)},
+	{name: "UnexportedFieldsBits", value: new(UnexportedFieldsBits), plain: new(plainUnexportedFieldsBits)},
+	{name: "UnexportedFieldsLength", value: new(UnexportedFieldsLength), plain: new(plainUnexportedFieldsLength)},
+	{name: "EmbeddedStructsPacket", value: new(EmbeddedStructsPacket), plain: new(plainEmbeddedStructsPacket)},
+	{name: "EmbeddedStructsPacket2", value: new(EmbeddedStructsPacket2), plain: new(plainEmbeddedStructsPacket2)},
+	{name: "MapsStruct", value: new(MapsStruct), plain: new(plainMapsStruct), rejected: "can't generate MapsStruct methods: MapsStruct.A field error: maps are not supported by d2bgen"},
+	{name: "MapsStruct2", value: new(MapsStruct2), plain: new(plainMapsStruct2), rejected: "can't generate MapsStruct2 methods: MapsStruct2.A field error: maps are not supported by d2bgen"},
+	{name: "MapsKey", value: new(MapsKey), plain: new(plainMapsKey)},
+	{name: "MapsStruct3", value: new(MapsStruct3), plain: new(plainMapsStruct3), rejected: "can't generate MapsStruct3 methods: MapsStruct3.A field error: maps are not supported by d2bgen"},
+	{name: "MapsStruct4", value: new(MapsStruct4), plain: new(plainMapsStruct4), rejected: "can't generate MapsStruct4 methods: MapsStruct4.A field error: maps are not supported by d2bgen"},
+	{name: "MapsValue", value: new(MapsValue), plain: new(plainMapsValue)},
+	{name: "MapsStruct5", value: new(MapsStruct5), plain: new(plainMapsStruct5), rejected: "can't generate MapsStruct5 methods: MapsStruct5.A field error: maps are not supported by d2bgen"},
+	{name: "MapsStruct6", value: new(MapsStruct6), plain: new(plainMapsStruct6), rejected: "can't generate MapsStruct6 methods: MapsStruct6.A field error: maps are not supported by d2bgen"},
+	{name: "MapsWithoutPrefix", value: new(MapsWithoutPrefix), plain: new(plainMapsWithoutPrefix), rejected: "can't generate MapsWithoutPrefix methods: MapsWithoutPrefix.A field error: maps are not supported by d2bgen"},
+	{name: "MapsSortedSlice", value: new(MapsSortedSlice), plain: new(plainMapsSortedSlice), rejected: "parsing MapsSortedSlice struct tags error: A field tag error: sorted can't be used with slice"},
+	{name: "MapsStringKey", value: new(MapsStringKey), plain: new(plainMapsStringKey), rejected: "can't generate MapsStringKey methods: MapsStringKey.A field error: maps are not supported by d2bgen"},
+	{name: "MarshalerFrame", value: new(MarshalerFrame), plain: new(plainMarshalerFrame), rejected: "can't generate MarshalerFrame methods: MarshalerFrame.Time field error: type compat.testBCDTime implements only encoding.BinaryMarshaler, which is not supported by d2bgen"},
+	{name: "MarshalerFixed", value: new(MarshalerFixed), plain: new(plainMarshalerFixed), rejected: "can't generate MarshalerFixed methods: MarshalerFixed.Time field error: type compat.testBCDTime implements only encoding.BinaryMarshaler, which is not supported by d2bgen"},
+	{name: "OptionsStruct", value: new(OptionsStruct), plain: new(plainOptionsStruct), rejected: "can't generate OptionsStruct methods: OptionsStruct.A field error: int needs size tag"},
+	{name: "SizeStruct", value: new(SizeStruct), plain: new(plainSizeStruct)},
+	{name: "SizeStruct2", value: new(SizeStruct2), plain: new(plainSizeStruct2)},
+	{name: "SizeItem", value: new(SizeItem), plain: new(plainSizeItem)},
+	{name: "SizeStruct3", value: new(SizeStruct3), plain: new(plainSizeStruct3)},
+	{name: "SizeStruct4", value: new(SizeStruct4), plain: new(plainSizeStruct4)},
+	{name: "ElemOptionsStruct", value: new(ElemOptionsStruct), plain: new(plainElemOptionsStruct)},
+	{name: "ElemOptionsStruct2", value: new(ElemOptionsStruct2), plain: new(plainElemOptionsStruct2), rejected: "can't generate ElemOptionsStruct2 methods: ElemOptionsStruct2.A field error: maps are not supported by d2bgen"},
+	{name: "ElemOptionsStruct3", value: new(ElemOptionsStruct3), plain: new(plainElemOptionsStruct3), rejected: "parsing ElemOptionsStruct3 struct tags error: C field tag error: tag option elem.charset is not supported by d2bgen"},
+	{name: "ElemOptionsNotContainer", value: new(ElemOptionsNotContainer), plain: new(plainElemOptionsNotContainer), rejected: "parsing ElemOptionsNotContainer struct tags error: A field tag error: elem options can't be used with string"},
+	{name: "ElemOptionsKeyOfSlice", value: new(ElemOptionsKeyOfSlice), plain: new(plainElemOptionsKeyOfSlice), rejected: "parsing ElemOptionsKeyOfSlice struct tags error: A field tag error: key options can't be used with slice"},
+	{name: "ElemOptionsLengthFrom", value: new(ElemOptionsLengthFrom), plain: new(plainElemOptionsLengthFrom), rejected: "parsing ElemOptionsLengthFrom struct tags error: A field tag error: -, lengthfrom, autofill, bits, bitorder, endian, align, pack, natural, reserved and offset can't be used with elem options"},
+	{name: "ElemOptionsEndian", value: new(ElemOptionsEndian), plain: new(plainElemOptionsEndian), rejected: "parsing ElemOptionsEndian struct tags error: A field tag error: -, lengthfrom, autofill, bits, bitorder, endian, align, pack, natural, reserved and offset can't be used with elem options"},
+	{name: "ElemOptionsWrongType", value: new(ElemOptionsWrongType), plain: new(plainElemOptionsWrongType), rejected: "parsing ElemOptionsWrongType struct tags error: A field tag error: elem options error: string options can't be used with uint8"},
+	{name: "ElemOptionsWithoutLength", value: new(ElemOptionsWithoutLength), plain: new(plainElemOptionsWithoutLength), rejected: "can't generate ElemOptionsWithoutLength methods: ElemOptionsWithoutLength.A field error: can't generate array element: need to specify length"},
+	{name: "VarintStruct", value: new(VarintStruct), plain: new(plainVarintStruct), rejected: "parsing VarintStruct struct tags error: A field tag error: tag option varint is not supported by d2bgen"},
+	{name: "VarintStruct2", value: new(VarintStruct2), plain: new(plainVarintStruct2), rejected: "parsing VarintStruct2 struct tags error: A field tag error: tag option lenprefix:varint is not supported by d2bgen"},
+	{name: "VarintStruct3", value: new(VarintStruct3), plain: new(plainVarintStruct3), rejected: "parsing VarintStruct3 struct tags error: N field tag error: tag option varint is not supported by d2bgen"},
+	{name: "VarintStruct4", value: new(VarintStruct4), plain: new(plainVarintStruct4), rejected: "parsing VarintStruct4 struct tags error: A field tag error: tag option zigzag is not supported by d2bgen"},
+	{name: "VarintSmall", value: new(VarintSmall), plain: new(plainVarintSmall), rejected: "parsing VarintSmall struct tags error: A field tag error: tag option varint is not supported by d2bgen"},
+	{name: "VarintSigned", value: new(VarintSigned), plain: new(plainVarintSigned), rejected: "parsing VarintSigned struct tags error: A field tag error: tag option zigzag is not supported by d2bgen"},
+	{name: "VarintNotInteger", value: new(VarintNotInteger), plain: new(plainVarintNotInteger), rejected: "parsing VarintNotInteger struct tags error: A field tag error: varint can't be used with float32"},
+	{name: "VarintUnsigned", value: new(VarintUnsigned), plain: new(plainVarintUnsigned), rejected: "parsing VarintUnsigned struct tags error: A field tag error: zigzag can't be used with uint32"},
+	{name: "VarintBoth", value: new(VarintBoth), plain: new(plainVarintBoth), rejected: "parsing VarintBoth struct tags error: A field tag error: varint and zigzag can't be used together"},
+	{name: "VarintWithSize", value: new(VarintWithSize), plain: new(plainVarintWithSize), rejected: "parsing VarintWithSize struct tags error: A field tag error: varint and zigzag can't be used with size or bits"},
+	{name: "VarintWithBits", value: new(VarintWithBits), plain: new(plainVarintWithBits), rejected: "parsing VarintWithBits struct tags error: A field tag error: varint and zigzag can't be used with size or bits"},
+}
+
+// testNaturalInner is testNaturalInner from align_test.go:12
+type testNaturalInner struct {
+	_ struct{} `d2b:"natural"`
+	A uint8
+	B uint64
+}
+
+type plainTestNaturalInner struct {
+	_ struct{} `d2b:"natural"`
+	A uint8
+	B uint64
+}
+
+// testPackedInner is testPackedInner from align_test.go:18
+type testPackedInner struct {
+	A uint8
+	B uint32
+}
+
+type plainTestPackedInner struct {
+	A uint8
+	B uint32
+}
+
+// testNaturalWithPacked is testNaturalWithPacked from align_test.go:23
+type testNaturalWithPacked struct {
+	_ struct{} `d2b:"natural"`
+	X uint8
+	I struct{ A uint32 }
+	Y uint8
+	P testPackedInner
+}
+
+type plainTestNaturalWithPacked struct {
+	_ struct{} `d2b:"natural"`
+	X uint8
+	I struct{ A uint32 }
+	Y uint8
+	P plainTestPackedInner
+}
+
+// testNaturalOuter is testNaturalOuter from align_test.go:31
+type testNaturalOuter struct {
+	_ struct{} `d2b:"natural"`
+	X uint8
+	I testNaturalInner
+	Y uint16
+}
+
+type plainTestNaturalOuter struct {
+	_ struct{} `d2b:"natural"`
+	X uint8
+	I plainTestNaturalInner
+	Y uint16
+}
+
+// benchHeader is benchHeader from bench_test.go:11
+type benchHeader struct {
+	Version uint8 `d2b:"bits:4"`
+	Flags   uint8 `d2b:"bits:4"`
+	Type    uint8
+	Length  uint16 `d2b:"endian:big"`
+}
+
+type plainBenchHeader struct {
+	Version uint8 `d2b:"bits:4"`
+	Flags   uint8 `d2b:"bits:4"`
+	Type    uint8
+	Length  uint16 `d2b:"endian:big"`
+}
+
+// benchRecord is benchRecord from bench_test.go:18
+type benchRecord struct {
+	ID     uint32
+	Value  int64
+	Scale  float32
+	Active bool
+	Name   string `d2b:"length:16"`
+	Points [4]int16
+	Tags   []uint16 `d2b:"length:4"`
+}
+
+type plainBenchRecord struct {
+	ID     uint32
+	Value  int64
+	Scale  float32
+	Active bool
+	Name   string `d2b:"length:16"`
+	Points [4]int16
+	Tags   []uint16 `d2b:"length:4"`
+}
+
+// benchFrame is benchFrame from bench_test.go:28
+type benchFrame struct {
+	Header  benchHeader
+	Count   uint16
+	Records []benchRecord `d2b:"lengthfrom:Count"`
+	Comment string        `d2b:"lenprefix:uint8"`
+}
+
+type plainBenchFrame struct {
+	Header  plainBenchHeader
+	Count   uint16
+	Records []plainBenchRecord `d2b:"lengthfrom:Count"`
+	Comment string             `d2b:"lenprefix:uint8"`
+}
+
+// testMagicHeader is testMagicHeader from consts_test.go:11
+type testMagicHeader struct {
+	Magic   uint32 `d2b:"const:0xCAFEBABE"`
+	Version uint16
+}
+
+type plainTestMagicHeader struct {
+	Magic   uint32 `d2b:"const:0xCAFEBABE"`
+	Version uint16
+}
+
+// testHeader is testHeader from fields_test.go:10
+type testHeader struct {
+	Type   uint8
+	Length uint16
+}
+
+// testHeaderWithIP is testHeaderWithIP from fields_test.go:15
+type testHeaderWithIP struct {
+	testHeader
+	Addr testIPv4
+}
+
+// testUnexported is testUnexported from fields_test.go:20
+type testUnexported struct {
+	a    uint16
+	B    uint8
+	name string `d2b:"lenprefix:uint8"`
+	addr testIPv4
+	_    [2]byte
+	ptr  *int8
+}
+
+type plainTestUnexported struct {
+	a    uint16
+	B    uint8
+	name string `d2b:"lenprefix:uint8"`
+	addr testIPv4
+	_    [2]byte
+	ptr  *int8
+}
+
+// testIPv4 is testIPv4 from marshaler_test.go:13
+type testIPv4 uint32
+
+func (ip testIPv4) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(ip))
+	return b, nil
+}
+
+func (ip *testIPv4) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	*ip = testIPv4(binary.BigEndian.Uint32(data))
+	return 4, nil
+}
+
+func (ip testIPv4) SizeD2B() int {
+	return 4
+}
+
+// testBCDTime is testBCDTime from marshaler_test.go:31
+type testBCDTime struct {
+	Hours   int
+	Minutes int
+}
+
+func (t testBCDTime) MarshalBinary() ([]byte, error) {
+	if t.Hours > 99 || t.Minutes > 99 {
+		return nil, errors.New("value is too big")
+	}
+	return []byte{byte(t.Hours/10<<4 | t.Hours%10), byte(t.Minutes/10<<4 | t.Minutes%10)}, nil
+}
+
+func (t *testBCDTime) UnmarshalBinary(data []byte) error {
+	t.Hours = int(data[0]>>4)*10 + int(data[0]&0xF)
+	t.Minutes = int(data[1]>>4)*10 + int(data[1]&0xF)
+	return nil
+}
+
+func (t *testBCDTime) SizeD2B() int {
+	return 2
+}
+
+// testPascalString is testPascalString from marshaler_test.go:54
+type testPascalString string
+
+func (s testPascalString) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return append([]byte{byte(len(s))}, s...), nil
+}
+
+func (s *testPascalString) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	if len(data) < 1 || len(data) < int(data[0])+1 {
+		return 0, errors.New("not enough data")
+	}
+	*s = testPascalString(data[1 : data[0]+1])
+	return int(data[0]) + 1, nil
+}
+
+// AlignStruct is Struct from align_test.go:41
+type AlignStruct struct {
+	_ struct{} `d2b:"natural"`
+	A uint8
+	B uint32
+	C uint16
+	D [2]uint16
+}
+
+type plainAlignStruct struct {
+	_ struct{} `d2b:"natural"`
+	A uint8
+	B uint32
+	C uint16
+	D [2]uint16
+}
+
+// AlignStruct2 is Struct from align_test.go:103
+type AlignStruct2 struct {
+	A uint8
+	B uint16 `d2b:"align:4"`
+	S string `d2b:"lenprefix:uint8"`
+	C uint32 `d2b:"align:4"`
+}
+
+type plainAlignStruct2 struct {
+	A uint8
+	B uint16 `d2b:"align:4"`
+	S string `d2b:"lenprefix:uint8"`
+	C uint32 `d2b:"align:4"`
+}
+
+// AlignStruct3 is Struct from align_test.go:125
+type AlignStruct3 struct {
+	_ struct{} `d2b:"natural"`
+	A uint8
+	B uint32 `d2b:"align:2"`
+	C uint8  `d2b:"align:8"`
+}
+
+type plainAlignStruct3 struct {
+	_ struct{} `d2b:"natural"`
+	A uint8
+	B uint32 `d2b:"align:2"`
+	C uint8  `d2b:"align:8"`
+}
+
+// AlignNotPowerOfTwo is NotPowerOfTwo from align_test.go:139
+type AlignNotPowerOfTwo struct {
+	A uint8 `d2b:"align:3"`
+}
+
+type plainAlignNotPowerOfTwo struct {
+	A uint8 `d2b:"align:3"`
+}
+
+// AlignElem is Elem from align_test.go:142
+type AlignElem struct {
+	A [2]uint8 `d2b:"elem.align:2"`
+}
+
+type plainAlignElem struct {
+	A [2]uint8 `d2b:"elem.align:2"`
+}
+
+// AlignBoth is Both from align_test.go:145
+type AlignBoth struct {
+	_ struct{} `d2b:"pack,natural"`
+}
+
+type plainAlignBoth struct {
+	_ struct{} `d2b:"pack,natural"`
+}
+
+// AlignBitField is BitField from align_test.go:148
+type AlignBitField struct {
+	A uint8 `d2b:"bits:4"`
+	B uint8 `d2b:"bits:4,align:2"`
+}
+
+type plainAlignBitField struct {
+	A uint8 `d2b:"bits:4"`
+	B uint8 `d2b:"bits:4,align:2"`
+}
+
+// ReservedStruct is Struct from align_test.go:163
+type ReservedStruct struct {
+	A uint8
+	_ struct{} `d2b:"reserved:3"`
+	B uint16   `d2b:"reserved:2,fill:0xFF"`
+	C [2]byte  `d2b:"reserved:1,reserved:strict"`
+}
+
+type plainReservedStruct struct {
+	A uint8
+	_ struct{} `d2b:"reserved:3"`
+	B uint16   `d2b:"reserved:2,fill:0xFF"`
+	C [2]byte  `d2b:"reserved:1,reserved:strict"`
+}
+
+// ReservedFixed is Fixed from align_test.go:187
+type ReservedFixed struct {
+	S string `d2b:"length:2,reserved:1,strict"`
+}
+
+type plainReservedFixed struct {
+	S string `d2b:"length:2,reserved:1,strict"`
+}
+
+// ReservedPrefixed is Prefixed from align_test.go:190
+type ReservedPrefixed struct {
+	S string `d2b:"lenprefix:uint8,reserved:1,reserved:strict"`
+}
+
+type plainReservedPrefixed struct {
+	S string `d2b:"lenprefix:uint8,reserved:1,reserved:strict"`
+}
+
+// ReservedZero is Zero from align_test.go:208
+type ReservedZero struct {
+	_ struct{} `d2b:"reserved:0"`
+}
+
+type plainReservedZero struct {
+	_ struct{} `d2b:"reserved:0"`
+}
+
+// ReservedNegative is Negative from align_test.go:211
+type ReservedNegative struct {
+	_ struct{} `d2b:"reserved:-1"`
+}
+
+type plainReservedNegative struct {
+	_ struct{} `d2b:"reserved:-1"`
+}
+
+// ReservedFillWithoutReserved is FillWithoutReserved from align_test.go:214
+type ReservedFillWithoutReserved struct {
+	A uint8 `d2b:"fill:1"`
+}
+
+type plainReservedFillWithoutReserved struct {
+	A uint8 `d2b:"fill:1"`
+}
+
+// ReservedBigFill is BigFill from align_test.go:217
+type ReservedBigFill struct {
+	_ struct{} `d2b:"reserved:1,fill:256"`
+}
+
+type plainReservedBigFill struct {
+	_ struct{} `d2b:"reserved:1,fill:256"`
+}
+
+// ReservedElem is Elem from align_test.go:220
+type ReservedElem struct {
+	A [2]uint8 `d2b:"elem.reserved:1"`
+}
+
+type plainReservedElem struct {
+	A [2]uint8 `d2b:"elem.reserved:1"`
+}
+
+// ReservedStrictWithoutReserved is StrictWithoutReserved from align_test.go:223
+type ReservedStrictWithoutReserved struct {
+	A uint8 `d2b:"reserved:strict"`
+}
+
+type plainReservedStrictWithoutReserved struct {
+	A uint8 `d2b:"reserved:strict"`
+}
+
+// ReservedPadCount is PadCount from align_test.go:226
+type ReservedPadCount struct {
+	_ struct{} `d2b:"pad:2"`
+}
+
+type plainReservedPadCount struct {
+	_ struct{} `d2b:"pad:2"`
+}
+
+// OffsetHeader is Header from align_test.go:241
+type OffsetHeader struct {
+	Magic   [4]byte
+	Version uint16 `d2b:"offset:0x08"`
+	Count   uint32 `d2b:"offset:16"`
+}
+
+type plainOffsetHeader struct {
+	Magic   [4]byte
+	Version uint16 `d2b:"offset:0x08"`
+	Count   uint32 `d2b:"offset:16"`
+}
+
+// OffsetStruct is Struct from align_test.go:269
+type OffsetStruct struct {
+	Name string `d2b:"lenprefix:uint8"`
+	A    uint8  `d2b:"offset:4"`
+}
+
+type plainOffsetStruct struct {
+	Name string `d2b:"lenprefix:uint8"`
+	A    uint8  `d2b:"offset:4"`
+}
+
+// OffsetOverlap is Overlap from align_test.go:291
+type OffsetOverlap struct {
+	A uint32
+	B uint8 `d2b:"offset:2"`
+}
+
+type plainOffsetOverlap struct {
+	A uint32
+	B uint8 `d2b:"offset:2"`
+}
+
+// OffsetNegative is Negative from align_test.go:295
+type OffsetNegative struct {
+	A uint8 `d2b:"offset:-1"`
+}
+
+type plainOffsetNegative struct {
+	A uint8 `d2b:"offset:-1"`
+}
+
+// OffsetNotNumber is NotNumber from align_test.go:298
+type OffsetNotNumber struct {
+	A uint8 `d2b:"offset:x"`
+}
+
+type plainOffsetNotNumber struct {
+	A uint8 `d2b:"offset:x"`
+}
+
+// OffsetWithAlign is WithAlign from align_test.go:301
+type OffsetWithAlign struct {
+	A uint8 `d2b:"offset:4,align:4"`
+}
+
+type plainOffsetWithAlign struct {
+	A uint8 `d2b:"offset:4,align:4"`
+}
+
+// BenchmarkDecoderStringsStruct is Struct from bench_test.go:82
+type BenchmarkDecoderStringsStruct struct {
+	Names []string `d2b:"lenprefix:uint16,elem.lenprefix:uint8"`
+}
+
+type plainBenchmarkDecoderStringsStruct struct {
+	Names []string `d2b:"lenprefix:uint16,elem.lenprefix:uint8"`
+}
+
+// BenchmarkDecoderCStringStruct is Struct from bench_test.go:107
+type BenchmarkDecoderCStringStruct struct {
+	Text string `d2b:"cstring"`
+}
+
+type plainBenchmarkDecoderCStringStruct struct {
+	Text string `d2b:"cstring"`
+}
+
+// BitFieldsHeader is Header from bits_test.go:14
+type BitFieldsHeader struct {
+	Version uint8 `d2b:"bits:3"`
+	Flag    bool  `d2b:"bits:1"`
+	Type    uint8 `d2b:"bits:4"`
+	Length  uint16
+	Offset  int16  `d2b:"bits:13"`
+	Mode    uint32 `d2b:"bits:3"`
+}
+
+type plainBitFieldsHeader struct {
+	Version uint8 `d2b:"bits:3"`
+	Flag    bool  `d2b:"bits:1"`
+	Type    uint8 `d2b:"bits:4"`
+	Length  uint16
+	Offset  int16  `d2b:"bits:13"`
+	Mode    uint32 `d2b:"bits:3"`
+}
+
+// BitFieldsLSBHeader is LSBHeader from bits_test.go:36
+type BitFieldsLSBHeader struct {
+	_       struct{} `d2b:"bitorder:lsb"`
+	Version uint8    `d2b:"bits:3"`
+	Flag    bool     `d2b:"bits:1"`
+	Type    uint8    `d2b:"bits:4"`
+	Length  uint16
+	Offset  int16  `d2b:"bits:13"`
+	Mode    uint32 `d2b:"bits:3"`
+}
+
+type plainBitFieldsLSBHeader struct {
+	_       struct{} `d2b:"bitorder:lsb"`
+	Version uint8    `d2b:"bits:3"`
+	Flag    bool     `d2b:"bits:1"`
+	Type    uint8    `d2b:"bits:4"`
+	Length  uint16
+	Offset  int16  `d2b:"bits:13"`
+	Mode    uint32 `d2b:"bits:3"`
+}
+
+// BitFieldsNotWholeByte is NotWholeByte from bits_test.go:85
+type BitFieldsNotWholeByte struct {
+	A uint8 `d2b:"bits:3"`
+	B uint8 `d2b:"bits:4"`
+	C uint8
+}
+
+type plainBitFieldsNotWholeByte struct {
+	A uint8 `d2b:"bits:3"`
+	B uint8 `d2b:"bits:4"`
+	C uint8
+}
+
+// BitFieldsTooWide is TooWide from bits_test.go:90
+type BitFieldsTooWide struct {
+	A uint8 `d2b:"bits:9"`
+}
+
+type plainBitFieldsTooWide struct {
+	A uint8 `d2b:"bits:9"`
+}
+
+// BitFieldsWrongType is WrongType from bits_test.go:93
+type BitFieldsWrongType struct {
+	A string `d2b:"bits:8"`
+}
+
+type plainBitFieldsWrongType struct {
+	A string `d2b:"bits:8"`
+}
+
+// BitFieldsWrongOrder is WrongOrder from bits_test.go:96
+type BitFieldsWrongOrder struct {
+	_ struct{} `d2b:"bitorder:middle"`
+}
+
+type plainBitFieldsWrongOrder struct {
+	_ struct{} `d2b:"bitorder:middle"`
+}
+
+// BitFieldsTooLongGroup is TooLongGroup from bits_test.go:99
+type BitFieldsTooLongGroup struct {
+	A uint64 `d2b:"bits:64"`
+	B uint8  `d2b:"bits:8"`
+}
+
+type plainBitFieldsTooLongGroup struct {
+	A uint64 `d2b:"bits:64"`
+	B uint8  `d2b:"bits:8"`
+}
+
+// BitFieldsAutoFilled is AutoFilled from bits_test.go:103
+type BitFieldsAutoFilled struct {
+	Count uint8  `d2b:"bits:4"`
+	Flags uint8  `d2b:"bits:4"`
+	Data  []byte `d2b:"lengthfrom:Count,autofill"`
+}
+
+type plainBitFieldsAutoFilled struct {
+	Count uint8  `d2b:"bits:4"`
+	Flags uint8  `d2b:"bits:4"`
+	Data  []byte `d2b:"lengthfrom:Count,autofill"`
+}
+
+// CharsetsStruct is Struct from charsets_test.go:14
+type CharsetsStruct struct {
+	A string  `d2b:"length:4,charset:utf16le"`
+	B string  `d2b:"lenprefix:uint8,charset:utf16be"`
+	C *string `d2b:"cstring,charset:utf16le"`
+	D string  `d2b:"lenprefix:uint16,charset:utf16le,lenunit:bytes"`
+}
+
+type plainCharsetsStruct struct {
+	A string  `d2b:"length:4,charset:utf16le"`
+	B string  `d2b:"lenprefix:uint8,charset:utf16be"`
+	C *string `d2b:"cstring,charset:utf16le"`
+	D string  `d2b:"lenprefix:uint16,charset:utf16le,lenunit:bytes"`
+}
+
+// CharsetsStruct2 is Struct from charsets_test.go:44
+type CharsetsStruct2 struct {
+	N uint8
+	A string `d2b:"lengthfrom:N,autofill,charset:utf16le"`
+}
+
+type plainCharsetsStruct2 struct {
+	N uint8
+	A string `d2b:"lengthfrom:N,autofill,charset:utf16le"`
+}
+
+// CharsetsStruct3 is Struct from charsets_test.go:57
+type CharsetsStruct3 struct {
+	A string `d2b:"length:2,charset:utf16le"`
+	B string `d2b:"length:3,charset:utf16le,pad:space,trim:right"`
+	C string `d2b:"length:2,charset:utf16le,strict"`
+}
+
+type plainCharsetsStruct3 struct {
+	A string `d2b:"length:2,charset:utf16le"`
+	B string `d2b:"length:3,charset:utf16le,pad:space,trim:right"`
+	C string `d2b:"length:2,charset:utf16le,strict"`
+}
+
+// CharsetsStruct4 is Struct from charsets_test.go:74
+type CharsetsStruct4 struct {
+	A string `d2b:"length:3,charset:cp1252"`
+	B string `d2b:"length:3,charset:latin1"`
+	C string `d2b:"length:6,charset:ebcdic,pad:space,trim:right"`
+}
+
+type plainCharsetsStruct4 struct {
+	A string `d2b:"length:3,charset:cp1252"`
+	B string `d2b:"length:3,charset:latin1"`
+	C string `d2b:"length:6,charset:ebcdic,pad:space,trim:right"`
+}
+
+// CharsetsReplace is Replace from charsets_test.go:89
+type CharsetsReplace struct {
+	A string `d2b:"length:3,charset:latin1"`
+}
+
+type plainCharsetsReplace struct {
+	A string `d2b:"length:3,charset:latin1"`
+}
+
+// CharsetsSkip is Skip from charsets_test.go:92
+type CharsetsSkip struct {
+	A string `d2b:"length:3,charset:latin1,replace:skip"`
+}
+
+type plainCharsetsSkip struct {
+	A string `d2b:"length:3,charset:latin1,replace:skip"`
+}
+
+// CharsetsError is Error from charsets_test.go:95
+type CharsetsError struct {
+	A string `d2b:"length:3,charset:latin1,replace:error"`
+}
+
+type plainCharsetsError struct {
+	A string `d2b:"length:3,charset:latin1,replace:error"`
+}
+
+// CharsetsReplace2 is Replace from charsets_test.go:108
+type CharsetsReplace2 struct {
+	A string `d2b:"length:2,charset:cp1252"`
+}
+
+type plainCharsetsReplace2 struct {
+	A string `d2b:"length:2,charset:cp1252"`
+}
+
+// CharsetsSkip2 is Skip from charsets_test.go:111
+type CharsetsSkip2 struct {
+	A string `d2b:"length:2,charset:cp1252,replace:skip"`
+}
+
+type plainCharsetsSkip2 struct {
+	A string `d2b:"length:2,charset:cp1252,replace:skip"`
+}
+
+// CharsetsError2 is Error from charsets_test.go:114
+type CharsetsError2 struct {
+	A string `d2b:"length:2,charset:cp1252,replace:error"`
+}
+
+type plainCharsetsError2 struct {
+	A string `d2b:"length:2,charset:cp1252,replace:error"`
+}
+
+// CharsetsStruct5 is Struct from charsets_test.go:132
+type CharsetsStruct5 struct {
+	A string `d2b:"length:3,charset:test-binary"`
+}
+
+type plainCharsetsStruct5 struct {
+	A string `d2b:"length:3,charset:test-binary"`
+}
+
+// CharsetsOverread is Overread from charsets_test.go:142
+type CharsetsOverread struct {
+	A string `d2b:"length:2,charset:test-overread"`
+}
+
+type plainCharsetsOverread struct {
+	A string `d2b:"length:2,charset:test-overread"`
+}
+
+// CharsetsZeroUnit is ZeroUnit from charsets_test.go:145
+type CharsetsZeroUnit struct {
+	A string `d2b:"cstring,charset:test-zero-unit"`
+}
+
+type plainCharsetsZeroUnit struct {
+	A string `d2b:"cstring,charset:test-zero-unit"`
+}
+
+// CharsetsStruct6 is Struct from charsets_test.go:152
+type CharsetsStruct6 struct {
+	A string `d2b:"cstring,charset:utf16le"`
+	B uint8
+}
+
+type plainCharsetsStruct6 struct {
+	A string `d2b:"cstring,charset:utf16le"`
+	B uint8
+}
+
+// CharsetsUnknown is Unknown from charsets_test.go:162
+type CharsetsUnknown struct {
+	A string `d2b:"length:2,charset:koi8"`
+}
+
+type plainCharsetsUnknown struct {
+	A string `d2b:"length:2,charset:koi8"`
+}
+
+// CharsetsNotString is NotString from charsets_test.go:165
+type CharsetsNotString struct {
+	A []byte `d2b:"length:2,charset:utf16le"`
+}
+
+type plainCharsetsNotString struct {
+	A []byte `d2b:"length:2,charset:utf16le"`
+}
+
+// CharsetsWithoutCharset is WithoutCharset from charsets_test.go:168
+type CharsetsWithoutCharset struct {
+	A string `d2b:"length:2,replace:skip"`
+}
+
+type plainCharsetsWithoutCharset struct {
+	A string `d2b:"length:2,replace:skip"`
+}
+
+// CharsetsOddLength is OddLength from charsets_test.go:171
+type CharsetsOddLength struct {
+	A string `d2b:"length:3,charset:utf16le,lenunit:bytes"`
+}
+
+type plainCharsetsOddLength struct {
+	A string `d2b:"length:3,charset:utf16le,lenunit:bytes"`
+}
+
+// CharsetsUnknownPolicy is UnknownPolicy from charsets_test.go:174
+type CharsetsUnknownPolicy struct {
+	A string `d2b:"length:2,charset:utf16le,replace:drop"`
+}
+
+type plainCharsetsUnknownPolicy struct {
+	A string `d2b:"length:2,charset:utf16le,replace:drop"`
+}
+
+// CompileStruct is Struct from codec_test.go:13
+type CompileStruct struct {
+	A uint16
+	B string `d2b:"length:3"`
+	C []int8 `d2b:"lenprefix:uint8"`
+}
+
+type plainCompileStruct struct {
+	A uint16
+	B string `d2b:"length:3"`
+	C []int8 `d2b:"lenprefix:uint8"`
+}
+
+// CompileFixed is Fixed from codec_test.go:40
+type CompileFixed struct {
+	A uint16
+	B [3]int32
+	C *bool
+}
+
+type plainCompileFixed struct {
+	A uint16
+	B [3]int32
+	C *bool
+}
+
+// CompileNode is Node from codec_test.go:68
+type CompileNode struct {
+	Value uint8
+	Next  *CompileNode
+}
+
+type plainCompileNode struct {
+	Value uint8
+	Next  *plainCompileNode
+}
+
+// ConstStruct is Struct from consts_test.go:19
+type ConstStruct struct {
+	A [4]byte `d2b:"const:\"\\x89PNG\""`
+	B uint32  `d2b:"const:0xCAFEBABE,endian:big"`
+	C string  `d2b:"const:\"a,b\""`
+	D []byte  `d2b:"const:\"\\r\\n\\x00\""`
+	E *int8   `d2b:"const:-2"`
+	F uint8
+}
+
+type plainConstStruct struct {
+	A [4]byte `d2b:"const:\"\\x89PNG\""`
+	B uint32  `d2b:"const:0xCAFEBABE,endian:big"`
+	C string  `d2b:"const:\"a,b\""`
+	D []byte  `d2b:"const:\"\\r\\n\\x00\""`
+	E *int8   `d2b:"const:-2"`
+	F uint8
+}
+
+// ConstStruct2 is Struct from consts_test.go:50
+type ConstStruct2 struct {
+	Headers [2]testMagicHeader
+}
+
+type plainConstStruct2 struct {
+	Headers [2]plainTestMagicHeader
+}
+
+// ConstStruct3 is Struct from consts_test.go:68
+type ConstStruct3 struct {
+	A [4]byte `d2b:"const:\"\\x89PNG\""`
+	C string  `d2b:"const:\"ok\""`
+}
+
+type plainConstStruct3 struct {
+	A [4]byte `d2b:"const:\"\\x89PNG\""`
+	C string  `d2b:"const:\"ok\""`
+}
+
+// ConstOverflow is Overflow from consts_test.go:79
+type ConstOverflow struct {
+	A uint8 `d2b:"const:256"`
+}
+
+type plainConstOverflow struct {
+	A uint8 `d2b:"const:256"`
+}
+
+// ConstNotQuoted is NotQuoted from consts_test.go:82
+type ConstNotQuoted struct {
+	A string `d2b:"const:PNG"`
+}
+
+type plainConstNotQuoted struct {
+	A string `d2b:"const:PNG"`
+}
+
+// ConstWrongLength is WrongLength from consts_test.go:85
+type ConstWrongLength struct {
+	A [2]byte `d2b:"const:\"abc\""`
+}
+
+type plainConstWrongLength struct {
+	A [2]byte `d2b:"const:\"abc\""`
+}
+
+// ConstFloat is Float from consts_test.go:88
+type ConstFloat struct {
+	A float32 `d2b:"const:1"`
+}
+
+type plainConstFloat struct {
+	A float32 `d2b:"const:1"`
+}
+
+// ConstWithLength is WithLength from consts_test.go:91
+type ConstWithLength struct {
+	A string `d2b:"const:\"ab\",length:2"`
+}
+
+type plainConstWithLength struct {
+	A string `d2b:"const:\"ab\",length:2"`
+}
+
+// ConstWithBits is WithBits from consts_test.go:94
+type ConstWithBits struct {
+	A uint8 `d2b:"const:1,bits:8"`
+}
+
+type plainConstWithBits struct {
+	A uint8 `d2b:"const:1,bits:8"`
+}
+
+// ConstAutoFilled is AutoFilled from consts_test.go:97
+type ConstAutoFilled struct {
+	N uint8  `d2b:"const:2"`
+	A []byte `d2b:"lengthfrom:N,autofill"`
+}
+
+type plainConstAutoFilled struct {
+	N uint8  `d2b:"const:2"`
+	A []byte `d2b:"lengthfrom:N,autofill"`
+}
+
+// DecodeStruct is Struct from decode_test.go:63
+type DecodeStruct struct {
+	A uint16
+	B uint32
+	C uint64
+}
+
+type plainDecodeStruct struct {
+	A uint16
+	B uint32
+	C uint64
+}
+
+// DecodeStruct2 is Struct from decode_test.go:103
+type DecodeStruct2 struct {
+	A     *[]int32  `d2b:"length:2"`
+	B     *[]uint32 `d2b:"length:2"`
+	C     [2]int32
+	D     int    `d2b:"-"`
+	Test  string `d2b:"length:6"`
+	Test1 string `d2b:"length:4"`
+}
+
+type plainDecodeStruct2 struct {
+	A     *[]int32  `d2b:"length:2"`
+	B     *[]uint32 `d2b:"length:2"`
+	C     [2]int32
+	D     int    `d2b:"-"`
+	Test  string `d2b:"length:6"`
+	Test1 string `d2b:"length:4"`
+}
+
+// DecodeItem is Item from decode_test.go:139
+type DecodeItem struct {
+	ID   int16
+	Name string `d2b:"length:4"`
+}
+
+type plainDecodeItem struct {
+	ID   int16
+	Name string `d2b:"length:4"`
+}
+
+// DecodeStruct3 is Struct from decode_test.go:143
+type DecodeStruct3 struct {
+	A     int8
+	Items []DecodeItem `d2b:"length:2"`
+}
+
+type plainDecodeStruct3 struct {
+	A     int8
+	Items []plainDecodeItem `d2b:"length:2"`
+}
+
+// DecodeItem2 is Item from decode_test.go:170
+type DecodeItem2 struct {
+	ID   uint8
+	Name string `d2b:"lenprefix:uint8"`
+}
+
+type plainDecodeItem2 struct {
+	ID   uint8
+	Name string `d2b:"lenprefix:uint8"`
+}
+
+// DecodeStruct4 is Struct from decode_test.go:174
+type DecodeStruct4 struct {
+	A string        `d2b:"lenprefix:uint8"`
+	B []uint16      `d2b:"lenprefix:uint16"`
+	C []DecodeItem2 `d2b:"lenprefix:uint8"`
+}
+
+type plainDecodeStruct4 struct {
+	A string             `d2b:"lenprefix:uint8"`
+	B []uint16           `d2b:"lenprefix:uint16"`
+	C []plainDecodeItem2 `d2b:"lenprefix:uint8"`
+}
+
+// DecodeStruct5 is Struct from decode_test.go:193
+type DecodeStruct5 struct {
+	A []uint32 `d2b:"lenprefix:uint16"`
+}
+
+type plainDecodeStruct5 struct {
+	A []uint32 `d2b:"lenprefix:uint16"`
+}
+
+// DecodeStruct6 is Struct from decode_test.go:205
+type DecodeStruct6 struct {
+	A string `d2b:"lenprefix:uint8,length:2"`
+}
+
+type plainDecodeStruct6 struct {
+	A string `d2b:"lenprefix:uint8,length:2"`
+}
+
+// DecodeStruct7 is Struct from decode_test.go:214
+type DecodeStruct7 struct {
+	Count   uint8
+	Size    *int16
+	Records []int16 `d2b:"lengthfrom:Count"`
+	Text    string  `d2b:"lengthfrom:Size"`
+}
+
+type plainDecodeStruct7 struct {
+	Count   uint8
+	Size    *int16
+	Records []int16 `d2b:"lengthfrom:Count"`
+	Text    string  `d2b:"lengthfrom:Size"`
+}
+
+// DecodeStruct8 is Struct from decode_test.go:228
+type DecodeStruct8 struct {
+	Count   int8
+	Records []int16 `d2b:"lengthfrom:Count,length:2"`
+}
+
+type plainDecodeStruct8 struct {
+	Count   int8
+	Records []int16 `d2b:"lengthfrom:Count,length:2"`
+}
+
+// DecodePrefixed is Prefixed from decode_test.go:241
+type DecodePrefixed struct {
+	A []struct{} `d2b:"lenprefix:uint32"`
+}
+
+type plainDecodePrefixed struct {
+	A []struct{} `d2b:"lenprefix:uint32"`
+}
+
+// DecodeLengthFrom is LengthFrom from decode_test.go:244
+type DecodeLengthFrom struct {
+	Count uint32
+	A     []struct{} `d2b:"lengthfrom:Count"`
+}
+
+type plainDecodeLengthFrom struct {
+	Count uint32
+	A     []struct{} `d2b:"lengthfrom:Count"`
+}
+
+// DecodeMap is Map from decode_test.go:248
+type DecodeMap struct {
+	A map[struct{}]struct{} `d2b:"lenprefix:uint32"`
+}
+
+type plainDecodeMap struct {
+	A map[struct{}]struct{} `d2b:"lenprefix:uint32"`
+}
+
+// DecodePayload is Payload from decode_test.go:257
+type DecodePayload struct {
+	A uint16
+	B uint16 `d2b:"endian:big"`
+}
+
+type plainDecodePayload struct {
+	A uint16
+	B uint16 `d2b:"endian:big"`
+}
+
+// DecodeFrame is Frame from decode_test.go:261
+type DecodeFrame struct {
+	Length  uint16         `d2b:"endian:big"`
+	Payload *DecodePayload `d2b:"endian:little"`
+	Array   [2]uint16      `d2b:"endian:little"`
+	Slice   []uint16       `d2b:"lengthfrom:Length,endian:little"`
+	Tail    uint16
+}
+
+type plainDecodeFrame struct {
+	Length  uint16              `d2b:"endian:big"`
+	Payload *plainDecodePayload `d2b:"endian:little"`
+	Array   [2]uint16           `d2b:"endian:little"`
+	Slice   []uint16            `d2b:"lengthfrom:Length,endian:little"`
+	Tail    uint16
+}
+
+// DecodeStruct9 is Struct from decode_test.go:280
+type DecodeStruct9 struct {
+	A bool
+	B *bool
+	C [3]bool
+	D bool `d2b:"bool:strict"`
+}
+
+type plainDecodeStruct9 struct {
+	A bool
+	B *bool
+	C [3]bool
+	D bool `d2b:"bool:strict"`
+}
+
+// DecodeStruct10 is Struct from decode_test.go:299
+type DecodeStruct10 struct {
+	A bool `d2b:"bool:strict"`
+}
+
+type plainDecodeStruct10 struct {
+	A bool `d2b:"bool:strict"`
+}
+
+// DecodeBadTag is BadTag from decode_test.go:309
+type DecodeBadTag struct {
+	A uint8 `d2b:"bool:strict"`
+}
+
+type plainDecodeBadTag struct {
+	A uint8 `d2b:"bool:strict"`
+}
+
+// DecodeStruct11 is Struct from decode_test.go:316
+type DecodeStruct11 struct {
+	A int  `d2b:"size:1"`
+	B uint `d2b:"size:2"`
+	C *int `d2b:"size:4"`
+}
+
+type plainDecodeStruct11 struct {
+	A int  `d2b:"size:1"`
+	B uint `d2b:"size:2"`
+	C *int `d2b:"size:4"`
+}
+
+// DecodeStruct12 is Struct from decode_test.go:336
+type DecodeStruct12 struct {
+	A string `d2b:"cstring"`
+	B string `d2b:"cstring"`
+	C string `d2b:"length:4,cstring"`
+}
+
+type plainDecodeStruct12 struct {
+	A string `d2b:"cstring"`
+	B string `d2b:"cstring"`
+	C string `d2b:"length:4,cstring"`
+}
+
+// DecodeStruct13 is Struct from decode_test.go:350
+type DecodeStruct13 struct {
+	A string `d2b:"length:4,pad:space"`
+	B string `d2b:"length:4,pad:space,trim:right"`
+	C string `d2b:"length:4,trim:right"`
+}
+
+type plainDecodeStruct13 struct {
+	A string `d2b:"length:4,pad:space"`
+	B string `d2b:"length:4,pad:space,trim:right"`
+	C string `d2b:"length:4,trim:right"`
+}
+
+// DecodeStruct14 is Struct from decode_test.go:379
+type DecodeStruct14 struct {
+	A int
+}
+
+type plainDecodeStruct14 struct {
+	A int
+}
+
+// DecodeStruct15 is Struct from decode_test.go:390
+type DecodeStruct15 struct {
+	A string `d2b:"length:hello"`
+}
+
+type plainDecodeStruct15 struct {
+	A string `d2b:"length:hello"`
+}
+
+// DecodeStruct16 is Struct from decode_test.go:401
+type DecodeStruct16 struct{ A string }
+
+type plainDecodeStruct16 struct{ A string }
+
+// DecodeStruct17 is Struct from decode_test.go:410
+type DecodeStruct17 struct{ A []int32 }
+
+type plainDecodeStruct17 struct{ A []int32 }
+
+// DecodeStruct18 is Struct from decode_test.go:419
+type DecodeStruct18 struct {
+	A []int `d2b:"length:2"`
+}
+
+type plainDecodeStruct18 struct {
+	A []int `d2b:"length:2"`
+}
+
+// DecodeStruct19 is Struct from decode_test.go:430
+type DecodeStruct19 struct {
+	A []int `d2b:"length:2"`
+}
+
+type plainDecodeStruct19 struct {
+	A []int `d2b:"length:2"`
+}
+
+// DecodeStruct20 is Struct from decode_test.go:441
+type DecodeStruct20 struct {
+	A [2]int
+}
+
+type plainDecodeStruct20 struct {
+	A [2]int
+}
+
+// DecoderRecord is Record from decoder_test.go:33
+type DecoderRecord struct {
+	ID   uint16
+	Name string `d2b:"length:4"`
+	Data [2]int8
+}
+
+type plainDecoderRecord struct {
+	ID   uint16
+	Name string `d2b:"length:4"`
+	Data [2]int8
+}
+
+// DecoderMessage is Message from decoder_test.go:52
+type DecoderMessage struct {
+	Type uint8
+	Body []uint16 `d2b:"lenprefix:uint8"`
+	Text string   `d2b:"lenprefix:uint8"`
+}
+
+type plainDecoderMessage struct {
+	Type uint8
+	Body []uint16 `d2b:"lenprefix:uint8"`
+	Text string   `d2b:"lenprefix:uint8"`
+}
+
+// DecoderStruct is Struct from decoder_test.go:71
+type DecoderStruct struct {
+	A string `d2b:"cstring"`
+	B uint8
+}
+
+type plainDecoderStruct struct {
+	A string `d2b:"cstring"`
+	B uint8
+}
+
+// DecoderStruct2 is Struct from decoder_test.go:83
+type DecoderStruct2 struct {
+	A string `d2b:"cstring,charset:utf16le"`
+	B uint8
+}
+
+type plainDecoderStruct2 struct {
+	A string `d2b:"cstring,charset:utf16le"`
+	B uint8
+}
+
+// DecoderStruct4 is Struct from decoder_test.go:129
+type DecoderStruct4 struct {
+	A string
+}
+
+type plainDecoderStruct4 struct {
+	A string
+}
+
+// EncodeCustomType is CustomType from encode_test.go:14
+type EncodeCustomType [5][2]int16
+
+// EncodeTestStruct1 is TestStruct1 from encode_test.go:15
+type EncodeTestStruct1 struct {
+	FieldA string  `d2b:"length:10"`
+	FieldB string  `d2b:"length:10"`
+	Slice  []int32 `d2b:"length:2"`
+	A      EncodeCustomType
+	QQ     *int32
+	B      int16
+	C      int32
+	D      int64
+	E      uint8
+	F      uint16
+	G      uint32
+	H      uint64
+	EE     string `d2b:"-"`
+	I      int8
+	Q      *uint8
+}
+
+type plainEncodeTestStruct1 struct {
+	FieldA string  `d2b:"length:10"`
+	FieldB string  `d2b:"length:10"`
+	Slice  []int32 `d2b:"length:2"`
+	A      EncodeCustomType
+	QQ     *int32
+	B      int16
+	C      int32
+	D      int64
+	E      uint8
+	F      uint16
+	G      uint32
+	H      uint64
+	EE     string `d2b:"-"`
+	I      int8
+	Q      *uint8
+}
+
+// EncodeStruct is Struct from encode_test.go:65
+type EncodeStruct struct {
+	A string   `d2b:"lenprefix:uint8"`
+	B []uint16 `d2b:"lenprefix:uint16"`
+	C *string  `d2b:"lenprefix:uint32"`
+	D []int8   `d2b:"lenprefix:uint8,length:2"`
+}
+
+type plainEncodeStruct struct {
+	A string   `d2b:"lenprefix:uint8"`
+	B []uint16 `d2b:"lenprefix:uint16"`
+	C *string  `d2b:"lenprefix:uint32"`
+	D []int8   `d2b:"lenprefix:uint8,length:2"`
+}
+
+// EncodeStruct2 is Struct from encode_test.go:81
+type EncodeStruct2 struct {
+	A []int8 `d2b:"lenprefix:uint8,length:2"`
+}
+
+type plainEncodeStruct2 struct {
+	A []int8 `d2b:"lenprefix:uint8,length:2"`
+}
+
+// EncodeStruct22 is Struct2 from encode_test.go:88
+type EncodeStruct22 struct {
+	A []int8 `d2b:"lenprefix:uint8"`
+}
+
+type plainEncodeStruct22 struct {
+	A []int8 `d2b:"lenprefix:uint8"`
+}
+
+// EncodeStruct3 is Struct from encode_test.go:96
+type EncodeStruct3 struct {
+	A []int8 `d2b:"lenprefix:int128"`
+}
+
+type plainEncodeStruct3 struct {
+	A []int8 `d2b:"lenprefix:int128"`
+}
+
+// EncodeStruct23 is Struct2 from encode_test.go:101
+type EncodeStruct23 struct {
+	A int8 `d2b:"lenprefix:uint8"`
+}
+
+type plainEncodeStruct23 struct {
+	A int8 `d2b:"lenprefix:uint8"`
+}
+
+// EncodeStruct4 is Struct from encode_test.go:108
+type EncodeStruct4 struct {
+	Count   uint8
+	Name    string  `d2b:"length:2"`
+	Records []int16 `d2b:"lengthfrom:Count"`
+}
+
+type plainEncodeStruct4 struct {
+	Count   uint8
+	Name    string  `d2b:"length:2"`
+	Records []int16 `d2b:"lengthfrom:Count"`
+}
+
+// EncodeStruct5 is Struct from encode_test.go:121
+type EncodeStruct5 struct {
+	Count  *int16
+	Keys   []uint8 `d2b:"lengthfrom:Count,autofill"`
+	Values []uint8 `d2b:"lengthfrom:Count,autofill"`
+	Size   uint8
+	Text   *string `d2b:"lengthfrom:Size,autofill,length:4"`
+}
+
+type plainEncodeStruct5 struct {
+	Count  *int16
+	Keys   []uint8 `d2b:"lengthfrom:Count,autofill"`
+	Values []uint8 `d2b:"lengthfrom:Count,autofill"`
+	Size   uint8
+	Text   *string `d2b:"lengthfrom:Size,autofill,length:4"`
+}
+
+// EncodeMissing is Missing from encode_test.go:142
+type EncodeMissing struct {
+	A []int8 `d2b:"lengthfrom:Count"`
+}
+
+type plainEncodeMissing struct {
+	A []int8 `d2b:"lengthfrom:Count"`
+}
+
+// EncodeAfter is After from encode_test.go:145
+type EncodeAfter struct {
+	A     []int8 `d2b:"lengthfrom:Count"`
+	Count uint8
+}
+
+type plainEncodeAfter struct {
+	A     []int8 `d2b:"lengthfrom:Count"`
+	Count uint8
+}
+
+// EncodeNotInteger is NotInteger from encode_test.go:149
+type EncodeNotInteger struct {
+	Count string `d2b:"length:1"`
+	A     []int8 `d2b:"lengthfrom:Count"`
+}
+
+type plainEncodeNotInteger struct {
+	Count string `d2b:"length:1"`
+	A     []int8 `d2b:"lengthfrom:Count"`
+}
+
+// EncodeNotSlice is NotSlice from encode_test.go:153
+type EncodeNotSlice struct {
+	Count uint8
+	A     int8 `d2b:"lengthfrom:Count"`
+}
+
+type plainEncodeNotSlice struct {
+	Count uint8
+	A     int8 `d2b:"lengthfrom:Count"`
+}
+
+// EncodeWithPrefix is WithPrefix from encode_test.go:157
+type EncodeWithPrefix struct {
+	Count uint8
+	A     []int8 `d2b:"lengthfrom:Count,lenprefix:uint8"`
+}
+
+type plainEncodeWithPrefix struct {
+	Count uint8
+	A     []int8 `d2b:"lengthfrom:Count,lenprefix:uint8"`
+}
+
+// EncodeWithoutLengthFrom is WithoutLengthFrom from encode_test.go:161
+type EncodeWithoutLengthFrom struct {
+	A []int8 `d2b:"length:2,autofill"`
+}
+
+type plainEncodeWithoutLengthFrom struct {
+	A []int8 `d2b:"length:2,autofill"`
+}
+
+// EncodePayload is Payload from encode_test.go:172
+type EncodePayload struct {
+	A uint16
+	B uint16 `d2b:"endian:big"`
+}
+
+type plainEncodePayload struct {
+	A uint16
+	B uint16 `d2b:"endian:big"`
+}
+
+// EncodeFrame is Frame from encode_test.go:176
+type EncodeFrame struct {
+	Length  uint16        `d2b:"endian:big"`
+	Payload EncodePayload `d2b:"endian:little"`
+	Array   [2]uint16     `d2b:"endian:little"`
+	Slice   []uint16      `d2b:"lenprefix:uint16,endian:little"`
+	Tail    uint16
+}
+
+type plainEncodeFrame struct {
+	Length  uint16             `d2b:"endian:big"`
+	Payload plainEncodePayload `d2b:"endian:little"`
+	Array   [2]uint16          `d2b:"endian:little"`
+	Slice   []uint16           `d2b:"lenprefix:uint16,endian:little"`
+	Tail    uint16
+}
+
+// EncodeStruct6 is Struct from encode_test.go:204
+type EncodeStruct6 struct {
+	A uint16 `d2b:"endian:middle"`
+}
+
+type plainEncodeStruct6 struct {
+	A uint16 `d2b:"endian:middle"`
+}
+
+// EncodeStruct7 is Struct from encode_test.go:212
+type EncodeStruct7 struct {
+	A float32
+	B *float64
+	C [2]float32
+	D float32  `d2b:"float16"`
+	E *float64 `d2b:"float16"`
+}
+
+type plainEncodeStruct7 struct {
+	A float32
+	B *float64
+	C [2]float32
+	D float32  `d2b:"float16"`
+	E *float64 `d2b:"float16"`
+}
+
+// EncodeStruct8 is Struct from encode_test.go:238
+type EncodeStruct8 struct {
+	A int32 `d2b:"float16"`
+}
+
+type plainEncodeStruct8 struct {
+	A int32 `d2b:"float16"`
+}
+
+// EncodeStructWithBool is StructWithBool from encode_test.go:246
+type EncodeStructWithBool struct {
+	A bool
+	B int16
+}
+
+type plainEncodeStructWithBool struct {
+	A bool
+	B int16
+}
+
+// EncodeStruct9 is Struct from encode_test.go:259
+type EncodeStruct9 struct {
+	A int  `d2b:"size:1"`
+	B uint `d2b:"size:2"`
+	C *int `d2b:"size:4"`
+	D int  `d2b:"size:8"`
+}
+
+type plainEncodeStruct9 struct {
+	A int  `d2b:"size:1"`
+	B uint `d2b:"size:2"`
+	C *int `d2b:"size:4"`
+	D int  `d2b:"size:8"`
+}
+
+// EncodeStruct10 is Struct from encode_test.go:276
+type EncodeStruct10 struct {
+	A int  `d2b:"size:1"`
+	B uint `d2b:"size:2"`
+}
+
+type plainEncodeStruct10 struct {
+	A int  `d2b:"size:1"`
+	B uint `d2b:"size:2"`
+}
+
+// EncodeWrongSize is WrongSize from encode_test.go:288
+type EncodeWrongSize struct {
+	A int `d2b:"size:3"`
+}
+
+type plainEncodeWrongSize struct {
+	A int `d2b:"size:3"`
+}
+
+// EncodeWrongType is WrongType from encode_test.go:291
+type EncodeWrongType struct {
+	A int16 `d2b:"size:4"`
+}
+
+type plainEncodeWrongType struct {
+	A int16 `d2b:"size:4"`
+}
+
+// EncodeStruct11 is Struct from encode_test.go:301
+type EncodeStruct11 struct {
+	A string  `d2b:"cstring"`
+	B string  `d2b:"length:6,pad:space"`
+	C *string `d2b:"length:4,cstring"`
+	D string  `d2b:"length:3"`
+}
+
+type plainEncodeStruct11 struct {
+	A string  `d2b:"cstring"`
+	B string  `d2b:"length:6,pad:space"`
+	C *string `d2b:"length:4,cstring"`
+	D string  `d2b:"length:3"`
+}
+
+// EncodeStruct12 is Struct from encode_test.go:316
+type EncodeStruct12 struct {
+	A string `d2b:"length:3,strict"`
+	B string `d2b:"length:3,cstring,strict"`
+}
+
+type plainEncodeStruct12 struct {
+	A string `d2b:"length:3,strict"`
+	B string `d2b:"length:3,cstring,strict"`
+}
+
+// EncodeNotString is NotString from encode_test.go:329
+type EncodeNotString struct {
+	A []byte `d2b:"length:2,pad:space"`
+}
+
+type plainEncodeNotString struct {
+	A []byte `d2b:"length:2,pad:space"`
+}
+
+// EncodeUnknownPad is UnknownPad from encode_test.go:332
+type EncodeUnknownPad struct {
+	A string `d2b:"length:2,pad:tab"`
+}
+
+type plainEncodeUnknownPad struct {
+	A string `d2b:"length:2,pad:tab"`
+}
+
+// EncodePrefixedCString is PrefixedCString from encode_test.go:335
+type EncodePrefixedCString struct {
+	A string `d2b:"cstring,lenprefix:uint8"`
+}
+
+type plainEncodePrefixedCString struct {
+	A string `d2b:"cstring,lenprefix:uint8"`
+}
+
+// EncodePaddedCString is PaddedCString from encode_test.go:338
+type EncodePaddedCString struct {
+	A string `d2b:"cstring,length:2,pad:space"`
+}
+
+type plainEncodePaddedCString struct {
+	A string `d2b:"cstring,length:2,pad:space"`
+}
+
+// EncodeVariableTrim is VariableTrim from encode_test.go:341
+type EncodeVariableTrim struct {
+	A string `d2b:"lenprefix:uint8,trim:right"`
+}
+
+type plainEncodeVariableTrim struct {
+	A string `d2b:"lenprefix:uint8,trim:right"`
+}
+
+// EncodeErrTestStruct is ErrTestStruct from encode_test.go:350
+type EncodeErrTestStruct struct {
+	Field string `d2b:"length:1qwe"`
+}
+
+type plainEncodeErrTestStruct struct {
+	Field string `d2b:"length:1qwe"`
+}
+
+// EncodeErrTestStruct2 is ErrTestStruct from encode_test.go:359
+type EncodeErrTestStruct2 struct {
+	Field []int `d2b:"length:2"` //31
+}
+
+type plainEncodeErrTestStruct2 struct {
+	Field []int `d2b:"length:2"` //31
+}
+
+// EncodeErrTestStruct3 is ErrTestStruct from encode_test.go:367
+type EncodeErrTestStruct3 struct {
+	Field []int `d2b:"length:2"` //31
+}
+
+type plainEncodeErrTestStruct3 struct {
+	Field []int `d2b:"length:2"` //31
+}
+
+// EncodeErrTestStruct4 is ErrTestStruct from encode_test.go:375
+type EncodeErrTestStruct4 struct {
+	Field [2]int
+}
+
+type plainEncodeErrTestStruct4 struct {
+	Field [2]int
+}
+
+// EncodeErrTestStruct5 is ErrTestStruct from encode_test.go:383
+type EncodeErrTestStruct5 struct {
+	Field []int32
+}
+
+type plainEncodeErrTestStruct5 struct {
+	Field []int32
+}
+
+// EncodeErrTestStruct6 is ErrTestStruct from encode_test.go:392
+type EncodeErrTestStruct6 struct {
+	Field string
+}
+
+type plainEncodeErrTestStruct6 struct {
+	Field string
+}
+
+// EncodeErrTestStruct7 is ErrTestStruct from encode_test.go:400
+type EncodeErrTestStruct7 struct {
+	Field string `d2b:"length:1qwe"` //31
+}
+
+type plainEncodeErrTestStruct7 struct {
+	Field string `d2b:"length:1qwe"` //31
+}
+
+// EncodeErrTestStruct8 is ErrTestStruct from encode_test.go:409
+type EncodeErrTestStruct8 struct {
+	Field [5]int
+}
+
+type plainEncodeErrTestStruct8 struct {
+	Field [5]int
+}
+
+// EncodeErrTestStruct9 is ErrTestStruct from encode_test.go:418
+type EncodeErrTestStruct9 struct {
+	Field *string
+}
+
+type plainEncodeErrTestStruct9 struct {
+	Field *string
+}
+
+// EncodeErrTestStruct10 is ErrTestStruct from encode_test.go:436
+type EncodeErrTestStruct10 struct {
+	Field *int
+}
+
+type plainEncodeErrTestStruct10 struct {
+	Field *int
+}
+
+// EncodeErrTestStruct11 is ErrTestStruct from encode_test.go:451
+type EncodeErrTestStruct11 struct {
+	Field int32 `d2b:"length:hello"`
+}
+
+type plainEncodeErrTestStruct11 struct {
+	Field int32 `d2b:"length:hello"`
+}
+
+// EncodeErrTestStruct12 is ErrTestStruct from encode_test.go:460
+type EncodeErrTestStruct12 struct {
+	Field []int32
+}
+
+type plainEncodeErrTestStruct12 struct {
+	Field []int32
+}
+
+// EncodeErrTestStruct13 is ErrTestStruct from encode_test.go:469
+type EncodeErrTestStruct13 struct {
+	Field []int `d2b:"length:5"`
+}
+
+type plainEncodeErrTestStruct13 struct {
+	Field []int `d2b:"length:5"`
+}
+
+// EncodeToStruct is Struct from encode_test.go:482
+type EncodeToStruct struct {
+	A uint16
+	B string `d2b:"lenprefix:uint8"`
+}
+
+type plainEncodeToStruct struct {
+	A uint16
+	B string `d2b:"lenprefix:uint8"`
+}
+
+// EncodeToLimited is Limited from encode_test.go:517
+type EncodeToLimited struct {
+	A []uint8 `d2b:"lenprefix:uint8,length:1"`
+}
+
+type plainEncodeToLimited struct {
+	A []uint8 `d2b:"lenprefix:uint8,length:1"`
+}
+
+// EncoderRecord is Record from encoder_test.go:26
+type EncoderRecord struct {
+	ID    uint16
+	Name  string `d2b:"length:4"`
+	Data  []int8 `d2b:"length:2"`
+	Value *uint32
+}
+
+type plainEncoderRecord struct {
+	ID    uint16
+	Name  string `d2b:"length:4"`
+	Data  []int8 `d2b:"length:2"`
+	Value *uint32
+}
+
+// UnexportedFieldsStruct is Struct from fields_test.go:50
+type UnexportedFieldsStruct struct {
+	A uint8 `d2b:"bits:4"`
+	_ uint8 `d2b:"bits:4"`
+	_ [2]byte
+	B uint8
+}
+
+type plainUnexportedFieldsStruct struct {
+	A uint8 `d2b:"bits:4"`
+	_ uint8 `d2b:"bits:4"`
+	_ [2]byte
+	B uint8
+}
+
+// UnexportedFieldsStruct2 is Struct from fields_test.go:64
+type UnexportedFieldsStruct2 struct {
+	A []testUnexported         `d2b:"lenprefix:uint8"`
+	B map[uint8]testUnexported `d2b:"lenprefix:uint8"`
+}
+
+type plainUnexportedFieldsStruct2 struct {
+	A []plainTestUnexported         `d2b:"lenprefix:uint8"`
+	B map[uint8]plainTestUnexported `d2b:"lenprefix:uint8"`
+}
+
+// UnexportedFieldsStruct3 is Struct from fields_test.go:88
+type UnexportedFieldsStruct3 struct {
+	_ uint8 `d2b:"bits:4"`
+	A uint8 `d2b:"bits:2"`
+	_ uint8 `d2b:"bits:2"`
+	b uint8
+	C uint8
+}
+
+type plainUnexportedFieldsStruct3 struct {
+	_ uint8 `d2b:"bits:4"`
+	A uint8 `d2b:"bits:2"`
+	_ uint8 `d2b:"bits:2"`
+	b uint8
+	C uint8
+}
+
+// UnexportedFieldsBits is Bits from fields_test.go:105
+type UnexportedFieldsBits struct {
+	a uint8 `d2b:"bits:4"`
+	B uint8 `d2b:"bits:4"`
+}
+
+type plainUnexportedFieldsBits struct {
+	a uint8 `d2b:"bits:4"`
+	B uint8 `d2b:"bits:4"`
+}
+
+// UnexportedFieldsLength is Length from fields_test.go:109
+type UnexportedFieldsLength struct {
+	n uint8
+	A []uint8 `d2b:"lengthfrom:n"`
+}
+
+type plainUnexportedFieldsLength struct {
+	n uint8
+	A []uint8 `d2b:"lengthfrom:n"`
+}
+
+// EmbeddedStructsPacket is Packet from fields_test.go:127
+type EmbeddedStructsPacket struct {
+	testHeader
+	Payload []byte `d2b:"lengthfrom:Length,autofill"`
+}
+
+type plainEmbeddedStructsPacket struct {
+	testHeader
+	Payload []byte `d2b:"lengthfrom:Length,autofill"`
+}
+
+// EmbeddedStructsFlags is Flags from fields_test.go:146
+type EmbeddedStructsFlags struct {
+	A uint8 `d2b:"bits:4"`
+}
+
+// EmbeddedStructsPacket2 is Packet from fields_test.go:149
+type EmbeddedStructsPacket2 struct {
+	testHeaderWithIP
+	EmbeddedStructsFlags
+	B uint8 `d2b:"bits:4"`
+}
+
+type plainEmbeddedStructsPacket2 struct {
+	testHeaderWithIP
+	EmbeddedStructsFlags
+	B uint8 `d2b:"bits:4"`
+}
+
+// MapsStruct is Struct from maps_test.go:14
+type MapsStruct struct {
+	A map[uint16]uint32 `d2b:"lenprefix:uint16,sorted"`
+}
+
+type plainMapsStruct struct {
+	A map[uint16]uint32 `d2b:"lenprefix:uint16,sorted"`
+}
+
+// MapsStruct2 is Struct from maps_test.go:40
+type MapsStruct2 struct {
+	A map[uint8]int8 `d2b:"lenprefix:uint8"`
+}
+
+type plainMapsStruct2 struct {
+	A map[uint8]int8 `d2b:"lenprefix:uint8"`
+}
+
+// MapsKey is Key from maps_test.go:54
+type MapsKey struct {
+	A, B uint8
+}
+
+type plainMapsKey struct {
+	A, B uint8
+}
+
+// MapsStruct3 is Struct from maps_test.go:57
+type MapsStruct3 struct {
+	A map[int16]bool    `d2b:"lenprefix:uint8,sorted"`
+	B map[float32]int8  `d2b:"lenprefix:uint8,sorted"`
+	C map[MapsKey]uint8 `d2b:"lenprefix:uint8,sorted"`
+	D map[bool]uint8    `d2b:"lenprefix:uint8,sorted"`
+}
+
+type plainMapsStruct3 struct {
+	A map[int16]bool         `d2b:"lenprefix:uint8,sorted"`
+	B map[float32]int8       `d2b:"lenprefix:uint8,sorted"`
+	C map[plainMapsKey]uint8 `d2b:"lenprefix:uint8,sorted"`
+	D map[bool]uint8         `d2b:"lenprefix:uint8,sorted"`
+}
+
+// MapsStruct4 is Struct from maps_test.go:82
+type MapsStruct4 struct {
+	N uint8
+	A map[uint8]uint8  `d2b:"lengthfrom:N,autofill,sorted"`
+	B *map[uint8]uint8 `d2b:"lenprefix:uint8,sorted"`
+}
+
+type plainMapsStruct4 struct {
+	N uint8
+	A map[uint8]uint8  `d2b:"lengthfrom:N,autofill,sorted"`
+	B *map[uint8]uint8 `d2b:"lenprefix:uint8,sorted"`
+}
+
+// MapsValue is Value from maps_test.go:98
+type MapsValue struct {
+	S []uint8 `d2b:"lenprefix:uint8"`
+}
+
+type plainMapsValue struct {
+	S []uint8 `d2b:"lenprefix:uint8"`
+}
+
+// MapsStruct5 is Struct from maps_test.go:101
+type MapsStruct5 struct {
+	A map[uint8]MapsValue `d2b:"lenprefix:uint8,sorted"`
+}
+
+type plainMapsStruct5 struct {
+	A map[uint8]plainMapsValue `d2b:"lenprefix:uint8,sorted"`
+}
+
+// MapsStruct6 is Struct from maps_test.go:124
+type MapsStruct6 struct {
+	A map[uint8]uint16 `d2b:"lenprefix:uint8,length:2"`
+}
+
+type plainMapsStruct6 struct {
+	A map[uint8]uint16 `d2b:"lenprefix:uint8,length:2"`
+}
+
+// MapsWithoutPrefix is WithoutPrefix from maps_test.go:136
+type MapsWithoutPrefix struct {
+	A map[uint8]uint8 `d2b:"length:2"`
+}
+
+type plainMapsWithoutPrefix struct {
+	A map[uint8]uint8 `d2b:"length:2"`
+}
+
+// MapsSortedSlice is SortedSlice from maps_test.go:139
+type MapsSortedSlice struct {
+	A []uint8 `d2b:"lenprefix:uint8,sorted"`
+}
+
+type plainMapsSortedSlice struct {
+	A []uint8 `d2b:"lenprefix:uint8,sorted"`
+}
+
+// MapsStringKey is StringKey from maps_test.go:142
+type MapsStringKey struct {
+	A map[string]uint8 `d2b:"lenprefix:uint8"`
+}
+
+type plainMapsStringKey struct {
+	A map[string]uint8 `d2b:"lenprefix:uint8"`
+}
+
+// MarshalerFrame is Frame from marshaler_test.go:81
+type MarshalerFrame struct {
+	Addr  testIPv4
+	Time  testBCDTime
+	Name  testPascalString
+	Addrs [2]testIPv4
+	Last  *testIPv4
+}
+
+type plainMarshalerFrame struct {
+	Addr  testIPv4
+	Time  testBCDTime
+	Name  testPascalString
+	Addrs [2]testIPv4
+	Last  *testIPv4
+}
+
+// MarshalerFixed is Fixed from marshaler_test.go:118
+type MarshalerFixed struct {
+	Addr testIPv4
+	Time *testBCDTime
+}
+
+type plainMarshalerFixed struct {
+	Addr testIPv4
+	Time *testBCDTime
+}
+
+// OptionsStruct is Struct from options_test.go:13
+type OptionsStruct struct {
+	A int
+	B uint `d2b:"size:1"`
+	C [2]int
+}
+
+type plainOptionsStruct struct {
+	A int
+	B uint `d2b:"size:1"`
+	C [2]int
+}
+
+// SizeStruct is Struct from size_test.go:14
+type SizeStruct struct {
+	A uint16
+	B string  `d2b:"length:5"`
+	C []int32 `d2b:"length:2"`
+	D *[2]bool
+	E uint8 `d2b:"-"`
+}
+
+type plainSizeStruct struct {
+	A uint16
+	B string  `d2b:"length:5"`
+	C []int32 `d2b:"length:2"`
+	D *[2]bool
+	E uint8 `d2b:"-"`
+}
+
+// SizeStruct2 is Struct from size_test.go:29
+type SizeStruct2 struct {
+	A string `d2b:"lenprefix:uint8"`
+}
+
+type plainSizeStruct2 struct {
+	A string `d2b:"lenprefix:uint8"`
+}
+
+// SizeItem is Item from size_test.go:38
+type SizeItem struct {
+	ID   uint8
+	Name string `d2b:"lenprefix:uint16"`
+}
+
+type plainSizeItem struct {
+	ID   uint8
+	Name string `d2b:"lenprefix:uint16"`
+}
+
+// SizeStruct3 is Struct from size_test.go:42
+type SizeStruct3 struct {
+	Count   uint8
+	Items   []SizeItem `d2b:"lengthfrom:Count,autofill"`
+	Comment *string    `d2b:"lenprefix:uint8"`
+	Custom  testPascalString
+}
+
+type plainSizeStruct3 struct {
+	Count   uint8
+	Items   []plainSizeItem `d2b:"lengthfrom:Count,autofill"`
+	Comment *string         `d2b:"lenprefix:uint8"`
+	Custom  testPascalString
+}
+
+// SizeStruct4 is Struct from size_test.go:64
+type SizeStruct4 struct {
+	A []testPascalString `d2b:"length:2"`
+}
+
+type plainSizeStruct4 struct {
+	A []testPascalString `d2b:"length:2"`
+}
+
+// ElemOptionsStruct is Struct from tags_test.go:13
+type ElemOptionsStruct struct {
+	A [2]string  `d2b:"elem.length:3"`
+	B []string   `d2b:"length:2,elem.length:2,elem.pad:space,elem.trim:right"`
+	C []string   `d2b:"lenprefix:uint8,elem.lenprefix:uint8"`
+	D *[]string  `d2b:"lenprefix:uint8,elem.cstring"`
+	E [][]uint16 `d2b:"lenprefix:uint8,elem.lenprefix:uint8"`
+	F [][]string `d2b:"length:1,elem.length:2,elem.elem.length:1"`
+}
+
+type plainElemOptionsStruct struct {
+	A [2]string  `d2b:"elem.length:3"`
+	B []string   `d2b:"length:2,elem.length:2,elem.pad:space,elem.trim:right"`
+	C []string   `d2b:"lenprefix:uint8,elem.lenprefix:uint8"`
+	D *[]string  `d2b:"lenprefix:uint8,elem.cstring"`
+	E [][]uint16 `d2b:"lenprefix:uint8,elem.lenprefix:uint8"`
+	F [][]string `d2b:"length:1,elem.length:2,elem.elem.length:1"`
+}
+
+// ElemOptionsStruct2 is Struct from tags_test.go:52
+type ElemOptionsStruct2 struct {
+	A map[string]int     `d2b:"lenprefix:uint8,sorted,key.lenprefix:uint8,elem.size:2"`
+	B map[uint8][]string `d2b:"lenprefix:uint8,elem.length:2,elem.elem.length:1"`
+}
+
+type plainElemOptionsStruct2 struct {
+	A map[string]int     `d2b:"lenprefix:uint8,sorted,key.lenprefix:uint8,elem.size:2"`
+	B map[uint8][]string `d2b:"lenprefix:uint8,elem.length:2,elem.elem.length:1"`
+}
+
+// ElemOptionsStruct3 is Struct from tags_test.go:66
+type ElemOptionsStruct3 struct {
+	A []float32 `d2b:"lenprefix:uint8,elem.float16"`
+	B [2]bool   `d2b:"elem.bool:strict"`
+	C []string  `d2b:"length:1,elem.length:2,elem.charset:utf16le"`
+}
+
+type plainElemOptionsStruct3 struct {
+	A []float32 `d2b:"lenprefix:uint8,elem.float16"`
+	B [2]bool   `d2b:"elem.bool:strict"`
+	C []string  `d2b:"length:1,elem.length:2,elem.charset:utf16le"`
+}
+
+// ElemOptionsNotContainer is NotContainer from tags_test.go:82
+type ElemOptionsNotContainer struct {
+	A string `d2b:"length:2,elem.length:2"`
+}
+
+type plainElemOptionsNotContainer struct {
+	A string `d2b:"length:2,elem.length:2"`
+}
+
+// ElemOptionsKeyOfSlice is KeyOfSlice from tags_test.go:85
+type ElemOptionsKeyOfSlice struct {
+	A []string `d2b:"lenprefix:uint8,key.length:2"`
+}
+
+type plainElemOptionsKeyOfSlice struct {
+	A []string `d2b:"lenprefix:uint8,key.length:2"`
+}
+
+// ElemOptionsLengthFrom is LengthFrom from tags_test.go:88
+type ElemOptionsLengthFrom struct {
+	N uint8
+	A [][]uint8 `d2b:"lenprefix:uint8,elem.lengthfrom:N"`
+}
+
+type plainElemOptionsLengthFrom struct {
+	N uint8
+	A [][]uint8 `d2b:"lenprefix:uint8,elem.lengthfrom:N"`
+}
+
+// ElemOptionsEndian is Endian from tags_test.go:92
+type ElemOptionsEndian struct {
+	A []uint16 `d2b:"lenprefix:uint8,elem.endian:big"`
+}
+
+type plainElemOptionsEndian struct {
+	A []uint16 `d2b:"lenprefix:uint8,elem.endian:big"`
+}
+
+// ElemOptionsWrongType is WrongType from tags_test.go:95
+type ElemOptionsWrongType struct {
+	A []uint8 `d2b:"lenprefix:uint8,elem.cstring"`
+}
+
+type plainElemOptionsWrongType struct {
+	A []uint8 `d2b:"lenprefix:uint8,elem.cstring"`
+}
+
+// ElemOptionsWithoutLength is WithoutLength from tags_test.go:98
+type ElemOptionsWithoutLength struct {
+	A [2]string
+}
+
+type plainElemOptionsWithoutLength struct {
+	A [2]string
+}
+
+// VarintStruct is Struct from varint_test.go:15
+type VarintStruct struct {
+	A uint64 `d2b:"varint"`
+	B int64  `d2b:"zigzag"`
+	C uint16 `d2b:"varint"`
+	D int    `d2b:"zigzag"`
+	E *int32 `d2b:"varint"`
+}
+
+type plainVarintStruct struct {
+	A uint64 `d2b:"varint"`
+	B int64  `d2b:"zigzag"`
+	C uint16 `d2b:"varint"`
+	D int    `d2b:"zigzag"`
+	E *int32 `d2b:"varint"`
+}
+
+// VarintStruct2 is Struct from varint_test.go:48
+type VarintStruct2 struct {
+	A string   `d2b:"lenprefix:varint"`
+	B []uint16 `d2b:"lenprefix:varint,elem.varint"`
+}
+
+type plainVarintStruct2 struct {
+	A string   `d2b:"lenprefix:varint"`
+	B []uint16 `d2b:"lenprefix:varint,elem.varint"`
+}
+
+// VarintStruct3 is Struct from varint_test.go:72
+type VarintStruct3 struct {
+	N uint32 `d2b:"varint"`
+	A []byte `d2b:"lengthfrom:N,autofill"`
+}
+
+type plainVarintStruct3 struct {
+	N uint32 `d2b:"varint"`
+	A []byte `d2b:"lengthfrom:N,autofill"`
+}
+
+// VarintStruct4 is Struct from varint_test.go:90
+type VarintStruct4 struct {
+	A int32  `d2b:"zigzag"`
+	B string `d2b:"lenprefix:varint"`
+	C uint8
+}
+
+type plainVarintStruct4 struct {
+	A int32  `d2b:"zigzag"`
+	B string `d2b:"lenprefix:varint"`
+	C uint8
+}
+
+// VarintSmall is Small from varint_test.go:103
+type VarintSmall struct {
+	A uint8 `d2b:"varint"`
+}
+
+type plainVarintSmall struct {
+	A uint8 `d2b:"varint"`
+}
+
+// VarintSigned is Signed from varint_test.go:106
+type VarintSigned struct {
+	A int8 `d2b:"zigzag"`
+}
+
+type plainVarintSigned struct {
+	A int8 `d2b:"zigzag"`
+}
+
+// VarintNotInteger is NotInteger from varint_test.go:117
+type VarintNotInteger struct {
+	A float32 `d2b:"varint"`
+}
+
+type plainVarintNotInteger struct {
+	A float32 `d2b:"varint"`
+}
+
+// VarintUnsigned is Unsigned from varint_test.go:120
+type VarintUnsigned struct {
+	A uint32 `d2b:"zigzag"`
+}
+
+type plainVarintUnsigned struct {
+	A uint32 `d2b:"zigzag"`
+}
+
+// VarintBoth is Both from varint_test.go:123
+type VarintBoth struct {
+	A int32 `d2b:"varint,zigzag"`
+}
+
+type plainVarintBoth struct {
+	A int32 `d2b:"varint,zigzag"`
+}
+
+// VarintWithSize is WithSize from varint_test.go:126
+type VarintWithSize struct {
+	A int `d2b:"varint,size:4"`
+}
+
+type plainVarintWithSize struct {
+	A int `d2b:"varint,size:4"`
+}
+
+// VarintWithBits is WithBits from varint_test.go:129
+type VarintWithBits struct {
+	A uint8 `d2b:"varint,bits:8"`
+}
+
+type plainVarintWithBits struct {
+	A uint8 `d2b:"varint,bits:8"`
+}
@@ -0,0 +1,4601 @@
+// Code generated by d2bgen. DO NOT EDIT.
+
+package compat
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	d2b "gopkg.in/saturn4er/go-data-to-bytes.v2"
+)
+
+// SizeD2B returns length of testPackedInner bytes representation
+func (v *testPackedInner) SizeD2B() int {
+	return 5
+}
+
+// MarshalD2B encodes testPackedInner to bytes
+func (v *testPackedInner) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of testPackedInner to buf
+func (v *testPackedInner) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, byte(v.A))
+	buf = append(buf, 0, 0, 0, 0)
+	endian.PutUint32(buf[len(buf)-4:], uint32(v.B))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes testPackedInner from data and returns count of used bytes
+func (v *testPackedInner) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	v.A = b[0]
+	b = b[1:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 4, Available: len(b)}
+	}
+	v.B = endian.Uint32(b)
+	b = b[4:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of benchHeader bytes representation
+func (v *benchHeader) SizeD2B() int {
+	return 4
+}
+
+// MarshalD2B encodes benchHeader to bytes
+func (v *benchHeader) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of benchHeader to buf
+func (v *benchHeader) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var acc1 uint64
+	x2 := uint64(v.Version)
+	if x2&^0xf != 0 {
+		return nil, fmt.Errorf("benchHeader.Version: value %d doesn't fit in 4 bits", x2)
+	}
+	acc1 = acc1<<4 | x2
+	x3 := uint64(v.Flags)
+	if x3&^0xf != 0 {
+		return nil, fmt.Errorf("benchHeader.Flags: value %d doesn't fit in 4 bits", x3)
+	}
+	acc1 = acc1<<4 | x3
+	var a4 [8]byte
+	binary.BigEndian.PutUint64(a4[:], acc1)
+	buf = append(buf, a4[7:]...)
+	buf = append(buf, byte(v.Type))
+	buf = append(buf, 0, 0)
+	binary.BigEndian.PutUint16(buf[len(buf)-2:], uint16(v.Length))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes benchHeader from data and returns count of used bytes
+func (v *benchHeader) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Version", Needed: 1, Available: len(b)}
+	}
+	var a1 [8]byte
+	copy(a1[7:], b[:1])
+	acc2 := binary.BigEndian.Uint64(a1[:])
+	v.Version = uint8((acc2 >> 4 & 0xf))
+	v.Flags = uint8((acc2 >> 0 & 0xf))
+	b = b[1:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Type", Needed: 1, Available: len(b)}
+	}
+	v.Type = b[0]
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Length", Needed: 2, Available: len(b)}
+	}
+	v.Length = binary.BigEndian.Uint16(b)
+	b = b[2:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of benchRecord bytes representation
+func (v *benchRecord) SizeD2B() int {
+	return 49
+}
+
+// MarshalD2B encodes benchRecord to bytes
+func (v *benchRecord) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of benchRecord to buf
+func (v *benchRecord) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0, 0, 0)
+	endian.PutUint32(buf[len(buf)-4:], uint32(v.ID))
+	buf = append(buf, 0, 0, 0, 0, 0, 0, 0, 0)
+	endian.PutUint64(buf[len(buf)-8:], uint64(v.Value))
+	buf = append(buf, 0, 0, 0, 0)
+	endian.PutUint32(buf[len(buf)-4:], uint32(math.Float32bits(float32(v.Scale))))
+	if v.Active {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+	l1 := len(v.Name)
+	if l1 > 16 {
+		l1 = 16
+	}
+	buf = append(buf, v.Name[:l1]...)
+	buf = append(buf, make([]byte, 16-l1)...)
+	for i2 := 0; i2 < len(v.Points); i2++ {
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16(v.Points[i2]))
+	}
+	l3 := len(v.Tags)
+	if l3 > 4 {
+		l3 = 4
+	}
+	for i4 := 0; i4 < l3; i4++ {
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16(v.Tags[i4]))
+	}
+	if l3 < 4 {
+		buf = append(buf, make([]byte, (4-l3)*(2))...)
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes benchRecord from data and returns count of used bytes
+func (v *benchRecord) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "ID", Needed: 4, Available: len(b)}
+	}
+	v.ID = endian.Uint32(b)
+	b = b[4:]
+	if len(b) < 8 {
+		return 0, &d2b.ShortBufferError{Path: "Value", Needed: 8, Available: len(b)}
+	}
+	v.Value = int64(endian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "Scale", Needed: 4, Available: len(b)}
+	}
+	v.Scale = math.Float32frombits(endian.Uint32(b))
+	b = b[4:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Active", Needed: 1, Available: len(b)}
+	}
+	v.Active = b[0] != 0
+	b = b[1:]
+	if len(b) < 16 {
+		return 0, &d2b.ShortBufferError{Path: "Name", Needed: 16, Available: len(b)}
+	}
+	s1 := b[:16]
+	for i2, c3 := range s1 {
+		if c3 == 0 {
+			s1 = s1[:i2]
+			break
+		}
+	}
+	if string(v.Name) != string(s1) {
+		v.Name = string(s1)
+	}
+	b = b[16:]
+	for i4 := 0; i4 < len(v.Points); i4++ {
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "Points[" + strconv.Itoa(i4) + "]", Needed: 2, Available: len(b)}
+		}
+		v.Points[i4] = int16(endian.Uint16(b))
+		b = b[2:]
+	}
+	if len(v.Tags) < 4 {
+		s5 := make([]uint16, 4)
+		copy(s5, v.Tags)
+		v.Tags = s5
+	}
+	for i6 := 0; i6 < len(v.Tags); i6++ {
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "Tags[" + strconv.Itoa(i6) + "]", Needed: 2, Available: len(b)}
+		}
+		v.Tags[i6] = endian.Uint16(b)
+		b = b[2:]
+	}
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes benchFrame to bytes
+func (v *benchFrame) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of benchFrame to buf
+func (v *benchFrame) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var err error
+	if buf, err = v.Header.AppendD2B(buf, endian); err != nil {
+		return nil, fmt.Errorf("benchFrame.Header: %v", err)
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.Count))
+	l1 := len(v.Records)
+	var n2 int
+	if x3 := uint64(v.Count); x3 > uint64(^uint(0)>>1) {
+		return nil, fmt.Errorf("benchFrame.Records: invalid length %d", x3)
+	} else {
+		n2 = int(x3)
+	}
+	if l1 != n2 {
+		return nil, fmt.Errorf("benchFrame.Records: length %d doesn't match Count field value %d", l1, n2)
+	}
+	for i4 := 0; i4 < len(v.Records); i4++ {
+		if buf, err = v.Records[i4].AppendD2B(buf, endian); err != nil {
+			return nil, fmt.Errorf("benchFrame.Records: %v", err)
+		}
+	}
+	if uint64(len(v.Comment))>>8 != 0 {
+		return nil, fmt.Errorf("benchFrame.Comment: value %d doesn't fit in 1 bytes", len(v.Comment))
+	}
+	buf = append(buf, byte(len(v.Comment)))
+	buf = append(buf, v.Comment...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes benchFrame from data and returns count of used bytes
+func (v *benchFrame) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	var n int
+	var err error
+	if n, err = v.Header.UnmarshalD2B(b, endian); err != nil {
+		if sb, ok := err.(*d2b.ShortBufferError); ok {
+			return 0, sb.PrependPath("Header")
+		}
+		return 0, fmt.Errorf("benchFrame.Header: %v", err)
+	}
+	b = b[n:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Count", Needed: 2, Available: len(b)}
+	}
+	v.Count = endian.Uint16(b)
+	b = b[2:]
+	var n1 int
+	if x2 := uint64(v.Count); x2 > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("benchFrame.Records: invalid length %d", x2)
+	} else {
+		n1 = int(x2)
+	}
+	e3 := int(^uint(0) >> 1)
+	if n1 <= e3/(49) {
+		e3 = n1 * (49)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "Records", Needed: e3, Available: len(b)}
+	}
+	c4 := n1
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]benchRecord, 0, c4)
+	var z6 benchRecord
+	for i7 := 0; i7 < n1; i7++ {
+		s5 = append(s5, z6)
+		if n, err = s5[i7].UnmarshalD2B(b, endian); err != nil {
+			if sb, ok := err.(*d2b.ShortBufferError); ok {
+				return 0, sb.PrependPath("Records[" + strconv.Itoa(i7) + "]")
+			}
+			return 0, fmt.Errorf("benchFrame.Records: %v", err)
+		}
+		b = b[n:]
+	}
+	v.Records = s5
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Comment", Needed: 1, Available: len(b)}
+	}
+	l8 := uint64(b[0])
+	b = b[1:]
+	n9 := int(l8)
+	if len(b) < n9 {
+		return 0, &d2b.ShortBufferError{Path: "Comment", Needed: n9, Available: len(b)}
+	}
+	if string(v.Comment) != string(b[:n9]) {
+		v.Comment = string(b[:n9])
+	}
+	b = b[n9:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes testUnexported to bytes
+func (v *testUnexported) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of testUnexported to buf
+func (v *testUnexported) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var err error
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.a))
+	buf = append(buf, byte(v.B))
+	if uint64(len(v.name))>>8 != 0 {
+		return nil, fmt.Errorf("testUnexported.name: value %d doesn't fit in 1 bytes", len(v.name))
+	}
+	buf = append(buf, byte(len(v.name)))
+	buf = append(buf, v.name...)
+	var m1 []byte
+	if m1, err = v.addr.MarshalD2B(endian); err != nil {
+		return nil, fmt.Errorf("testUnexported.addr: can't marshal testIPv4: %v", err)
+	}
+	if len(m1) != v.addr.SizeD2B() {
+		return nil, fmt.Errorf("testUnexported.addr: marshaled to %d bytes, but it's size is %d", len(m1), v.addr.SizeD2B())
+	}
+	buf = append(buf, m1...)
+	var z2 [2]byte
+	for i3 := 0; i3 < len(z2); i3++ {
+		buf = append(buf, byte(z2[i3]))
+	}
+	if v.ptr != nil {
+		buf = append(buf, byte((*v.ptr)))
+	} else {
+		var z4 int8
+		buf = append(buf, byte(z4))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes testUnexported from data and returns count of used bytes
+func (v *testUnexported) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	var n int
+	var err error
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "a", Needed: 2, Available: len(b)}
+	}
+	v.a = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 1, Available: len(b)}
+	}
+	v.B = b[0]
+	b = b[1:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "name", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	n2 := int(l1)
+	if len(b) < n2 {
+		return 0, &d2b.ShortBufferError{Path: "name", Needed: n2, Available: len(b)}
+	}
+	if string(v.name) != string(b[:n2]) {
+		v.name = string(b[:n2])
+	}
+	b = b[n2:]
+	s3 := v.addr.SizeD2B()
+	if len(b) < s3 {
+		return 0, &d2b.ShortBufferError{Path: "addr", Needed: s3, Available: len(b)}
+	}
+	if n, err = v.addr.UnmarshalD2B(b[:s3], endian); err != nil {
+		if sb, ok := err.(*d2b.ShortBufferError); ok {
+			return 0, sb.PrependPath("addr")
+		}
+		return 0, fmt.Errorf("testUnexported.addr: %v", err)
+	}
+	if n < 0 || n > s3 {
+		return 0, fmt.Errorf("testUnexported.addr: testIPv4 unmarshaler used %d bytes of %d", n, s3)
+	}
+	b = b[n:]
+	z4 := new([2]byte)
+	for i5 := 0; i5 < len((*z4)); i5++ {
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "_[" + strconv.Itoa(i5) + "]", Needed: 1, Available: len(b)}
+		}
+		(*z4)[i5] = byte(b[0])
+		b = b[1:]
+	}
+	if v.ptr == nil {
+		v.ptr = new(int8)
+	}
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "ptr", Needed: 1, Available: len(b)}
+	}
+	(*v.ptr) = int8(b[0])
+	b = b[1:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes BenchmarkDecoderStringsStruct to bytes
+func (v *BenchmarkDecoderStringsStruct) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of BenchmarkDecoderStringsStruct to buf
+func (v *BenchmarkDecoderStringsStruct) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if uint64(len(v.Names))>>16 != 0 {
+		return nil, fmt.Errorf("BenchmarkDecoderStringsStruct.Names: value %d doesn't fit in 2 bytes", len(v.Names))
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(len(v.Names)))
+	for i1 := 0; i1 < len(v.Names); i1++ {
+		if uint64(len(v.Names[i1]))>>8 != 0 {
+			return nil, fmt.Errorf("BenchmarkDecoderStringsStruct.Names: value %d doesn't fit in 1 bytes", len(v.Names[i1]))
+		}
+		buf = append(buf, byte(len(v.Names[i1])))
+		buf = append(buf, v.Names[i1]...)
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes BenchmarkDecoderStringsStruct from data and returns count of used bytes
+func (v *BenchmarkDecoderStringsStruct) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Names", Needed: 2, Available: len(b)}
+	}
+	l1 := uint64(endian.Uint16(b))
+	b = b[2:]
+	n2 := int(l1)
+	c3 := n2
+	if c3 > len(b) {
+		c3 = len(b)
+	}
+	s4 := make([]string, 0, c3)
+	var z5 string
+	for i6 := 0; i6 < n2; i6++ {
+		s4 = append(s4, z5)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "Names[" + strconv.Itoa(i6) + "]", Needed: 1, Available: len(b)}
+		}
+		l7 := uint64(b[0])
+		b = b[1:]
+		n8 := int(l7)
+		if len(b) < n8 {
+			return 0, &d2b.ShortBufferError{Path: "Names[" + strconv.Itoa(i6) + "]", Needed: n8, Available: len(b)}
+		}
+		if string(s4[i6]) != string(b[:n8]) {
+			s4[i6] = string(b[:n8])
+		}
+		b = b[n8:]
+	}
+	v.Names = s4
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes BenchmarkDecoderCStringStruct to bytes
+func (v *BenchmarkDecoderCStringStruct) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of BenchmarkDecoderCStringStruct to buf
+func (v *BenchmarkDecoderCStringStruct) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if strings.IndexByte(v.Text, 0) >= 0 {
+		return nil, fmt.Errorf("BenchmarkDecoderCStringStruct.Text: cstring can't contain zero byte")
+	}
+	buf = append(buf, v.Text...)
+	buf = append(buf, 0)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes BenchmarkDecoderCStringStruct from data and returns count of used bytes
+func (v *BenchmarkDecoderCStringStruct) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	e1 := -1
+	for i2, c3 := range b {
+		if c3 == 0 {
+			e1 = i2
+			break
+		}
+	}
+	if e1 < 0 {
+		return 0, &d2b.ShortBufferError{Path: "Text", Needed: len(b) + 1, Available: len(b)}
+	}
+	if string(v.Text) != string(b[:e1]) {
+		v.Text = string(b[:e1])
+	}
+	b = b[e1+1:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of BitFieldsHeader bytes representation
+func (v *BitFieldsHeader) SizeD2B() int {
+	return 5
+}
+
+// MarshalD2B encodes BitFieldsHeader to bytes
+func (v *BitFieldsHeader) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of BitFieldsHeader to buf
+func (v *BitFieldsHeader) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var acc1 uint64
+	x2 := uint64(v.Version)
+	if x2&^0x7 != 0 {
+		return nil, fmt.Errorf("BitFieldsHeader.Version: value %d doesn't fit in 3 bits", x2)
+	}
+	acc1 = acc1<<3 | x2
+	var x3 uint64
+	if v.Flag {
+		x3 = 1
+	}
+	acc1 = acc1<<1 | x3
+	x4 := uint64(v.Type)
+	if x4&^0xf != 0 {
+		return nil, fmt.Errorf("BitFieldsHeader.Type: value %d doesn't fit in 4 bits", x4)
+	}
+	acc1 = acc1<<4 | x4
+	var a5 [8]byte
+	binary.BigEndian.PutUint64(a5[:], acc1)
+	buf = append(buf, a5[7:]...)
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.Length))
+	var acc6 uint64
+	x7 := int64(v.Offset)
+	if x7 < -4096 || x7 >= 4096 {
+		return nil, fmt.Errorf("BitFieldsHeader.Offset: value %d doesn't fit in 13 bits", x7)
+	}
+	acc6 = acc6<<13 | (uint64(x7) & 0x1fff)
+	x8 := uint64(v.Mode)
+	if x8&^0x7 != 0 {
+		return nil, fmt.Errorf("BitFieldsHeader.Mode: value %d doesn't fit in 3 bits", x8)
+	}
+	acc6 = acc6<<3 | x8
+	var a9 [8]byte
+	binary.BigEndian.PutUint64(a9[:], acc6)
+	buf = append(buf, a9[6:]...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes BitFieldsHeader from data and returns count of used bytes
+func (v *BitFieldsHeader) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Version", Needed: 1, Available: len(b)}
+	}
+	var a1 [8]byte
+	copy(a1[7:], b[:1])
+	acc2 := binary.BigEndian.Uint64(a1[:])
+	v.Version = uint8((acc2 >> 5 & 0x7))
+	v.Flag = bool((acc2 >> 4 & 0x1) != 0)
+	v.Type = uint8((acc2 >> 0 & 0xf))
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Length", Needed: 2, Available: len(b)}
+	}
+	v.Length = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Offset", Needed: 2, Available: len(b)}
+	}
+	var a3 [8]byte
+	copy(a3[6:], b[:2])
+	acc4 := binary.BigEndian.Uint64(a3[:])
+	v.Offset = int16(int64((acc4>>3&0x1fff)<<51) >> 51)
+	v.Mode = uint32((acc4 >> 0 & 0x7))
+	b = b[2:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of BitFieldsLSBHeader bytes representation
+func (v *BitFieldsLSBHeader) SizeD2B() int {
+	return 5
+}
+
+// MarshalD2B encodes BitFieldsLSBHeader to bytes
+func (v *BitFieldsLSBHeader) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of BitFieldsLSBHeader to buf
+func (v *BitFieldsLSBHeader) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var acc1 uint64
+	x2 := uint64(v.Version)
+	if x2&^0x7 != 0 {
+		return nil, fmt.Errorf("BitFieldsLSBHeader.Version: value %d doesn't fit in 3 bits", x2)
+	}
+	acc1 |= x2 << 0
+	var x3 uint64
+	if v.Flag {
+		x3 = 1
+	}
+	acc1 |= x3 << 3
+	x4 := uint64(v.Type)
+	if x4&^0xf != 0 {
+		return nil, fmt.Errorf("BitFieldsLSBHeader.Type: value %d doesn't fit in 4 bits", x4)
+	}
+	acc1 |= x4 << 4
+	var a5 [8]byte
+	binary.LittleEndian.PutUint64(a5[:], acc1)
+	buf = append(buf, a5[:1]...)
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.Length))
+	var acc6 uint64
+	x7 := int64(v.Offset)
+	if x7 < -4096 || x7 >= 4096 {
+		return nil, fmt.Errorf("BitFieldsLSBHeader.Offset: value %d doesn't fit in 13 bits", x7)
+	}
+	acc6 |= (uint64(x7) & 0x1fff) << 0
+	x8 := uint64(v.Mode)
+	if x8&^0x7 != 0 {
+		return nil, fmt.Errorf("BitFieldsLSBHeader.Mode: value %d doesn't fit in 3 bits", x8)
+	}
+	acc6 |= x8 << 13
+	var a9 [8]byte
+	binary.LittleEndian.PutUint64(a9[:], acc6)
+	buf = append(buf, a9[:2]...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes BitFieldsLSBHeader from data and returns count of used bytes
+func (v *BitFieldsLSBHeader) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Version", Needed: 1, Available: len(b)}
+	}
+	var a1 [8]byte
+	copy(a1[:], b[:1])
+	acc2 := binary.LittleEndian.Uint64(a1[:])
+	v.Version = uint8((acc2 >> 0 & 0x7))
+	v.Flag = bool((acc2 >> 3 & 0x1) != 0)
+	v.Type = uint8((acc2 >> 4 & 0xf))
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Length", Needed: 2, Available: len(b)}
+	}
+	v.Length = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Offset", Needed: 2, Available: len(b)}
+	}
+	var a3 [8]byte
+	copy(a3[:], b[:2])
+	acc4 := binary.LittleEndian.Uint64(a3[:])
+	v.Offset = int16(int64((acc4>>0&0x1fff)<<51) >> 51)
+	v.Mode = uint32((acc4 >> 13 & 0x7))
+	b = b[2:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes CompileStruct to bytes
+func (v *CompileStruct) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of CompileStruct to buf
+func (v *CompileStruct) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.A))
+	l1 := len(v.B)
+	if l1 > 3 {
+		l1 = 3
+	}
+	buf = append(buf, v.B[:l1]...)
+	buf = append(buf, make([]byte, 3-l1)...)
+	if uint64(len(v.C))>>8 != 0 {
+		return nil, fmt.Errorf("CompileStruct.C: value %d doesn't fit in 1 bytes", len(v.C))
+	}
+	buf = append(buf, byte(len(v.C)))
+	for i2 := 0; i2 < len(v.C); i2++ {
+		buf = append(buf, byte(v.C[i2]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes CompileStruct from data and returns count of used bytes
+func (v *CompileStruct) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 2, Available: len(b)}
+	}
+	v.A = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 3 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 3, Available: len(b)}
+	}
+	s1 := b[:3]
+	for i2, c3 := range s1 {
+		if c3 == 0 {
+			s1 = s1[:i2]
+			break
+		}
+	}
+	if string(v.B) != string(s1) {
+		v.B = string(s1)
+	}
+	b = b[3:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 1, Available: len(b)}
+	}
+	l4 := uint64(b[0])
+	b = b[1:]
+	n5 := int(l4)
+	e6 := int(^uint(0) >> 1)
+	if n5 <= e6/(1) {
+		e6 = n5 * (1)
+	}
+	if len(b) < e6 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: e6, Available: len(b)}
+	}
+	c7 := n5
+	if c7 > len(b) {
+		c7 = len(b)
+	}
+	s8 := make([]int8, 0, c7)
+	var z9 int8
+	for i10 := 0; i10 < n5; i10++ {
+		s8 = append(s8, z9)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "C[" + strconv.Itoa(i10) + "]", Needed: 1, Available: len(b)}
+		}
+		s8[i10] = int8(b[0])
+		b = b[1:]
+	}
+	v.C = s8
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of CompileFixed bytes representation
+func (v *CompileFixed) SizeD2B() int {
+	return 15
+}
+
+// MarshalD2B encodes CompileFixed to bytes
+func (v *CompileFixed) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of CompileFixed to buf
+func (v *CompileFixed) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.A))
+	for i1 := 0; i1 < len(v.B); i1++ {
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(v.B[i1]))
+	}
+	if v.C != nil {
+		if *v.C {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+	} else {
+		var z2 bool
+		if z2 {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes CompileFixed from data and returns count of used bytes
+func (v *CompileFixed) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 2, Available: len(b)}
+	}
+	v.A = endian.Uint16(b)
+	b = b[2:]
+	for i1 := 0; i1 < len(v.B); i1++ {
+		if len(b) < 4 {
+			return 0, &d2b.ShortBufferError{Path: "B[" + strconv.Itoa(i1) + "]", Needed: 4, Available: len(b)}
+		}
+		v.B[i1] = int32(endian.Uint32(b))
+		b = b[4:]
+	}
+	if v.C == nil {
+		v.C = new(bool)
+	}
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 1, Available: len(b)}
+	}
+	(*v.C) = b[0] != 0
+	b = b[1:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of DecodeStruct bytes representation
+func (v *DecodeStruct) SizeD2B() int {
+	return 14
+}
+
+// MarshalD2B encodes DecodeStruct to bytes
+func (v *DecodeStruct) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct to buf
+func (v *DecodeStruct) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.A))
+	buf = append(buf, 0, 0, 0, 0)
+	endian.PutUint32(buf[len(buf)-4:], uint32(v.B))
+	buf = append(buf, 0, 0, 0, 0, 0, 0, 0, 0)
+	endian.PutUint64(buf[len(buf)-8:], uint64(v.C))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct from data and returns count of used bytes
+func (v *DecodeStruct) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 2, Available: len(b)}
+	}
+	v.A = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 4, Available: len(b)}
+	}
+	v.B = endian.Uint32(b)
+	b = b[4:]
+	if len(b) < 8 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 8, Available: len(b)}
+	}
+	v.C = endian.Uint64(b)
+	b = b[8:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of DecodeStruct2 bytes representation
+func (v *DecodeStruct2) SizeD2B() int {
+	return 34
+}
+
+// MarshalD2B encodes DecodeStruct2 to bytes
+func (v *DecodeStruct2) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct2 to buf
+func (v *DecodeStruct2) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if v.A != nil {
+		l1 := len((*v.A))
+		if l1 > 2 {
+			l1 = 2
+		}
+		for i2 := 0; i2 < l1; i2++ {
+			buf = append(buf, 0, 0, 0, 0)
+			endian.PutUint32(buf[len(buf)-4:], uint32((*v.A)[i2]))
+		}
+		if l1 < 2 {
+			buf = append(buf, make([]byte, (2-l1)*(4))...)
+		}
+	} else {
+		var z3 []int32
+		l4 := len(z3)
+		if l4 > 2 {
+			l4 = 2
+		}
+		for i5 := 0; i5 < l4; i5++ {
+			buf = append(buf, 0, 0, 0, 0)
+			endian.PutUint32(buf[len(buf)-4:], uint32(z3[i5]))
+		}
+		if l4 < 2 {
+			buf = append(buf, make([]byte, (2-l4)*(4))...)
+		}
+	}
+	if v.B != nil {
+		l6 := len((*v.B))
+		if l6 > 2 {
+			l6 = 2
+		}
+		for i7 := 0; i7 < l6; i7++ {
+			buf = append(buf, 0, 0, 0, 0)
+			endian.PutUint32(buf[len(buf)-4:], uint32((*v.B)[i7]))
+		}
+		if l6 < 2 {
+			buf = append(buf, make([]byte, (2-l6)*(4))...)
+		}
+	} else {
+		var z8 []uint32
+		l9 := len(z8)
+		if l9 > 2 {
+			l9 = 2
+		}
+		for i10 := 0; i10 < l9; i10++ {
+			buf = append(buf, 0, 0, 0, 0)
+			endian.PutUint32(buf[len(buf)-4:], uint32(z8[i10]))
+		}
+		if l9 < 2 {
+			buf = append(buf, make([]byte, (2-l9)*(4))...)
+		}
+	}
+	for i11 := 0; i11 < len(v.C); i11++ {
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(v.C[i11]))
+	}
+	l12 := len(v.Test)
+	if l12 > 6 {
+		l12 = 6
+	}
+	buf = append(buf, v.Test[:l12]...)
+	buf = append(buf, make([]byte, 6-l12)...)
+	l13 := len(v.Test1)
+	if l13 > 4 {
+		l13 = 4
+	}
+	buf = append(buf, v.Test1[:l13]...)
+	buf = append(buf, make([]byte, 4-l13)...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct2 from data and returns count of used bytes
+func (v *DecodeStruct2) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if v.A == nil {
+		v.A = new([]int32)
+	}
+	if len((*v.A)) < 2 {
+		s1 := make([]int32, 2)
+		copy(s1, (*v.A))
+		(*v.A) = s1
+	}
+	for i2 := 0; i2 < len((*v.A)); i2++ {
+		if len(b) < 4 {
+			return 0, &d2b.ShortBufferError{Path: "A[" + strconv.Itoa(i2) + "]", Needed: 4, Available: len(b)}
+		}
+		(*v.A)[i2] = int32(endian.Uint32(b))
+		b = b[4:]
+	}
+	if v.B == nil {
+		v.B = new([]uint32)
+	}
+	if len((*v.B)) < 2 {
+		s3 := make([]uint32, 2)
+		copy(s3, (*v.B))
+		(*v.B) = s3
+	}
+	for i4 := 0; i4 < len((*v.B)); i4++ {
+		if len(b) < 4 {
+			return 0, &d2b.ShortBufferError{Path: "B[" + strconv.Itoa(i4) + "]", Needed: 4, Available: len(b)}
+		}
+		(*v.B)[i4] = endian.Uint32(b)
+		b = b[4:]
+	}
+	for i5 := 0; i5 < len(v.C); i5++ {
+		if len(b) < 4 {
+			return 0, &d2b.ShortBufferError{Path: "C[" + strconv.Itoa(i5) + "]", Needed: 4, Available: len(b)}
+		}
+		v.C[i5] = int32(endian.Uint32(b))
+		b = b[4:]
+	}
+	if len(b) < 6 {
+		return 0, &d2b.ShortBufferError{Path: "Test", Needed: 6, Available: len(b)}
+	}
+	s6 := b[:6]
+	for i7, c8 := range s6 {
+		if c8 == 0 {
+			s6 = s6[:i7]
+			break
+		}
+	}
+	if string(v.Test) != string(s6) {
+		v.Test = string(s6)
+	}
+	b = b[6:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "Test1", Needed: 4, Available: len(b)}
+	}
+	s9 := b[:4]
+	for i10, c11 := range s9 {
+		if c11 == 0 {
+			s9 = s9[:i10]
+			break
+		}
+	}
+	if string(v.Test1) != string(s9) {
+		v.Test1 = string(s9)
+	}
+	b = b[4:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of DecodeItem bytes representation
+func (v *DecodeItem) SizeD2B() int {
+	return 6
+}
+
+// MarshalD2B encodes DecodeItem to bytes
+func (v *DecodeItem) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of DecodeItem to buf
+func (v *DecodeItem) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.ID))
+	l1 := len(v.Name)
+	if l1 > 4 {
+		l1 = 4
+	}
+	buf = append(buf, v.Name[:l1]...)
+	buf = append(buf, make([]byte, 4-l1)...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeItem from data and returns count of used bytes
+func (v *DecodeItem) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "ID", Needed: 2, Available: len(b)}
+	}
+	v.ID = int16(endian.Uint16(b))
+	b = b[2:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "Name", Needed: 4, Available: len(b)}
+	}
+	s1 := b[:4]
+	for i2, c3 := range s1 {
+		if c3 == 0 {
+			s1 = s1[:i2]
+			break
+		}
+	}
+	if string(v.Name) != string(s1) {
+		v.Name = string(s1)
+	}
+	b = b[4:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of DecodeStruct3 bytes representation
+func (v *DecodeStruct3) SizeD2B() int {
+	return 13
+}
+
+// MarshalD2B encodes DecodeStruct3 to bytes
+func (v *DecodeStruct3) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct3 to buf
+func (v *DecodeStruct3) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var err error
+	buf = append(buf, byte(v.A))
+	l1 := len(v.Items)
+	if l1 > 2 {
+		l1 = 2
+	}
+	for i2 := 0; i2 < l1; i2++ {
+		if buf, err = v.Items[i2].AppendD2B(buf, endian); err != nil {
+			return nil, fmt.Errorf("DecodeStruct3.Items: %v", err)
+		}
+	}
+	if l1 < 2 {
+		buf = append(buf, make([]byte, (2-l1)*(6))...)
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct3 from data and returns count of used bytes
+func (v *DecodeStruct3) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	var n int
+	var err error
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	v.A = int8(b[0])
+	b = b[1:]
+	if len(v.Items) < 2 {
+		s1 := make([]DecodeItem, 2)
+		copy(s1, v.Items)
+		v.Items = s1
+	}
+	for i2 := 0; i2 < len(v.Items); i2++ {
+		if n, err = v.Items[i2].UnmarshalD2B(b, endian); err != nil {
+			if sb, ok := err.(*d2b.ShortBufferError); ok {
+				return 0, sb.PrependPath("Items[" + strconv.Itoa(i2) + "]")
+			}
+			return 0, fmt.Errorf("DecodeStruct3.Items: %v", err)
+		}
+		b = b[n:]
+	}
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes DecodeItem2 to bytes
+func (v *DecodeItem2) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of DecodeItem2 to buf
+func (v *DecodeItem2) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, byte(v.ID))
+	if uint64(len(v.Name))>>8 != 0 {
+		return nil, fmt.Errorf("DecodeItem2.Name: value %d doesn't fit in 1 bytes", len(v.Name))
+	}
+	buf = append(buf, byte(len(v.Name)))
+	buf = append(buf, v.Name...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeItem2 from data and returns count of used bytes
+func (v *DecodeItem2) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "ID", Needed: 1, Available: len(b)}
+	}
+	v.ID = b[0]
+	b = b[1:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Name", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	n2 := int(l1)
+	if len(b) < n2 {
+		return 0, &d2b.ShortBufferError{Path: "Name", Needed: n2, Available: len(b)}
+	}
+	if string(v.Name) != string(b[:n2]) {
+		v.Name = string(b[:n2])
+	}
+	b = b[n2:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes DecodeStruct4 to bytes
+func (v *DecodeStruct4) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct4 to buf
+func (v *DecodeStruct4) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var err error
+	if uint64(len(v.A))>>8 != 0 {
+		return nil, fmt.Errorf("DecodeStruct4.A: value %d doesn't fit in 1 bytes", len(v.A))
+	}
+	buf = append(buf, byte(len(v.A)))
+	buf = append(buf, v.A...)
+	if uint64(len(v.B))>>16 != 0 {
+		return nil, fmt.Errorf("DecodeStruct4.B: value %d doesn't fit in 2 bytes", len(v.B))
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(len(v.B)))
+	for i1 := 0; i1 < len(v.B); i1++ {
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16(v.B[i1]))
+	}
+	if uint64(len(v.C))>>8 != 0 {
+		return nil, fmt.Errorf("DecodeStruct4.C: value %d doesn't fit in 1 bytes", len(v.C))
+	}
+	buf = append(buf, byte(len(v.C)))
+	for i2 := 0; i2 < len(v.C); i2++ {
+		if buf, err = v.C[i2].AppendD2B(buf, endian); err != nil {
+			return nil, fmt.Errorf("DecodeStruct4.C: %v", err)
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct4 from data and returns count of used bytes
+func (v *DecodeStruct4) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	var n int
+	var err error
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	n2 := int(l1)
+	if len(b) < n2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: n2, Available: len(b)}
+	}
+	if string(v.A) != string(b[:n2]) {
+		v.A = string(b[:n2])
+	}
+	b = b[n2:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 2, Available: len(b)}
+	}
+	l3 := uint64(endian.Uint16(b))
+	b = b[2:]
+	n4 := int(l3)
+	e5 := int(^uint(0) >> 1)
+	if n4 <= e5/(2) {
+		e5 = n4 * (2)
+	}
+	if len(b) < e5 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: e5, Available: len(b)}
+	}
+	c6 := n4
+	if c6 > len(b) {
+		c6 = len(b)
+	}
+	s7 := make([]uint16, 0, c6)
+	var z8 uint16
+	for i9 := 0; i9 < n4; i9++ {
+		s7 = append(s7, z8)
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "B[" + strconv.Itoa(i9) + "]", Needed: 2, Available: len(b)}
+		}
+		s7[i9] = endian.Uint16(b)
+		b = b[2:]
+	}
+	v.B = s7
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 1, Available: len(b)}
+	}
+	l10 := uint64(b[0])
+	b = b[1:]
+	n11 := int(l10)
+	c12 := n11
+	if c12 > len(b) {
+		c12 = len(b)
+	}
+	s13 := make([]DecodeItem2, 0, c12)
+	var z14 DecodeItem2
+	for i15 := 0; i15 < n11; i15++ {
+		s13 = append(s13, z14)
+		if n, err = s13[i15].UnmarshalD2B(b, endian); err != nil {
+			if sb, ok := err.(*d2b.ShortBufferError); ok {
+				return 0, sb.PrependPath("C[" + strconv.Itoa(i15) + "]")
+			}
+			return 0, fmt.Errorf("DecodeStruct4.C: %v", err)
+		}
+		b = b[n:]
+	}
+	v.C = s13
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes DecodeStruct5 to bytes
+func (v *DecodeStruct5) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct5 to buf
+func (v *DecodeStruct5) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if uint64(len(v.A))>>16 != 0 {
+		return nil, fmt.Errorf("DecodeStruct5.A: value %d doesn't fit in 2 bytes", len(v.A))
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(len(v.A)))
+	for i1 := 0; i1 < len(v.A); i1++ {
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(v.A[i1]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct5 from data and returns count of used bytes
+func (v *DecodeStruct5) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 2, Available: len(b)}
+	}
+	l1 := uint64(endian.Uint16(b))
+	b = b[2:]
+	n2 := int(l1)
+	e3 := int(^uint(0) >> 1)
+	if n2 <= e3/(4) {
+		e3 = n2 * (4)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: e3, Available: len(b)}
+	}
+	c4 := n2
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]uint32, 0, c4)
+	var z6 uint32
+	for i7 := 0; i7 < n2; i7++ {
+		s5 = append(s5, z6)
+		if len(b) < 4 {
+			return 0, &d2b.ShortBufferError{Path: "A[" + strconv.Itoa(i7) + "]", Needed: 4, Available: len(b)}
+		}
+		s5[i7] = endian.Uint32(b)
+		b = b[4:]
+	}
+	v.A = s5
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes DecodeStruct6 to bytes
+func (v *DecodeStruct6) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct6 to buf
+func (v *DecodeStruct6) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if len(v.A) > 2 {
+		return nil, fmt.Errorf("DecodeStruct6.A: length %d is greater than max length 2", len(v.A))
+	}
+	if uint64(len(v.A))>>8 != 0 {
+		return nil, fmt.Errorf("DecodeStruct6.A: value %d doesn't fit in 1 bytes", len(v.A))
+	}
+	buf = append(buf, byte(len(v.A)))
+	buf = append(buf, v.A...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct6 from data and returns count of used bytes
+func (v *DecodeStruct6) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	if l1 > 2 {
+		return 0, fmt.Errorf("DecodeStruct6.A: length %d is greater than max length 2", l1)
+	}
+	n2 := int(l1)
+	if len(b) < n2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: n2, Available: len(b)}
+	}
+	if string(v.A) != string(b[:n2]) {
+		v.A = string(b[:n2])
+	}
+	b = b[n2:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes DecodeStruct7 to bytes
+func (v *DecodeStruct7) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct7 to buf
+func (v *DecodeStruct7) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, byte(v.Count))
+	if v.Size != nil {
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16((*v.Size)))
+	} else {
+		var z1 int16
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16(z1))
+	}
+	l2 := len(v.Records)
+	var n3 int
+	if x4 := uint64(v.Count); x4 > uint64(^uint(0)>>1) {
+		return nil, fmt.Errorf("DecodeStruct7.Records: invalid length %d", x4)
+	} else {
+		n3 = int(x4)
+	}
+	if l2 != n3 {
+		return nil, fmt.Errorf("DecodeStruct7.Records: length %d doesn't match Count field value %d", l2, n3)
+	}
+	for i5 := 0; i5 < len(v.Records); i5++ {
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16(v.Records[i5]))
+	}
+	l6 := len(v.Text)
+	var n7 int
+	if v.Size != nil {
+		if x8 := int64((*v.Size)); x8 < 0 || uint64(x8) > uint64(^uint(0)>>1) {
+			return nil, fmt.Errorf("DecodeStruct7.Text: invalid length %d", x8)
+		} else {
+			n7 = int(x8)
+		}
+	}
+	if l6 != n7 {
+		return nil, fmt.Errorf("DecodeStruct7.Text: length %d doesn't match Size field value %d", l6, n7)
+	}
+	buf = append(buf, v.Text...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct7 from data and returns count of used bytes
+func (v *DecodeStruct7) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Count", Needed: 1, Available: len(b)}
+	}
+	v.Count = b[0]
+	b = b[1:]
+	if v.Size == nil {
+		v.Size = new(int16)
+	}
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Size", Needed: 2, Available: len(b)}
+	}
+	(*v.Size) = int16(endian.Uint16(b))
+	b = b[2:]
+	var n1 int
+	if x2 := uint64(v.Count); x2 > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("DecodeStruct7.Records: invalid length %d", x2)
+	} else {
+		n1 = int(x2)
+	}
+	e3 := int(^uint(0) >> 1)
+	if n1 <= e3/(2) {
+		e3 = n1 * (2)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "Records", Needed: e3, Available: len(b)}
+	}
+	c4 := n1
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]int16, 0, c4)
+	var z6 int16
+	for i7 := 0; i7 < n1; i7++ {
+		s5 = append(s5, z6)
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "Records[" + strconv.Itoa(i7) + "]", Needed: 2, Available: len(b)}
+		}
+		s5[i7] = int16(endian.Uint16(b))
+		b = b[2:]
+	}
+	v.Records = s5
+	var n8 int
+	if v.Size != nil {
+		if x9 := int64((*v.Size)); x9 < 0 || uint64(x9) > uint64(^uint(0)>>1) {
+			return 0, fmt.Errorf("DecodeStruct7.Text: invalid length %d", x9)
+		} else {
+			n8 = int(x9)
+		}
+	}
+	if len(b) < n8 {
+		return 0, &d2b.ShortBufferError{Path: "Text", Needed: n8, Available: len(b)}
+	}
+	if string(v.Text) != string(b[:n8]) {
+		v.Text = string(b[:n8])
+	}
+	b = b[n8:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes DecodeStruct8 to bytes
+func (v *DecodeStruct8) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct8 to buf
+func (v *DecodeStruct8) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, byte(v.Count))
+	l1 := len(v.Records)
+	var n2 int
+	if x3 := int64(v.Count); x3 < 0 || uint64(x3) > uint64(^uint(0)>>1) {
+		return nil, fmt.Errorf("DecodeStruct8.Records: invalid length %d", x3)
+	} else {
+		n2 = int(x3)
+	}
+	if l1 != n2 {
+		return nil, fmt.Errorf("DecodeStruct8.Records: length %d doesn't match Count field value %d", l1, n2)
+	}
+	if l1 > 2 {
+		return nil, fmt.Errorf("DecodeStruct8.Records: length %d is greater than max length 2", l1)
+	}
+	for i4 := 0; i4 < len(v.Records); i4++ {
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16(v.Records[i4]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct8 from data and returns count of used bytes
+func (v *DecodeStruct8) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Count", Needed: 1, Available: len(b)}
+	}
+	v.Count = int8(b[0])
+	b = b[1:]
+	var n1 int
+	if x2 := int64(v.Count); x2 < 0 || uint64(x2) > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("DecodeStruct8.Records: invalid length %d", x2)
+	} else {
+		n1 = int(x2)
+	}
+	if n1 > 2 {
+		return 0, fmt.Errorf("DecodeStruct8.Records: length %d is greater than max length 2", n1)
+	}
+	e3 := int(^uint(0) >> 1)
+	if n1 <= e3/(2) {
+		e3 = n1 * (2)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "Records", Needed: e3, Available: len(b)}
+	}
+	c4 := n1
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]int16, 0, c4)
+	var z6 int16
+	for i7 := 0; i7 < n1; i7++ {
+		s5 = append(s5, z6)
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "Records[" + strconv.Itoa(i7) + "]", Needed: 2, Available: len(b)}
+		}
+		s5[i7] = int16(endian.Uint16(b))
+		b = b[2:]
+	}
+	v.Records = s5
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of DecodePayload bytes representation
+func (v *DecodePayload) SizeD2B() int {
+	return 4
+}
+
+// MarshalD2B encodes DecodePayload to bytes
+func (v *DecodePayload) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of DecodePayload to buf
+func (v *DecodePayload) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.A))
+	buf = append(buf, 0, 0)
+	binary.BigEndian.PutUint16(buf[len(buf)-2:], uint16(v.B))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodePayload from data and returns count of used bytes
+func (v *DecodePayload) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 2, Available: len(b)}
+	}
+	v.A = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 2, Available: len(b)}
+	}
+	v.B = binary.BigEndian.Uint16(b)
+	b = b[2:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes DecodeFrame to bytes
+func (v *DecodeFrame) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of DecodeFrame to buf
+func (v *DecodeFrame) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var err error
+	buf = append(buf, 0, 0)
+	binary.BigEndian.PutUint16(buf[len(buf)-2:], uint16(v.Length))
+	if v.Payload != nil {
+		if buf, err = (*v.Payload).AppendD2B(buf, binary.LittleEndian); err != nil {
+			return nil, fmt.Errorf("DecodeFrame.Payload: %v", err)
+		}
+	} else {
+		var z1 DecodePayload
+		if buf, err = z1.AppendD2B(buf, binary.LittleEndian); err != nil {
+			return nil, fmt.Errorf("DecodeFrame.Payload: %v", err)
+		}
+	}
+	for i2 := 0; i2 < len(v.Array); i2++ {
+		buf = append(buf, 0, 0)
+		binary.LittleEndian.PutUint16(buf[len(buf)-2:], uint16(v.Array[i2]))
+	}
+	l3 := len(v.Slice)
+	var n4 int
+	if x5 := uint64(v.Length); x5 > uint64(^uint(0)>>1) {
+		return nil, fmt.Errorf("DecodeFrame.Slice: invalid length %d", x5)
+	} else {
+		n4 = int(x5)
+	}
+	if l3 != n4 {
+		return nil, fmt.Errorf("DecodeFrame.Slice: length %d doesn't match Length field value %d", l3, n4)
+	}
+	for i6 := 0; i6 < len(v.Slice); i6++ {
+		buf = append(buf, 0, 0)
+		binary.LittleEndian.PutUint16(buf[len(buf)-2:], uint16(v.Slice[i6]))
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.Tail))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeFrame from data and returns count of used bytes
+func (v *DecodeFrame) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	var n int
+	var err error
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Length", Needed: 2, Available: len(b)}
+	}
+	v.Length = binary.BigEndian.Uint16(b)
+	b = b[2:]
+	if v.Payload == nil {
+		v.Payload = new(DecodePayload)
+	}
+	if n, err = (*v.Payload).UnmarshalD2B(b, binary.LittleEndian); err != nil {
+		if sb, ok := err.(*d2b.ShortBufferError); ok {
+			return 0, sb.PrependPath("Payload")
+		}
+		return 0, fmt.Errorf("DecodeFrame.Payload: %v", err)
+	}
+	b = b[n:]
+	for i1 := 0; i1 < len(v.Array); i1++ {
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "Array[" + strconv.Itoa(i1) + "]", Needed: 2, Available: len(b)}
+		}
+		v.Array[i1] = binary.LittleEndian.Uint16(b)
+		b = b[2:]
+	}
+	var n2 int
+	if x3 := uint64(v.Length); x3 > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("DecodeFrame.Slice: invalid length %d", x3)
+	} else {
+		n2 = int(x3)
+	}
+	e4 := int(^uint(0) >> 1)
+	if n2 <= e4/(2) {
+		e4 = n2 * (2)
+	}
+	if len(b) < e4 {
+		return 0, &d2b.ShortBufferError{Path: "Slice", Needed: e4, Available: len(b)}
+	}
+	c5 := n2
+	if c5 > len(b) {
+		c5 = len(b)
+	}
+	s6 := make([]uint16, 0, c5)
+	var z7 uint16
+	for i8 := 0; i8 < n2; i8++ {
+		s6 = append(s6, z7)
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "Slice[" + strconv.Itoa(i8) + "]", Needed: 2, Available: len(b)}
+		}
+		s6[i8] = binary.LittleEndian.Uint16(b)
+		b = b[2:]
+	}
+	v.Slice = s6
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Tail", Needed: 2, Available: len(b)}
+	}
+	v.Tail = endian.Uint16(b)
+	b = b[2:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of DecodeStruct9 bytes representation
+func (v *DecodeStruct9) SizeD2B() int {
+	return 6
+}
+
+// MarshalD2B encodes DecodeStruct9 to bytes
+func (v *DecodeStruct9) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct9 to buf
+func (v *DecodeStruct9) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if v.A {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+	if v.B != nil {
+		if *v.B {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+	} else {
+		var z1 bool
+		if z1 {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+	}
+	for i2 := 0; i2 < len(v.C); i2++ {
+		if v.C[i2] {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+	}
+	if v.D {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct9 from data and returns count of used bytes
+func (v *DecodeStruct9) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	v.A = b[0] != 0
+	b = b[1:]
+	if v.B == nil {
+		v.B = new(bool)
+	}
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 1, Available: len(b)}
+	}
+	(*v.B) = b[0] != 0
+	b = b[1:]
+	for i1 := 0; i1 < len(v.C); i1++ {
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "C[" + strconv.Itoa(i1) + "]", Needed: 1, Available: len(b)}
+		}
+		v.C[i1] = b[0] != 0
+		b = b[1:]
+	}
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "D", Needed: 1, Available: len(b)}
+	}
+	if b[0] > 1 {
+		return 0, fmt.Errorf("DecodeStruct9.D: invalid bool value %d", b[0])
+	}
+	v.D = b[0] != 0
+	b = b[1:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of DecodeStruct10 bytes representation
+func (v *DecodeStruct10) SizeD2B() int {
+	return 1
+}
+
+// MarshalD2B encodes DecodeStruct10 to bytes
+func (v *DecodeStruct10) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct10 to buf
+func (v *DecodeStruct10) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if v.A {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct10 from data and returns count of used bytes
+func (v *DecodeStruct10) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	if b[0] > 1 {
+		return 0, fmt.Errorf("DecodeStruct10.A: invalid bool value %d", b[0])
+	}
+	v.A = b[0] != 0
+	b = b[1:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of DecodeStruct11 bytes representation
+func (v *DecodeStruct11) SizeD2B() int {
+	return 7
+}
+
+// MarshalD2B encodes DecodeStruct11 to bytes
+func (v *DecodeStruct11) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct11 to buf
+func (v *DecodeStruct11) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	x1 := int64(v.A)
+	if x1 < -128 || x1 >= 128 {
+		return nil, fmt.Errorf("DecodeStruct11.A: value %d doesn't fit in 1 bytes", x1)
+	}
+	buf = append(buf, byte(x1))
+	x2 := uint64(v.B)
+	if x2>>16 != 0 {
+		return nil, fmt.Errorf("DecodeStruct11.B: value %d doesn't fit in 2 bytes", x2)
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(x2))
+	if v.C != nil {
+		x3 := int64((*v.C))
+		if x3 < -2147483648 || x3 >= 2147483648 {
+			return nil, fmt.Errorf("DecodeStruct11.C: value %d doesn't fit in 4 bytes", x3)
+		}
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(x3))
+	} else {
+		var z4 int
+		x5 := int64(z4)
+		if x5 < -2147483648 || x5 >= 2147483648 {
+			return nil, fmt.Errorf("DecodeStruct11.C: value %d doesn't fit in 4 bytes", x5)
+		}
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(x5))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct11 from data and returns count of used bytes
+func (v *DecodeStruct11) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	x1 := int64(uint64(b[0])<<56) >> 56
+	v.A = int(x1)
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 2, Available: len(b)}
+	}
+	x2 := uint64(endian.Uint16(b))
+	v.B = uint(x2)
+	b = b[2:]
+	if v.C == nil {
+		v.C = new(int)
+	}
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 4, Available: len(b)}
+	}
+	x3 := int64(uint64(endian.Uint32(b))<<32) >> 32
+	(*v.C) = int(x3)
+	b = b[4:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes DecodeStruct12 to bytes
+func (v *DecodeStruct12) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct12 to buf
+func (v *DecodeStruct12) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if strings.IndexByte(v.A, 0) >= 0 {
+		return nil, fmt.Errorf("DecodeStruct12.A: cstring can't contain zero byte")
+	}
+	buf = append(buf, v.A...)
+	buf = append(buf, 0)
+	if strings.IndexByte(v.B, 0) >= 0 {
+		return nil, fmt.Errorf("DecodeStruct12.B: cstring can't contain zero byte")
+	}
+	buf = append(buf, v.B...)
+	buf = append(buf, 0)
+	l1 := len(v.C)
+	if l1 > 3 {
+		l1 = 3
+	}
+	buf = append(buf, v.C[:l1]...)
+	buf = append(buf, make([]byte, 4-l1)...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct12 from data and returns count of used bytes
+func (v *DecodeStruct12) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	e1 := -1
+	for i2, c3 := range b {
+		if c3 == 0 {
+			e1 = i2
+			break
+		}
+	}
+	if e1 < 0 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: len(b) + 1, Available: len(b)}
+	}
+	if string(v.A) != string(b[:e1]) {
+		v.A = string(b[:e1])
+	}
+	b = b[e1+1:]
+	e4 := -1
+	for i5, c6 := range b {
+		if c6 == 0 {
+			e4 = i5
+			break
+		}
+	}
+	if e4 < 0 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: len(b) + 1, Available: len(b)}
+	}
+	if string(v.B) != string(b[:e4]) {
+		v.B = string(b[:e4])
+	}
+	b = b[e4+1:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 4, Available: len(b)}
+	}
+	s7 := b[:4]
+	for i8, c9 := range s7 {
+		if c9 == 0 {
+			s7 = s7[:i8]
+			break
+		}
+	}
+	if string(v.C) != string(s7) {
+		v.C = string(s7)
+	}
+	b = b[4:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of DecodeStruct13 bytes representation
+func (v *DecodeStruct13) SizeD2B() int {
+	return 12
+}
+
+// MarshalD2B encodes DecodeStruct13 to bytes
+func (v *DecodeStruct13) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of DecodeStruct13 to buf
+func (v *DecodeStruct13) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	l1 := len(v.A)
+	if l1 > 4 {
+		l1 = 4
+	}
+	buf = append(buf, v.A[:l1]...)
+	for i2 := l1; i2 < 4; i2++ {
+		buf = append(buf, 32)
+	}
+	l3 := len(v.B)
+	if l3 > 4 {
+		l3 = 4
+	}
+	buf = append(buf, v.B[:l3]...)
+	for i4 := l3; i4 < 4; i4++ {
+		buf = append(buf, 32)
+	}
+	l5 := len(v.C)
+	if l5 > 4 {
+		l5 = 4
+	}
+	buf = append(buf, v.C[:l5]...)
+	buf = append(buf, make([]byte, 4-l5)...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecodeStruct13 from data and returns count of used bytes
+func (v *DecodeStruct13) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 4, Available: len(b)}
+	}
+	s1 := b[:4]
+	if string(v.A) != string(s1) {
+		v.A = string(s1)
+	}
+	b = b[4:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 4, Available: len(b)}
+	}
+	s2 := b[:4]
+	for len(s2) > 0 && s2[len(s2)-1] == 32 {
+		s2 = s2[:len(s2)-1]
+	}
+	if string(v.B) != string(s2) {
+		v.B = string(s2)
+	}
+	b = b[4:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 4, Available: len(b)}
+	}
+	s3 := b[:4]
+	for len(s3) > 0 && s3[len(s3)-1] == 0 {
+		s3 = s3[:len(s3)-1]
+	}
+	if string(v.C) != string(s3) {
+		v.C = string(s3)
+	}
+	b = b[4:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of DecoderRecord bytes representation
+func (v *DecoderRecord) SizeD2B() int {
+	return 8
+}
+
+// MarshalD2B encodes DecoderRecord to bytes
+func (v *DecoderRecord) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of DecoderRecord to buf
+func (v *DecoderRecord) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.ID))
+	l1 := len(v.Name)
+	if l1 > 4 {
+		l1 = 4
+	}
+	buf = append(buf, v.Name[:l1]...)
+	buf = append(buf, make([]byte, 4-l1)...)
+	for i2 := 0; i2 < len(v.Data); i2++ {
+		buf = append(buf, byte(v.Data[i2]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecoderRecord from data and returns count of used bytes
+func (v *DecoderRecord) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "ID", Needed: 2, Available: len(b)}
+	}
+	v.ID = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "Name", Needed: 4, Available: len(b)}
+	}
+	s1 := b[:4]
+	for i2, c3 := range s1 {
+		if c3 == 0 {
+			s1 = s1[:i2]
+			break
+		}
+	}
+	if string(v.Name) != string(s1) {
+		v.Name = string(s1)
+	}
+	b = b[4:]
+	for i4 := 0; i4 < len(v.Data); i4++ {
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "Data[" + strconv.Itoa(i4) + "]", Needed: 1, Available: len(b)}
+		}
+		v.Data[i4] = int8(b[0])
+		b = b[1:]
+	}
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes DecoderMessage to bytes
+func (v *DecoderMessage) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of DecoderMessage to buf
+func (v *DecoderMessage) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, byte(v.Type))
+	if uint64(len(v.Body))>>8 != 0 {
+		return nil, fmt.Errorf("DecoderMessage.Body: value %d doesn't fit in 1 bytes", len(v.Body))
+	}
+	buf = append(buf, byte(len(v.Body)))
+	for i1 := 0; i1 < len(v.Body); i1++ {
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16(v.Body[i1]))
+	}
+	if uint64(len(v.Text))>>8 != 0 {
+		return nil, fmt.Errorf("DecoderMessage.Text: value %d doesn't fit in 1 bytes", len(v.Text))
+	}
+	buf = append(buf, byte(len(v.Text)))
+	buf = append(buf, v.Text...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecoderMessage from data and returns count of used bytes
+func (v *DecoderMessage) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Type", Needed: 1, Available: len(b)}
+	}
+	v.Type = b[0]
+	b = b[1:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Body", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	n2 := int(l1)
+	e3 := int(^uint(0) >> 1)
+	if n2 <= e3/(2) {
+		e3 = n2 * (2)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "Body", Needed: e3, Available: len(b)}
+	}
+	c4 := n2
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]uint16, 0, c4)
+	var z6 uint16
+	for i7 := 0; i7 < n2; i7++ {
+		s5 = append(s5, z6)
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "Body[" + strconv.Itoa(i7) + "]", Needed: 2, Available: len(b)}
+		}
+		s5[i7] = endian.Uint16(b)
+		b = b[2:]
+	}
+	v.Body = s5
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Text", Needed: 1, Available: len(b)}
+	}
+	l8 := uint64(b[0])
+	b = b[1:]
+	n9 := int(l8)
+	if len(b) < n9 {
+		return 0, &d2b.ShortBufferError{Path: "Text", Needed: n9, Available: len(b)}
+	}
+	if string(v.Text) != string(b[:n9]) {
+		v.Text = string(b[:n9])
+	}
+	b = b[n9:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes DecoderStruct to bytes
+func (v *DecoderStruct) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of DecoderStruct to buf
+func (v *DecoderStruct) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if strings.IndexByte(v.A, 0) >= 0 {
+		return nil, fmt.Errorf("DecoderStruct.A: cstring can't contain zero byte")
+	}
+	buf = append(buf, v.A...)
+	buf = append(buf, 0)
+	buf = append(buf, byte(v.B))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes DecoderStruct from data and returns count of used bytes
+func (v *DecoderStruct) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	e1 := -1
+	for i2, c3 := range b {
+		if c3 == 0 {
+			e1 = i2
+			break
+		}
+	}
+	if e1 < 0 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: len(b) + 1, Available: len(b)}
+	}
+	if string(v.A) != string(b[:e1]) {
+		v.A = string(b[:e1])
+	}
+	b = b[e1+1:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 1, Available: len(b)}
+	}
+	v.B = b[0]
+	b = b[1:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of EncodeTestStruct1 bytes representation
+func (v *EncodeTestStruct1) SizeD2B() int {
+	return 83
+}
+
+// MarshalD2B encodes EncodeTestStruct1 to bytes
+func (v *EncodeTestStruct1) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of EncodeTestStruct1 to buf
+func (v *EncodeTestStruct1) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	l1 := len(v.FieldA)
+	if l1 > 10 {
+		l1 = 10
+	}
+	buf = append(buf, v.FieldA[:l1]...)
+	buf = append(buf, make([]byte, 10-l1)...)
+	l2 := len(v.FieldB)
+	if l2 > 10 {
+		l2 = 10
+	}
+	buf = append(buf, v.FieldB[:l2]...)
+	buf = append(buf, make([]byte, 10-l2)...)
+	l3 := len(v.Slice)
+	if l3 > 2 {
+		l3 = 2
+	}
+	for i4 := 0; i4 < l3; i4++ {
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(v.Slice[i4]))
+	}
+	if l3 < 2 {
+		buf = append(buf, make([]byte, (2-l3)*(4))...)
+	}
+	for i5 := 0; i5 < len(v.A); i5++ {
+		for i6 := 0; i6 < len(v.A[i5]); i6++ {
+			buf = append(buf, 0, 0)
+			endian.PutUint16(buf[len(buf)-2:], uint16(v.A[i5][i6]))
+		}
+	}
+	if v.QQ != nil {
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32((*v.QQ)))
+	} else {
+		var z7 int32
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(z7))
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.B))
+	buf = append(buf, 0, 0, 0, 0)
+	endian.PutUint32(buf[len(buf)-4:], uint32(v.C))
+	buf = append(buf, 0, 0, 0, 0, 0, 0, 0, 0)
+	endian.PutUint64(buf[len(buf)-8:], uint64(v.D))
+	buf = append(buf, byte(v.E))
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.F))
+	buf = append(buf, 0, 0, 0, 0)
+	endian.PutUint32(buf[len(buf)-4:], uint32(v.G))
+	buf = append(buf, 0, 0, 0, 0, 0, 0, 0, 0)
+	endian.PutUint64(buf[len(buf)-8:], uint64(v.H))
+	buf = append(buf, byte(v.I))
+	if v.Q != nil {
+		buf = append(buf, byte((*v.Q)))
+	} else {
+		var z8 uint8
+		buf = append(buf, byte(z8))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeTestStruct1 from data and returns count of used bytes
+func (v *EncodeTestStruct1) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 10 {
+		return 0, &d2b.ShortBufferError{Path: "FieldA", Needed: 10, Available: len(b)}
+	}
+	s1 := b[:10]
+	for i2, c3 := range s1 {
+		if c3 == 0 {
+			s1 = s1[:i2]
+			break
+		}
+	}
+	if string(v.FieldA) != string(s1) {
+		v.FieldA = string(s1)
+	}
+	b = b[10:]
+	if len(b) < 10 {
+		return 0, &d2b.ShortBufferError{Path: "FieldB", Needed: 10, Available: len(b)}
+	}
+	s4 := b[:10]
+	for i5, c6 := range s4 {
+		if c6 == 0 {
+			s4 = s4[:i5]
+			break
+		}
+	}
+	if string(v.FieldB) != string(s4) {
+		v.FieldB = string(s4)
+	}
+	b = b[10:]
+	if len(v.Slice) < 2 {
+		s7 := make([]int32, 2)
+		copy(s7, v.Slice)
+		v.Slice = s7
+	}
+	for i8 := 0; i8 < len(v.Slice); i8++ {
+		if len(b) < 4 {
+			return 0, &d2b.ShortBufferError{Path: "Slice[" + strconv.Itoa(i8) + "]", Needed: 4, Available: len(b)}
+		}
+		v.Slice[i8] = int32(endian.Uint32(b))
+		b = b[4:]
+	}
+	for i9 := 0; i9 < len(v.A); i9++ {
+		for i10 := 0; i10 < len(v.A[i9]); i10++ {
+			if len(b) < 2 {
+				return 0, &d2b.ShortBufferError{Path: "A[" + strconv.Itoa(i9) + "][" + strconv.Itoa(i10) + "]", Needed: 2, Available: len(b)}
+			}
+			v.A[i9][i10] = int16(endian.Uint16(b))
+			b = b[2:]
+		}
+	}
+	if v.QQ == nil {
+		v.QQ = new(int32)
+	}
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "QQ", Needed: 4, Available: len(b)}
+	}
+	(*v.QQ) = int32(endian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 2, Available: len(b)}
+	}
+	v.B = int16(endian.Uint16(b))
+	b = b[2:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 4, Available: len(b)}
+	}
+	v.C = int32(endian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		return 0, &d2b.ShortBufferError{Path: "D", Needed: 8, Available: len(b)}
+	}
+	v.D = int64(endian.Uint64(b))
+	b = b[8:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "E", Needed: 1, Available: len(b)}
+	}
+	v.E = b[0]
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "F", Needed: 2, Available: len(b)}
+	}
+	v.F = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "G", Needed: 4, Available: len(b)}
+	}
+	v.G = endian.Uint32(b)
+	b = b[4:]
+	if len(b) < 8 {
+		return 0, &d2b.ShortBufferError{Path: "H", Needed: 8, Available: len(b)}
+	}
+	v.H = endian.Uint64(b)
+	b = b[8:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "I", Needed: 1, Available: len(b)}
+	}
+	v.I = int8(b[0])
+	b = b[1:]
+	if v.Q == nil {
+		v.Q = new(uint8)
+	}
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Q", Needed: 1, Available: len(b)}
+	}
+	(*v.Q) = b[0]
+	b = b[1:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes EncodeStruct to bytes
+func (v *EncodeStruct) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of EncodeStruct to buf
+func (v *EncodeStruct) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if uint64(len(v.A))>>8 != 0 {
+		return nil, fmt.Errorf("EncodeStruct.A: value %d doesn't fit in 1 bytes", len(v.A))
+	}
+	buf = append(buf, byte(len(v.A)))
+	buf = append(buf, v.A...)
+	if uint64(len(v.B))>>16 != 0 {
+		return nil, fmt.Errorf("EncodeStruct.B: value %d doesn't fit in 2 bytes", len(v.B))
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(len(v.B)))
+	for i1 := 0; i1 < len(v.B); i1++ {
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16(v.B[i1]))
+	}
+	if v.C != nil {
+		if uint64(len((*v.C)))>>32 != 0 {
+			return nil, fmt.Errorf("EncodeStruct.C: value %d doesn't fit in 4 bytes", len((*v.C)))
+		}
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(len((*v.C))))
+		buf = append(buf, (*v.C)...)
+	} else {
+		var z2 string
+		if uint64(len(z2))>>32 != 0 {
+			return nil, fmt.Errorf("EncodeStruct.C: value %d doesn't fit in 4 bytes", len(z2))
+		}
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(len(z2)))
+		buf = append(buf, z2...)
+	}
+	if len(v.D) > 2 {
+		return nil, fmt.Errorf("EncodeStruct.D: length %d is greater than max length 2", len(v.D))
+	}
+	if uint64(len(v.D))>>8 != 0 {
+		return nil, fmt.Errorf("EncodeStruct.D: value %d doesn't fit in 1 bytes", len(v.D))
+	}
+	buf = append(buf, byte(len(v.D)))
+	for i3 := 0; i3 < len(v.D); i3++ {
+		buf = append(buf, byte(v.D[i3]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeStruct from data and returns count of used bytes
+func (v *EncodeStruct) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	n2 := int(l1)
+	if len(b) < n2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: n2, Available: len(b)}
+	}
+	if string(v.A) != string(b[:n2]) {
+		v.A = string(b[:n2])
+	}
+	b = b[n2:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 2, Available: len(b)}
+	}
+	l3 := uint64(endian.Uint16(b))
+	b = b[2:]
+	n4 := int(l3)
+	e5 := int(^uint(0) >> 1)
+	if n4 <= e5/(2) {
+		e5 = n4 * (2)
+	}
+	if len(b) < e5 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: e5, Available: len(b)}
+	}
+	c6 := n4
+	if c6 > len(b) {
+		c6 = len(b)
+	}
+	s7 := make([]uint16, 0, c6)
+	var z8 uint16
+	for i9 := 0; i9 < n4; i9++ {
+		s7 = append(s7, z8)
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "B[" + strconv.Itoa(i9) + "]", Needed: 2, Available: len(b)}
+		}
+		s7[i9] = endian.Uint16(b)
+		b = b[2:]
+	}
+	v.B = s7
+	if v.C == nil {
+		v.C = new(string)
+	}
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 4, Available: len(b)}
+	}
+	l10 := uint64(endian.Uint32(b))
+	b = b[4:]
+	if l10 > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("EncodeStruct.C: length %d is too big", l10)
+	}
+	n11 := int(l10)
+	if len(b) < n11 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: n11, Available: len(b)}
+	}
+	if string((*v.C)) != string(b[:n11]) {
+		(*v.C) = string(b[:n11])
+	}
+	b = b[n11:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "D", Needed: 1, Available: len(b)}
+	}
+	l12 := uint64(b[0])
+	b = b[1:]
+	if l12 > 2 {
+		return 0, fmt.Errorf("EncodeStruct.D: length %d is greater than max length 2", l12)
+	}
+	n13 := int(l12)
+	e14 := int(^uint(0) >> 1)
+	if n13 <= e14/(1) {
+		e14 = n13 * (1)
+	}
+	if len(b) < e14 {
+		return 0, &d2b.ShortBufferError{Path: "D", Needed: e14, Available: len(b)}
+	}
+	c15 := n13
+	if c15 > len(b) {
+		c15 = len(b)
+	}
+	s16 := make([]int8, 0, c15)
+	var z17 int8
+	for i18 := 0; i18 < n13; i18++ {
+		s16 = append(s16, z17)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "D[" + strconv.Itoa(i18) + "]", Needed: 1, Available: len(b)}
+		}
+		s16[i18] = int8(b[0])
+		b = b[1:]
+	}
+	v.D = s16
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes EncodeStruct2 to bytes
+func (v *EncodeStruct2) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of EncodeStruct2 to buf
+func (v *EncodeStruct2) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if len(v.A) > 2 {
+		return nil, fmt.Errorf("EncodeStruct2.A: length %d is greater than max length 2", len(v.A))
+	}
+	if uint64(len(v.A))>>8 != 0 {
+		return nil, fmt.Errorf("EncodeStruct2.A: value %d doesn't fit in 1 bytes", len(v.A))
+	}
+	buf = append(buf, byte(len(v.A)))
+	for i1 := 0; i1 < len(v.A); i1++ {
+		buf = append(buf, byte(v.A[i1]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeStruct2 from data and returns count of used bytes
+func (v *EncodeStruct2) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	if l1 > 2 {
+		return 0, fmt.Errorf("EncodeStruct2.A: length %d is greater than max length 2", l1)
+	}
+	n2 := int(l1)
+	e3 := int(^uint(0) >> 1)
+	if n2 <= e3/(1) {
+		e3 = n2 * (1)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: e3, Available: len(b)}
+	}
+	c4 := n2
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]int8, 0, c4)
+	var z6 int8
+	for i7 := 0; i7 < n2; i7++ {
+		s5 = append(s5, z6)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "A[" + strconv.Itoa(i7) + "]", Needed: 1, Available: len(b)}
+		}
+		s5[i7] = int8(b[0])
+		b = b[1:]
+	}
+	v.A = s5
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes EncodeStruct22 to bytes
+func (v *EncodeStruct22) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of EncodeStruct22 to buf
+func (v *EncodeStruct22) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if uint64(len(v.A))>>8 != 0 {
+		return nil, fmt.Errorf("EncodeStruct22.A: value %d doesn't fit in 1 bytes", len(v.A))
+	}
+	buf = append(buf, byte(len(v.A)))
+	for i1 := 0; i1 < len(v.A); i1++ {
+		buf = append(buf, byte(v.A[i1]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeStruct22 from data and returns count of used bytes
+func (v *EncodeStruct22) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	n2 := int(l1)
+	e3 := int(^uint(0) >> 1)
+	if n2 <= e3/(1) {
+		e3 = n2 * (1)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: e3, Available: len(b)}
+	}
+	c4 := n2
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]int8, 0, c4)
+	var z6 int8
+	for i7 := 0; i7 < n2; i7++ {
+		s5 = append(s5, z6)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "A[" + strconv.Itoa(i7) + "]", Needed: 1, Available: len(b)}
+		}
+		s5[i7] = int8(b[0])
+		b = b[1:]
+	}
+	v.A = s5
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes EncodeStruct4 to bytes
+func (v *EncodeStruct4) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of EncodeStruct4 to buf
+func (v *EncodeStruct4) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, byte(v.Count))
+	l1 := len(v.Name)
+	if l1 > 2 {
+		l1 = 2
+	}
+	buf = append(buf, v.Name[:l1]...)
+	buf = append(buf, make([]byte, 2-l1)...)
+	l2 := len(v.Records)
+	var n3 int
+	if x4 := uint64(v.Count); x4 > uint64(^uint(0)>>1) {
+		return nil, fmt.Errorf("EncodeStruct4.Records: invalid length %d", x4)
+	} else {
+		n3 = int(x4)
+	}
+	if l2 != n3 {
+		return nil, fmt.Errorf("EncodeStruct4.Records: length %d doesn't match Count field value %d", l2, n3)
+	}
+	for i5 := 0; i5 < len(v.Records); i5++ {
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16(v.Records[i5]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeStruct4 from data and returns count of used bytes
+func (v *EncodeStruct4) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Count", Needed: 1, Available: len(b)}
+	}
+	v.Count = b[0]
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Name", Needed: 2, Available: len(b)}
+	}
+	s1 := b[:2]
+	for i2, c3 := range s1 {
+		if c3 == 0 {
+			s1 = s1[:i2]
+			break
+		}
+	}
+	if string(v.Name) != string(s1) {
+		v.Name = string(s1)
+	}
+	b = b[2:]
+	var n4 int
+	if x5 := uint64(v.Count); x5 > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("EncodeStruct4.Records: invalid length %d", x5)
+	} else {
+		n4 = int(x5)
+	}
+	e6 := int(^uint(0) >> 1)
+	if n4 <= e6/(2) {
+		e6 = n4 * (2)
+	}
+	if len(b) < e6 {
+		return 0, &d2b.ShortBufferError{Path: "Records", Needed: e6, Available: len(b)}
+	}
+	c7 := n4
+	if c7 > len(b) {
+		c7 = len(b)
+	}
+	s8 := make([]int16, 0, c7)
+	var z9 int16
+	for i10 := 0; i10 < n4; i10++ {
+		s8 = append(s8, z9)
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "Records[" + strconv.Itoa(i10) + "]", Needed: 2, Available: len(b)}
+		}
+		s8[i10] = int16(endian.Uint16(b))
+		b = b[2:]
+	}
+	v.Records = s8
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes EncodeStruct5 to bytes
+func (v *EncodeStruct5) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of EncodeStruct5 to buf
+func (v *EncodeStruct5) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	l1 := len(v.Keys)
+	l2 := len(v.Values)
+	if l2 != l1 {
+		return nil, fmt.Errorf("EncodeStruct5.Count: Keys and Values fields have different length: %d and %d", l1, l2)
+	}
+	if uint64(l1) > 32767 {
+		return nil, fmt.Errorf("EncodeStruct5.Count: length %d overflows int16", l1)
+	}
+	l3 := int16(l1)
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(l3))
+	for i4 := 0; i4 < len(v.Keys); i4++ {
+		buf = append(buf, byte(v.Keys[i4]))
+	}
+	for i5 := 0; i5 < len(v.Values); i5++ {
+		buf = append(buf, byte(v.Values[i5]))
+	}
+	l6 := 0
+	if v.Text != nil {
+		l6 = len((*v.Text))
+	}
+	if uint64(l6) > 255 {
+		return nil, fmt.Errorf("EncodeStruct5.Size: length %d overflows uint8", l6)
+	}
+	l7 := uint8(l6)
+	buf = append(buf, byte(l7))
+	l8 := 0
+	if v.Text != nil {
+		l8 = len((*v.Text))
+	}
+	if l8 > 4 {
+		return nil, fmt.Errorf("EncodeStruct5.Text: length %d is greater than max length 4", l8)
+	}
+	if v.Text != nil {
+		buf = append(buf, (*v.Text)...)
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeStruct5 from data and returns count of used bytes
+func (v *EncodeStruct5) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if v.Count == nil {
+		v.Count = new(int16)
+	}
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Count", Needed: 2, Available: len(b)}
+	}
+	(*v.Count) = int16(endian.Uint16(b))
+	b = b[2:]
+	var n1 int
+	if v.Count != nil {
+		if x2 := int64((*v.Count)); x2 < 0 || uint64(x2) > uint64(^uint(0)>>1) {
+			return 0, fmt.Errorf("EncodeStruct5.Keys: invalid length %d", x2)
+		} else {
+			n1 = int(x2)
+		}
+	}
+	e3 := int(^uint(0) >> 1)
+	if n1 <= e3/(1) {
+		e3 = n1 * (1)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "Keys", Needed: e3, Available: len(b)}
+	}
+	c4 := n1
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]uint8, 0, c4)
+	var z6 uint8
+	for i7 := 0; i7 < n1; i7++ {
+		s5 = append(s5, z6)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "Keys[" + strconv.Itoa(i7) + "]", Needed: 1, Available: len(b)}
+		}
+		s5[i7] = b[0]
+		b = b[1:]
+	}
+	v.Keys = s5
+	var n8 int
+	if v.Count != nil {
+		if x9 := int64((*v.Count)); x9 < 0 || uint64(x9) > uint64(^uint(0)>>1) {
+			return 0, fmt.Errorf("EncodeStruct5.Values: invalid length %d", x9)
+		} else {
+			n8 = int(x9)
+		}
+	}
+	e10 := int(^uint(0) >> 1)
+	if n8 <= e10/(1) {
+		e10 = n8 * (1)
+	}
+	if len(b) < e10 {
+		return 0, &d2b.ShortBufferError{Path: "Values", Needed: e10, Available: len(b)}
+	}
+	c11 := n8
+	if c11 > len(b) {
+		c11 = len(b)
+	}
+	s12 := make([]uint8, 0, c11)
+	var z13 uint8
+	for i14 := 0; i14 < n8; i14++ {
+		s12 = append(s12, z13)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "Values[" + strconv.Itoa(i14) + "]", Needed: 1, Available: len(b)}
+		}
+		s12[i14] = b[0]
+		b = b[1:]
+	}
+	v.Values = s12
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Size", Needed: 1, Available: len(b)}
+	}
+	v.Size = b[0]
+	b = b[1:]
+	var n15 int
+	if x16 := uint64(v.Size); x16 > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("EncodeStruct5.Text: invalid length %d", x16)
+	} else {
+		n15 = int(x16)
+	}
+	if n15 > 4 {
+		return 0, fmt.Errorf("EncodeStruct5.Text: length %d is greater than max length 4", n15)
+	}
+	if v.Text == nil {
+		v.Text = new(string)
+	}
+	if len(b) < n15 {
+		return 0, &d2b.ShortBufferError{Path: "Text", Needed: n15, Available: len(b)}
+	}
+	if string((*v.Text)) != string(b[:n15]) {
+		(*v.Text) = string(b[:n15])
+	}
+	b = b[n15:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of EncodePayload bytes representation
+func (v *EncodePayload) SizeD2B() int {
+	return 4
+}
+
+// MarshalD2B encodes EncodePayload to bytes
+func (v *EncodePayload) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of EncodePayload to buf
+func (v *EncodePayload) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.A))
+	buf = append(buf, 0, 0)
+	binary.BigEndian.PutUint16(buf[len(buf)-2:], uint16(v.B))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodePayload from data and returns count of used bytes
+func (v *EncodePayload) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 2, Available: len(b)}
+	}
+	v.A = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 2, Available: len(b)}
+	}
+	v.B = binary.BigEndian.Uint16(b)
+	b = b[2:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes EncodeFrame to bytes
+func (v *EncodeFrame) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of EncodeFrame to buf
+func (v *EncodeFrame) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var err error
+	buf = append(buf, 0, 0)
+	binary.BigEndian.PutUint16(buf[len(buf)-2:], uint16(v.Length))
+	if buf, err = v.Payload.AppendD2B(buf, binary.LittleEndian); err != nil {
+		return nil, fmt.Errorf("EncodeFrame.Payload: %v", err)
+	}
+	for i1 := 0; i1 < len(v.Array); i1++ {
+		buf = append(buf, 0, 0)
+		binary.LittleEndian.PutUint16(buf[len(buf)-2:], uint16(v.Array[i1]))
+	}
+	if uint64(len(v.Slice))>>16 != 0 {
+		return nil, fmt.Errorf("EncodeFrame.Slice: value %d doesn't fit in 2 bytes", len(v.Slice))
+	}
+	buf = append(buf, 0, 0)
+	binary.LittleEndian.PutUint16(buf[len(buf)-2:], uint16(len(v.Slice)))
+	for i2 := 0; i2 < len(v.Slice); i2++ {
+		buf = append(buf, 0, 0)
+		binary.LittleEndian.PutUint16(buf[len(buf)-2:], uint16(v.Slice[i2]))
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.Tail))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeFrame from data and returns count of used bytes
+func (v *EncodeFrame) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	var n int
+	var err error
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Length", Needed: 2, Available: len(b)}
+	}
+	v.Length = binary.BigEndian.Uint16(b)
+	b = b[2:]
+	if n, err = v.Payload.UnmarshalD2B(b, binary.LittleEndian); err != nil {
+		if sb, ok := err.(*d2b.ShortBufferError); ok {
+			return 0, sb.PrependPath("Payload")
+		}
+		return 0, fmt.Errorf("EncodeFrame.Payload: %v", err)
+	}
+	b = b[n:]
+	for i1 := 0; i1 < len(v.Array); i1++ {
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "Array[" + strconv.Itoa(i1) + "]", Needed: 2, Available: len(b)}
+		}
+		v.Array[i1] = binary.LittleEndian.Uint16(b)
+		b = b[2:]
+	}
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Slice", Needed: 2, Available: len(b)}
+	}
+	l2 := uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	n3 := int(l2)
+	e4 := int(^uint(0) >> 1)
+	if n3 <= e4/(2) {
+		e4 = n3 * (2)
+	}
+	if len(b) < e4 {
+		return 0, &d2b.ShortBufferError{Path: "Slice", Needed: e4, Available: len(b)}
+	}
+	c5 := n3
+	if c5 > len(b) {
+		c5 = len(b)
+	}
+	s6 := make([]uint16, 0, c5)
+	var z7 uint16
+	for i8 := 0; i8 < n3; i8++ {
+		s6 = append(s6, z7)
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "Slice[" + strconv.Itoa(i8) + "]", Needed: 2, Available: len(b)}
+		}
+		s6[i8] = binary.LittleEndian.Uint16(b)
+		b = b[2:]
+	}
+	v.Slice = s6
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Tail", Needed: 2, Available: len(b)}
+	}
+	v.Tail = endian.Uint16(b)
+	b = b[2:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of EncodeStruct7 bytes representation
+func (v *EncodeStruct7) SizeD2B() int {
+	return 24
+}
+
+// MarshalD2B encodes EncodeStruct7 to bytes
+func (v *EncodeStruct7) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of EncodeStruct7 to buf
+func (v *EncodeStruct7) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0, 0, 0)
+	endian.PutUint32(buf[len(buf)-4:], uint32(math.Float32bits(float32(v.A))))
+	if v.B != nil {
+		buf = append(buf, 0, 0, 0, 0, 0, 0, 0, 0)
+		endian.PutUint64(buf[len(buf)-8:], uint64(math.Float64bits(float64((*v.B)))))
+	} else {
+		var z1 float64
+		buf = append(buf, 0, 0, 0, 0, 0, 0, 0, 0)
+		endian.PutUint64(buf[len(buf)-8:], uint64(math.Float64bits(float64(z1))))
+	}
+	for i2 := 0; i2 < len(v.C); i2++ {
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(math.Float32bits(float32(v.C[i2]))))
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(d2b.Float16Bits(float32(v.D))))
+	if v.E != nil {
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16(d2b.Float16Bits(float32((*v.E)))))
+	} else {
+		var z3 float64
+		buf = append(buf, 0, 0)
+		endian.PutUint16(buf[len(buf)-2:], uint16(d2b.Float16Bits(float32(z3))))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeStruct7 from data and returns count of used bytes
+func (v *EncodeStruct7) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 4, Available: len(b)}
+	}
+	v.A = math.Float32frombits(endian.Uint32(b))
+	b = b[4:]
+	if v.B == nil {
+		v.B = new(float64)
+	}
+	if len(b) < 8 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 8, Available: len(b)}
+	}
+	(*v.B) = math.Float64frombits(endian.Uint64(b))
+	b = b[8:]
+	for i1 := 0; i1 < len(v.C); i1++ {
+		if len(b) < 4 {
+			return 0, &d2b.ShortBufferError{Path: "C[" + strconv.Itoa(i1) + "]", Needed: 4, Available: len(b)}
+		}
+		v.C[i1] = math.Float32frombits(endian.Uint32(b))
+		b = b[4:]
+	}
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "D", Needed: 2, Available: len(b)}
+	}
+	v.D = d2b.Float16FromBits(endian.Uint16(b))
+	b = b[2:]
+	if v.E == nil {
+		v.E = new(float64)
+	}
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "E", Needed: 2, Available: len(b)}
+	}
+	(*v.E) = float64(d2b.Float16FromBits(endian.Uint16(b)))
+	b = b[2:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of EncodeStructWithBool bytes representation
+func (v *EncodeStructWithBool) SizeD2B() int {
+	return 3
+}
+
+// MarshalD2B encodes EncodeStructWithBool to bytes
+func (v *EncodeStructWithBool) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of EncodeStructWithBool to buf
+func (v *EncodeStructWithBool) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if v.A {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.B))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeStructWithBool from data and returns count of used bytes
+func (v *EncodeStructWithBool) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	v.A = b[0] != 0
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 2, Available: len(b)}
+	}
+	v.B = int16(endian.Uint16(b))
+	b = b[2:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of EncodeStruct9 bytes representation
+func (v *EncodeStruct9) SizeD2B() int {
+	return 15
+}
+
+// MarshalD2B encodes EncodeStruct9 to bytes
+func (v *EncodeStruct9) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of EncodeStruct9 to buf
+func (v *EncodeStruct9) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	x1 := int64(v.A)
+	if x1 < -128 || x1 >= 128 {
+		return nil, fmt.Errorf("EncodeStruct9.A: value %d doesn't fit in 1 bytes", x1)
+	}
+	buf = append(buf, byte(x1))
+	x2 := uint64(v.B)
+	if x2>>16 != 0 {
+		return nil, fmt.Errorf("EncodeStruct9.B: value %d doesn't fit in 2 bytes", x2)
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(x2))
+	if v.C != nil {
+		x3 := int64((*v.C))
+		if x3 < -2147483648 || x3 >= 2147483648 {
+			return nil, fmt.Errorf("EncodeStruct9.C: value %d doesn't fit in 4 bytes", x3)
+		}
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(x3))
+	} else {
+		var z4 int
+		x5 := int64(z4)
+		if x5 < -2147483648 || x5 >= 2147483648 {
+			return nil, fmt.Errorf("EncodeStruct9.C: value %d doesn't fit in 4 bytes", x5)
+		}
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(x5))
+	}
+	x6 := int64(v.D)
+	buf = append(buf, 0, 0, 0, 0, 0, 0, 0, 0)
+	endian.PutUint64(buf[len(buf)-8:], uint64(x6))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeStruct9 from data and returns count of used bytes
+func (v *EncodeStruct9) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	x1 := int64(uint64(b[0])<<56) >> 56
+	v.A = int(x1)
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 2, Available: len(b)}
+	}
+	x2 := uint64(endian.Uint16(b))
+	v.B = uint(x2)
+	b = b[2:]
+	if v.C == nil {
+		v.C = new(int)
+	}
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 4, Available: len(b)}
+	}
+	x3 := int64(uint64(endian.Uint32(b))<<32) >> 32
+	(*v.C) = int(x3)
+	b = b[4:]
+	if len(b) < 8 {
+		return 0, &d2b.ShortBufferError{Path: "D", Needed: 8, Available: len(b)}
+	}
+	x4 := int64(endian.Uint64(b))
+	if int64(int(x4)) != x4 {
+		return 0, fmt.Errorf("EncodeStruct9.D: value %d overflows int", x4)
+	}
+	v.D = int(x4)
+	b = b[8:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of EncodeStruct10 bytes representation
+func (v *EncodeStruct10) SizeD2B() int {
+	return 3
+}
+
+// MarshalD2B encodes EncodeStruct10 to bytes
+func (v *EncodeStruct10) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of EncodeStruct10 to buf
+func (v *EncodeStruct10) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	x1 := int64(v.A)
+	if x1 < -128 || x1 >= 128 {
+		return nil, fmt.Errorf("EncodeStruct10.A: value %d doesn't fit in 1 bytes", x1)
+	}
+	buf = append(buf, byte(x1))
+	x2 := uint64(v.B)
+	if x2>>16 != 0 {
+		return nil, fmt.Errorf("EncodeStruct10.B: value %d doesn't fit in 2 bytes", x2)
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(x2))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeStruct10 from data and returns count of used bytes
+func (v *EncodeStruct10) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	x1 := int64(uint64(b[0])<<56) >> 56
+	v.A = int(x1)
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 2, Available: len(b)}
+	}
+	x2 := uint64(endian.Uint16(b))
+	v.B = uint(x2)
+	b = b[2:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes EncodeStruct11 to bytes
+func (v *EncodeStruct11) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of EncodeStruct11 to buf
+func (v *EncodeStruct11) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if strings.IndexByte(v.A, 0) >= 0 {
+		return nil, fmt.Errorf("EncodeStruct11.A: cstring can't contain zero byte")
+	}
+	buf = append(buf, v.A...)
+	buf = append(buf, 0)
+	l1 := len(v.B)
+	if l1 > 6 {
+		l1 = 6
+	}
+	buf = append(buf, v.B[:l1]...)
+	for i2 := l1; i2 < 6; i2++ {
+		buf = append(buf, 32)
+	}
+	if v.C != nil {
+		l3 := len((*v.C))
+		if l3 > 3 {
+			l3 = 3
+		}
+		buf = append(buf, (*v.C)[:l3]...)
+		buf = append(buf, make([]byte, 4-l3)...)
+	} else {
+		var z4 string
+		l5 := len(z4)
+		if l5 > 3 {
+			l5 = 3
+		}
+		buf = append(buf, z4[:l5]...)
+		buf = append(buf, make([]byte, 4-l5)...)
+	}
+	l6 := len(v.D)
+	if l6 > 3 {
+		l6 = 3
+	}
+	buf = append(buf, v.D[:l6]...)
+	buf = append(buf, make([]byte, 3-l6)...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeStruct11 from data and returns count of used bytes
+func (v *EncodeStruct11) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	e1 := -1
+	for i2, c3 := range b {
+		if c3 == 0 {
+			e1 = i2
+			break
+		}
+	}
+	if e1 < 0 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: len(b) + 1, Available: len(b)}
+	}
+	if string(v.A) != string(b[:e1]) {
+		v.A = string(b[:e1])
+	}
+	b = b[e1+1:]
+	if len(b) < 6 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 6, Available: len(b)}
+	}
+	s4 := b[:6]
+	if string(v.B) != string(s4) {
+		v.B = string(s4)
+	}
+	b = b[6:]
+	if v.C == nil {
+		v.C = new(string)
+	}
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 4, Available: len(b)}
+	}
+	s5 := b[:4]
+	for i6, c7 := range s5 {
+		if c7 == 0 {
+			s5 = s5[:i6]
+			break
+		}
+	}
+	if string((*v.C)) != string(s5) {
+		(*v.C) = string(s5)
+	}
+	b = b[4:]
+	if len(b) < 3 {
+		return 0, &d2b.ShortBufferError{Path: "D", Needed: 3, Available: len(b)}
+	}
+	s8 := b[:3]
+	for i9, c10 := range s8 {
+		if c10 == 0 {
+			s8 = s8[:i9]
+			break
+		}
+	}
+	if string(v.D) != string(s8) {
+		v.D = string(s8)
+	}
+	b = b[3:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of EncodeStruct12 bytes representation
+func (v *EncodeStruct12) SizeD2B() int {
+	return 6
+}
+
+// MarshalD2B encodes EncodeStruct12 to bytes
+func (v *EncodeStruct12) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of EncodeStruct12 to buf
+func (v *EncodeStruct12) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	l1 := len(v.A)
+	if l1 > 3 {
+		return nil, fmt.Errorf("EncodeStruct12.A: string length %d is greater than 3", l1)
+	}
+	buf = append(buf, v.A[:l1]...)
+	buf = append(buf, make([]byte, 3-l1)...)
+	l2 := len(v.B)
+	if l2 > 2 {
+		return nil, fmt.Errorf("EncodeStruct12.B: string length %d is greater than 2", l2)
+	}
+	buf = append(buf, v.B[:l2]...)
+	buf = append(buf, make([]byte, 3-l2)...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeStruct12 from data and returns count of used bytes
+func (v *EncodeStruct12) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 3 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 3, Available: len(b)}
+	}
+	s1 := b[:3]
+	for i2, c3 := range s1 {
+		if c3 == 0 {
+			s1 = s1[:i2]
+			break
+		}
+	}
+	if string(v.A) != string(s1) {
+		v.A = string(s1)
+	}
+	b = b[3:]
+	if len(b) < 3 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 3, Available: len(b)}
+	}
+	s4 := b[:3]
+	for i5, c6 := range s4 {
+		if c6 == 0 {
+			s4 = s4[:i5]
+			break
+		}
+	}
+	if string(v.B) != string(s4) {
+		v.B = string(s4)
+	}
+	b = b[3:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes EncodeToStruct to bytes
+func (v *EncodeToStruct) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of EncodeToStruct to buf
+func (v *EncodeToStruct) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.A))
+	if uint64(len(v.B))>>8 != 0 {
+		return nil, fmt.Errorf("EncodeToStruct.B: value %d doesn't fit in 1 bytes", len(v.B))
+	}
+	buf = append(buf, byte(len(v.B)))
+	buf = append(buf, v.B...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeToStruct from data and returns count of used bytes
+func (v *EncodeToStruct) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 2, Available: len(b)}
+	}
+	v.A = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	n2 := int(l1)
+	if len(b) < n2 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: n2, Available: len(b)}
+	}
+	if string(v.B) != string(b[:n2]) {
+		v.B = string(b[:n2])
+	}
+	b = b[n2:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes EncodeToLimited to bytes
+func (v *EncodeToLimited) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of EncodeToLimited to buf
+func (v *EncodeToLimited) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if len(v.A) > 1 {
+		return nil, fmt.Errorf("EncodeToLimited.A: length %d is greater than max length 1", len(v.A))
+	}
+	if uint64(len(v.A))>>8 != 0 {
+		return nil, fmt.Errorf("EncodeToLimited.A: value %d doesn't fit in 1 bytes", len(v.A))
+	}
+	buf = append(buf, byte(len(v.A)))
+	for i1 := 0; i1 < len(v.A); i1++ {
+		buf = append(buf, byte(v.A[i1]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncodeToLimited from data and returns count of used bytes
+func (v *EncodeToLimited) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	if l1 > 1 {
+		return 0, fmt.Errorf("EncodeToLimited.A: length %d is greater than max length 1", l1)
+	}
+	n2 := int(l1)
+	e3 := int(^uint(0) >> 1)
+	if n2 <= e3/(1) {
+		e3 = n2 * (1)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: e3, Available: len(b)}
+	}
+	c4 := n2
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]uint8, 0, c4)
+	var z6 uint8
+	for i7 := 0; i7 < n2; i7++ {
+		s5 = append(s5, z6)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "A[" + strconv.Itoa(i7) + "]", Needed: 1, Available: len(b)}
+		}
+		s5[i7] = b[0]
+		b = b[1:]
+	}
+	v.A = s5
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of EncoderRecord bytes representation
+func (v *EncoderRecord) SizeD2B() int {
+	return 12
+}
+
+// MarshalD2B encodes EncoderRecord to bytes
+func (v *EncoderRecord) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of EncoderRecord to buf
+func (v *EncoderRecord) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.ID))
+	l1 := len(v.Name)
+	if l1 > 4 {
+		l1 = 4
+	}
+	buf = append(buf, v.Name[:l1]...)
+	buf = append(buf, make([]byte, 4-l1)...)
+	l2 := len(v.Data)
+	if l2 > 2 {
+		l2 = 2
+	}
+	for i3 := 0; i3 < l2; i3++ {
+		buf = append(buf, byte(v.Data[i3]))
+	}
+	if l2 < 2 {
+		buf = append(buf, make([]byte, (2-l2)*(1))...)
+	}
+	if v.Value != nil {
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32((*v.Value)))
+	} else {
+		var z4 uint32
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(z4))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EncoderRecord from data and returns count of used bytes
+func (v *EncoderRecord) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "ID", Needed: 2, Available: len(b)}
+	}
+	v.ID = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "Name", Needed: 4, Available: len(b)}
+	}
+	s1 := b[:4]
+	for i2, c3 := range s1 {
+		if c3 == 0 {
+			s1 = s1[:i2]
+			break
+		}
+	}
+	if string(v.Name) != string(s1) {
+		v.Name = string(s1)
+	}
+	b = b[4:]
+	if len(v.Data) < 2 {
+		s4 := make([]int8, 2)
+		copy(s4, v.Data)
+		v.Data = s4
+	}
+	for i5 := 0; i5 < len(v.Data); i5++ {
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "Data[" + strconv.Itoa(i5) + "]", Needed: 1, Available: len(b)}
+		}
+		v.Data[i5] = int8(b[0])
+		b = b[1:]
+	}
+	if v.Value == nil {
+		v.Value = new(uint32)
+	}
+	if len(b) < 4 {
+		return 0, &d2b.ShortBufferError{Path: "Value", Needed: 4, Available: len(b)}
+	}
+	(*v.Value) = endian.Uint32(b)
+	b = b[4:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of UnexportedFieldsStruct bytes representation
+func (v *UnexportedFieldsStruct) SizeD2B() int {
+	return 4
+}
+
+// MarshalD2B encodes UnexportedFieldsStruct to bytes
+func (v *UnexportedFieldsStruct) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of UnexportedFieldsStruct to buf
+func (v *UnexportedFieldsStruct) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var acc1 uint64
+	x2 := uint64(v.A)
+	if x2&^0xf != 0 {
+		return nil, fmt.Errorf("UnexportedFieldsStruct.A: value %d doesn't fit in 4 bits", x2)
+	}
+	acc1 = acc1<<4 | x2
+	acc1 <<= 4
+	var a3 [8]byte
+	binary.BigEndian.PutUint64(a3[:], acc1)
+	buf = append(buf, a3[7:]...)
+	var z4 [2]byte
+	for i5 := 0; i5 < len(z4); i5++ {
+		buf = append(buf, byte(z4[i5]))
+	}
+	buf = append(buf, byte(v.B))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes UnexportedFieldsStruct from data and returns count of used bytes
+func (v *UnexportedFieldsStruct) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	var a1 [8]byte
+	copy(a1[7:], b[:1])
+	acc2 := binary.BigEndian.Uint64(a1[:])
+	v.A = uint8((acc2 >> 4 & 0xf))
+	b = b[1:]
+	z3 := new([2]byte)
+	for i4 := 0; i4 < len((*z3)); i4++ {
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "_[" + strconv.Itoa(i4) + "]", Needed: 1, Available: len(b)}
+		}
+		(*z3)[i4] = byte(b[0])
+		b = b[1:]
+	}
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 1, Available: len(b)}
+	}
+	v.B = b[0]
+	b = b[1:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of UnexportedFieldsStruct3 bytes representation
+func (v *UnexportedFieldsStruct3) SizeD2B() int {
+	return 3
+}
+
+// MarshalD2B encodes UnexportedFieldsStruct3 to bytes
+func (v *UnexportedFieldsStruct3) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of UnexportedFieldsStruct3 to buf
+func (v *UnexportedFieldsStruct3) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var acc1 uint64
+	acc1 <<= 4
+	x2 := uint64(v.A)
+	if x2&^0x3 != 0 {
+		return nil, fmt.Errorf("UnexportedFieldsStruct3.A: value %d doesn't fit in 2 bits", x2)
+	}
+	acc1 = acc1<<2 | x2
+	acc1 <<= 2
+	var a3 [8]byte
+	binary.BigEndian.PutUint64(a3[:], acc1)
+	buf = append(buf, a3[7:]...)
+	buf = append(buf, byte(v.b))
+	buf = append(buf, byte(v.C))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes UnexportedFieldsStruct3 from data and returns count of used bytes
+func (v *UnexportedFieldsStruct3) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "_", Needed: 1, Available: len(b)}
+	}
+	var a1 [8]byte
+	copy(a1[7:], b[:1])
+	acc2 := binary.BigEndian.Uint64(a1[:])
+	v.A = uint8((acc2 >> 2 & 0x3))
+	b = b[1:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "b", Needed: 1, Available: len(b)}
+	}
+	v.b = b[0]
+	b = b[1:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 1, Available: len(b)}
+	}
+	v.C = b[0]
+	b = b[1:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of UnexportedFieldsBits bytes representation
+func (v *UnexportedFieldsBits) SizeD2B() int {
+	return 1
+}
+
+// MarshalD2B encodes UnexportedFieldsBits to bytes
+func (v *UnexportedFieldsBits) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of UnexportedFieldsBits to buf
+func (v *UnexportedFieldsBits) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var acc1 uint64
+	x2 := uint64(v.a)
+	if x2&^0xf != 0 {
+		return nil, fmt.Errorf("UnexportedFieldsBits.a: value %d doesn't fit in 4 bits", x2)
+	}
+	acc1 = acc1<<4 | x2
+	x3 := uint64(v.B)
+	if x3&^0xf != 0 {
+		return nil, fmt.Errorf("UnexportedFieldsBits.B: value %d doesn't fit in 4 bits", x3)
+	}
+	acc1 = acc1<<4 | x3
+	var a4 [8]byte
+	binary.BigEndian.PutUint64(a4[:], acc1)
+	buf = append(buf, a4[7:]...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes UnexportedFieldsBits from data and returns count of used bytes
+func (v *UnexportedFieldsBits) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "a", Needed: 1, Available: len(b)}
+	}
+	var a1 [8]byte
+	copy(a1[7:], b[:1])
+	acc2 := binary.BigEndian.Uint64(a1[:])
+	v.a = uint8((acc2 >> 4 & 0xf))
+	v.B = uint8((acc2 >> 0 & 0xf))
+	b = b[1:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes UnexportedFieldsLength to bytes
+func (v *UnexportedFieldsLength) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of UnexportedFieldsLength to buf
+func (v *UnexportedFieldsLength) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, byte(v.n))
+	l1 := len(v.A)
+	var n2 int
+	if x3 := uint64(v.n); x3 > uint64(^uint(0)>>1) {
+		return nil, fmt.Errorf("UnexportedFieldsLength.A: invalid length %d", x3)
+	} else {
+		n2 = int(x3)
+	}
+	if l1 != n2 {
+		return nil, fmt.Errorf("UnexportedFieldsLength.A: length %d doesn't match n field value %d", l1, n2)
+	}
+	for i4 := 0; i4 < len(v.A); i4++ {
+		buf = append(buf, byte(v.A[i4]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes UnexportedFieldsLength from data and returns count of used bytes
+func (v *UnexportedFieldsLength) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "n", Needed: 1, Available: len(b)}
+	}
+	v.n = b[0]
+	b = b[1:]
+	var n1 int
+	if x2 := uint64(v.n); x2 > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("UnexportedFieldsLength.A: invalid length %d", x2)
+	} else {
+		n1 = int(x2)
+	}
+	e3 := int(^uint(0) >> 1)
+	if n1 <= e3/(1) {
+		e3 = n1 * (1)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: e3, Available: len(b)}
+	}
+	c4 := n1
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]uint8, 0, c4)
+	var z6 uint8
+	for i7 := 0; i7 < n1; i7++ {
+		s5 = append(s5, z6)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "A[" + strconv.Itoa(i7) + "]", Needed: 1, Available: len(b)}
+		}
+		s5[i7] = b[0]
+		b = b[1:]
+	}
+	v.A = s5
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes EmbeddedStructsPacket to bytes
+func (v *EmbeddedStructsPacket) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of EmbeddedStructsPacket to buf
+func (v *EmbeddedStructsPacket) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, byte(v.testHeader.Type))
+	l1 := len(v.Payload)
+	if uint64(l1) > 65535 {
+		return nil, fmt.Errorf("EmbeddedStructsPacket.Length: length %d overflows uint16", l1)
+	}
+	l2 := uint16(l1)
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(l2))
+	for i3 := 0; i3 < len(v.Payload); i3++ {
+		buf = append(buf, byte(v.Payload[i3]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EmbeddedStructsPacket from data and returns count of used bytes
+func (v *EmbeddedStructsPacket) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Type", Needed: 1, Available: len(b)}
+	}
+	v.testHeader.Type = b[0]
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Length", Needed: 2, Available: len(b)}
+	}
+	v.testHeader.Length = endian.Uint16(b)
+	b = b[2:]
+	var n1 int
+	if x2 := uint64(v.testHeader.Length); x2 > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("EmbeddedStructsPacket.Payload: invalid length %d", x2)
+	} else {
+		n1 = int(x2)
+	}
+	e3 := int(^uint(0) >> 1)
+	if n1 <= e3/(1) {
+		e3 = n1 * (1)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "Payload", Needed: e3, Available: len(b)}
+	}
+	c4 := n1
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]byte, 0, c4)
+	var z6 byte
+	for i7 := 0; i7 < n1; i7++ {
+		s5 = append(s5, z6)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "Payload[" + strconv.Itoa(i7) + "]", Needed: 1, Available: len(b)}
+		}
+		s5[i7] = byte(b[0])
+		b = b[1:]
+	}
+	v.Payload = s5
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of EmbeddedStructsPacket2 bytes representation
+func (v *EmbeddedStructsPacket2) SizeD2B() int {
+	return 4 + new(testIPv4).SizeD2B()
+}
+
+// MarshalD2B encodes EmbeddedStructsPacket2 to bytes
+func (v *EmbeddedStructsPacket2) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of EmbeddedStructsPacket2 to buf
+func (v *EmbeddedStructsPacket2) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var err error
+	buf = append(buf, byte(v.testHeaderWithIP.testHeader.Type))
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.testHeaderWithIP.testHeader.Length))
+	var m1 []byte
+	if m1, err = v.testHeaderWithIP.Addr.MarshalD2B(endian); err != nil {
+		return nil, fmt.Errorf("EmbeddedStructsPacket2.Addr: can't marshal testIPv4: %v", err)
+	}
+	if len(m1) != v.testHeaderWithIP.Addr.SizeD2B() {
+		return nil, fmt.Errorf("EmbeddedStructsPacket2.Addr: marshaled to %d bytes, but it's size is %d", len(m1), v.testHeaderWithIP.Addr.SizeD2B())
+	}
+	buf = append(buf, m1...)
+	var acc2 uint64
+	x3 := uint64(v.EmbeddedStructsFlags.A)
+	if x3&^0xf != 0 {
+		return nil, fmt.Errorf("EmbeddedStructsPacket2.A: value %d doesn't fit in 4 bits", x3)
+	}
+	acc2 = acc2<<4 | x3
+	x4 := uint64(v.B)
+	if x4&^0xf != 0 {
+		return nil, fmt.Errorf("EmbeddedStructsPacket2.B: value %d doesn't fit in 4 bits", x4)
+	}
+	acc2 = acc2<<4 | x4
+	var a5 [8]byte
+	binary.BigEndian.PutUint64(a5[:], acc2)
+	buf = append(buf, a5[7:]...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes EmbeddedStructsPacket2 from data and returns count of used bytes
+func (v *EmbeddedStructsPacket2) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	var n int
+	var err error
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Type", Needed: 1, Available: len(b)}
+	}
+	v.testHeaderWithIP.testHeader.Type = b[0]
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Length", Needed: 2, Available: len(b)}
+	}
+	v.testHeaderWithIP.testHeader.Length = endian.Uint16(b)
+	b = b[2:]
+	s1 := v.testHeaderWithIP.Addr.SizeD2B()
+	if len(b) < s1 {
+		return 0, &d2b.ShortBufferError{Path: "Addr", Needed: s1, Available: len(b)}
+	}
+	if n, err = v.testHeaderWithIP.Addr.UnmarshalD2B(b[:s1], endian); err != nil {
+		if sb, ok := err.(*d2b.ShortBufferError); ok {
+			return 0, sb.PrependPath("Addr")
+		}
+		return 0, fmt.Errorf("EmbeddedStructsPacket2.Addr: %v", err)
+	}
+	if n < 0 || n > s1 {
+		return 0, fmt.Errorf("EmbeddedStructsPacket2.Addr: testIPv4 unmarshaler used %d bytes of %d", n, s1)
+	}
+	b = b[n:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	var a2 [8]byte
+	copy(a2[7:], b[:1])
+	acc3 := binary.BigEndian.Uint64(a2[:])
+	v.EmbeddedStructsFlags.A = uint8((acc3 >> 4 & 0xf))
+	v.B = uint8((acc3 >> 0 & 0xf))
+	b = b[1:]
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of MapsKey bytes representation
+func (v *MapsKey) SizeD2B() int {
+	return 2
+}
+
+// MarshalD2B encodes MapsKey to bytes
+func (v *MapsKey) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of MapsKey to buf
+func (v *MapsKey) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, byte(v.A))
+	buf = append(buf, byte(v.B))
+	return buf, nil
+}
+
+// UnmarshalD2B decodes MapsKey from data and returns count of used bytes
+func (v *MapsKey) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	v.A = b[0]
+	b = b[1:]
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 1, Available: len(b)}
+	}
+	v.B = b[0]
+	b = b[1:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes MapsValue to bytes
+func (v *MapsValue) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of MapsValue to buf
+func (v *MapsValue) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if uint64(len(v.S))>>8 != 0 {
+		return nil, fmt.Errorf("MapsValue.S: value %d doesn't fit in 1 bytes", len(v.S))
+	}
+	buf = append(buf, byte(len(v.S)))
+	for i1 := 0; i1 < len(v.S); i1++ {
+		buf = append(buf, byte(v.S[i1]))
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes MapsValue from data and returns count of used bytes
+func (v *MapsValue) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "S", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	n2 := int(l1)
+	e3 := int(^uint(0) >> 1)
+	if n2 <= e3/(1) {
+		e3 = n2 * (1)
+	}
+	if len(b) < e3 {
+		return 0, &d2b.ShortBufferError{Path: "S", Needed: e3, Available: len(b)}
+	}
+	c4 := n2
+	if c4 > len(b) {
+		c4 = len(b)
+	}
+	s5 := make([]uint8, 0, c4)
+	var z6 uint8
+	for i7 := 0; i7 < n2; i7++ {
+		s5 = append(s5, z6)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "S[" + strconv.Itoa(i7) + "]", Needed: 1, Available: len(b)}
+		}
+		s5[i7] = b[0]
+		b = b[1:]
+	}
+	v.S = s5
+	return len(data) - len(b), nil
+}
+
+// SizeD2B returns length of SizeStruct bytes representation
+func (v *SizeStruct) SizeD2B() int {
+	return 17
+}
+
+// MarshalD2B encodes SizeStruct to bytes
+func (v *SizeStruct) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)
+}
+
+// AppendD2B appends bytes representation of SizeStruct to buf
+func (v *SizeStruct) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(v.A))
+	l1 := len(v.B)
+	if l1 > 5 {
+		l1 = 5
+	}
+	buf = append(buf, v.B[:l1]...)
+	buf = append(buf, make([]byte, 5-l1)...)
+	l2 := len(v.C)
+	if l2 > 2 {
+		l2 = 2
+	}
+	for i3 := 0; i3 < l2; i3++ {
+		buf = append(buf, 0, 0, 0, 0)
+		endian.PutUint32(buf[len(buf)-4:], uint32(v.C[i3]))
+	}
+	if l2 < 2 {
+		buf = append(buf, make([]byte, (2-l2)*(4))...)
+	}
+	if v.D != nil {
+		for i4 := 0; i4 < len((*v.D)); i4++ {
+			if (*v.D)[i4] {
+				buf = append(buf, 1)
+			} else {
+				buf = append(buf, 0)
+			}
+		}
+	} else {
+		var z5 [2]bool
+		for i6 := 0; i6 < len(z5); i6++ {
+			if z5[i6] {
+				buf = append(buf, 1)
+			} else {
+				buf = append(buf, 0)
+			}
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes SizeStruct from data and returns count of used bytes
+func (v *SizeStruct) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 2, Available: len(b)}
+	}
+	v.A = endian.Uint16(b)
+	b = b[2:]
+	if len(b) < 5 {
+		return 0, &d2b.ShortBufferError{Path: "B", Needed: 5, Available: len(b)}
+	}
+	s1 := b[:5]
+	for i2, c3 := range s1 {
+		if c3 == 0 {
+			s1 = s1[:i2]
+			break
+		}
+	}
+	if string(v.B) != string(s1) {
+		v.B = string(s1)
+	}
+	b = b[5:]
+	if len(v.C) < 2 {
+		s4 := make([]int32, 2)
+		copy(s4, v.C)
+		v.C = s4
+	}
+	for i5 := 0; i5 < len(v.C); i5++ {
+		if len(b) < 4 {
+			return 0, &d2b.ShortBufferError{Path: "C[" + strconv.Itoa(i5) + "]", Needed: 4, Available: len(b)}
+		}
+		v.C[i5] = int32(endian.Uint32(b))
+		b = b[4:]
+	}
+	if v.D == nil {
+		v.D = new([2]bool)
+	}
+	for i6 := 0; i6 < len((*v.D)); i6++ {
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "D[" + strconv.Itoa(i6) + "]", Needed: 1, Available: len(b)}
+		}
+		(*v.D)[i6] = b[0] != 0
+		b = b[1:]
+	}
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes SizeStruct2 to bytes
+func (v *SizeStruct2) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of SizeStruct2 to buf
+func (v *SizeStruct2) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	if uint64(len(v.A))>>8 != 0 {
+		return nil, fmt.Errorf("SizeStruct2.A: value %d doesn't fit in 1 bytes", len(v.A))
+	}
+	buf = append(buf, byte(len(v.A)))
+	buf = append(buf, v.A...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes SizeStruct2 from data and returns count of used bytes
+func (v *SizeStruct2) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: 1, Available: len(b)}
+	}
+	l1 := uint64(b[0])
+	b = b[1:]
+	n2 := int(l1)
+	if len(b) < n2 {
+		return 0, &d2b.ShortBufferError{Path: "A", Needed: n2, Available: len(b)}
+	}
+	if string(v.A) != string(b[:n2]) {
+		v.A = string(b[:n2])
+	}
+	b = b[n2:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes SizeItem to bytes
+func (v *SizeItem) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of SizeItem to buf
+func (v *SizeItem) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	buf = append(buf, byte(v.ID))
+	if uint64(len(v.Name))>>16 != 0 {
+		return nil, fmt.Errorf("SizeItem.Name: value %d doesn't fit in 2 bytes", len(v.Name))
+	}
+	buf = append(buf, 0, 0)
+	endian.PutUint16(buf[len(buf)-2:], uint16(len(v.Name)))
+	buf = append(buf, v.Name...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes SizeItem from data and returns count of used bytes
+func (v *SizeItem) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "ID", Needed: 1, Available: len(b)}
+	}
+	v.ID = b[0]
+	b = b[1:]
+	if len(b) < 2 {
+		return 0, &d2b.ShortBufferError{Path: "Name", Needed: 2, Available: len(b)}
+	}
+	l1 := uint64(endian.Uint16(b))
+	b = b[2:]
+	n2 := int(l1)
+	if len(b) < n2 {
+		return 0, &d2b.ShortBufferError{Path: "Name", Needed: n2, Available: len(b)}
+	}
+	if string(v.Name) != string(b[:n2]) {
+		v.Name = string(b[:n2])
+	}
+	b = b[n2:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes SizeStruct3 to bytes
+func (v *SizeStruct3) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of SizeStruct3 to buf
+func (v *SizeStruct3) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var err error
+	l1 := len(v.Items)
+	if uint64(l1) > 255 {
+		return nil, fmt.Errorf("SizeStruct3.Count: length %d overflows uint8", l1)
+	}
+	l2 := uint8(l1)
+	buf = append(buf, byte(l2))
+	for i3 := 0; i3 < len(v.Items); i3++ {
+		if buf, err = v.Items[i3].AppendD2B(buf, endian); err != nil {
+			return nil, fmt.Errorf("SizeStruct3.Items: %v", err)
+		}
+	}
+	if v.Comment != nil {
+		if uint64(len((*v.Comment)))>>8 != 0 {
+			return nil, fmt.Errorf("SizeStruct3.Comment: value %d doesn't fit in 1 bytes", len((*v.Comment)))
+		}
+		buf = append(buf, byte(len((*v.Comment))))
+		buf = append(buf, (*v.Comment)...)
+	} else {
+		var z4 string
+		if uint64(len(z4))>>8 != 0 {
+			return nil, fmt.Errorf("SizeStruct3.Comment: value %d doesn't fit in 1 bytes", len(z4))
+		}
+		buf = append(buf, byte(len(z4)))
+		buf = append(buf, z4...)
+	}
+	var m5 []byte
+	if m5, err = v.Custom.MarshalD2B(endian); err != nil {
+		return nil, fmt.Errorf("SizeStruct3.Custom: can't marshal testPascalString: %v", err)
+	}
+	buf = append(buf, m5...)
+	return buf, nil
+}
+
+// UnmarshalD2B decodes SizeStruct3 from data and returns count of used bytes
+func (v *SizeStruct3) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	var n int
+	var err error
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Count", Needed: 1, Available: len(b)}
+	}
+	v.Count = b[0]
+	b = b[1:]
+	var n1 int
+	if x2 := uint64(v.Count); x2 > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("SizeStruct3.Items: invalid length %d", x2)
+	} else {
+		n1 = int(x2)
+	}
+	c3 := n1
+	if c3 > len(b) {
+		c3 = len(b)
+	}
+	s4 := make([]SizeItem, 0, c3)
+	var z5 SizeItem
+	for i6 := 0; i6 < n1; i6++ {
+		s4 = append(s4, z5)
+		if n, err = s4[i6].UnmarshalD2B(b, endian); err != nil {
+			if sb, ok := err.(*d2b.ShortBufferError); ok {
+				return 0, sb.PrependPath("Items[" + strconv.Itoa(i6) + "]")
+			}
+			return 0, fmt.Errorf("SizeStruct3.Items: %v", err)
+		}
+		b = b[n:]
+	}
+	v.Items = s4
+	if v.Comment == nil {
+		v.Comment = new(string)
+	}
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "Comment", Needed: 1, Available: len(b)}
+	}
+	l7 := uint64(b[0])
+	b = b[1:]
+	n8 := int(l7)
+	if len(b) < n8 {
+		return 0, &d2b.ShortBufferError{Path: "Comment", Needed: n8, Available: len(b)}
+	}
+	if string((*v.Comment)) != string(b[:n8]) {
+		(*v.Comment) = string(b[:n8])
+	}
+	b = b[n8:]
+	if n, err = v.Custom.UnmarshalD2B(b, endian); err != nil {
+		if sb, ok := err.(*d2b.ShortBufferError); ok {
+			return 0, sb.PrependPath("Custom")
+		}
+		return 0, fmt.Errorf("SizeStruct3.Custom: %v", err)
+	}
+	if n < 0 || n > len(b) {
+		return 0, fmt.Errorf("SizeStruct3.Custom: testPascalString unmarshaler used %d bytes of %d", n, len(b))
+	}
+	b = b[n:]
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes SizeStruct4 to bytes
+func (v *SizeStruct4) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of SizeStruct4 to buf
+func (v *SizeStruct4) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	var err error
+	l1 := len(v.A)
+	if l1 > 2 {
+		l1 = 2
+	}
+	for i2 := 0; i2 < l1; i2++ {
+		var m3 []byte
+		if m3, err = v.A[i2].MarshalD2B(endian); err != nil {
+			return nil, fmt.Errorf("SizeStruct4.A: can't marshal testPascalString: %v", err)
+		}
+		buf = append(buf, m3...)
+	}
+	if l1 < 2 {
+		return nil, fmt.Errorf("SizeStruct4.A: can't calculate slice element type length: type testPascalString has variable length")
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes SizeStruct4 from data and returns count of used bytes
+func (v *SizeStruct4) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	var n int
+	var err error
+	if len(v.A) < 2 {
+		s1 := make([]testPascalString, 2)
+		copy(s1, v.A)
+		v.A = s1
+	}
+	for i2 := 0; i2 < len(v.A); i2++ {
+		if n, err = v.A[i2].UnmarshalD2B(b, endian); err != nil {
+			if sb, ok := err.(*d2b.ShortBufferError); ok {
+				return 0, sb.PrependPath("A[" + strconv.Itoa(i2) + "]")
+			}
+			return 0, fmt.Errorf("SizeStruct4.A: %v", err)
+		}
+		if n < 0 || n > len(b) {
+			return 0, fmt.Errorf("SizeStruct4.A: testPascalString unmarshaler used %d bytes of %d", n, len(b))
+		}
+		b = b[n:]
+	}
+	return len(data) - len(b), nil
+}
+
+// MarshalD2B encodes ElemOptionsStruct to bytes
+func (v *ElemOptionsStruct) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return v.AppendD2B(nil, endian)
+}
+
+// AppendD2B appends bytes representation of ElemOptionsStruct to buf
+func (v *ElemOptionsStruct) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {
+	for i1 := 0; i1 < len(v.A); i1++ {
+		l2 := len(v.A[i1])
+		if l2 > 3 {
+			l2 = 3
+		}
+		buf = append(buf, v.A[i1][:l2]...)
+		buf = append(buf, make([]byte, 3-l2)...)
+	}
+	l3 := len(v.B)
+	if l3 > 2 {
+		l3 = 2
+	}
+	for i4 := 0; i4 < l3; i4++ {
+		l5 := len(v.B[i4])
+		if l5 > 2 {
+			l5 = 2
+		}
+		buf = append(buf, v.B[i4][:l5]...)
+		for i6 := l5; i6 < 2; i6++ {
+			buf = append(buf, 32)
+		}
+	}
+	if l3 < 2 {
+		buf = append(buf, make([]byte, (2-l3)*(2))...)
+	}
+	if uint64(len(v.C))>>8 != 0 {
+		return nil, fmt.Errorf("ElemOptionsStruct.C: value %d doesn't fit in 1 bytes", len(v.C))
+	}
+	buf = append(buf, byte(len(v.C)))
+	for i7 := 0; i7 < len(v.C); i7++ {
+		if uint64(len(v.C[i7]))>>8 != 0 {
+			return nil, fmt.Errorf("ElemOptionsStruct.C: value %d doesn't fit in 1 bytes", len(v.C[i7]))
+		}
+		buf = append(buf, byte(len(v.C[i7])))
+		buf = append(buf, v.C[i7]...)
+	}
+	if v.D != nil {
+		if uint64(len((*v.D)))>>8 != 0 {
+			return nil, fmt.Errorf("ElemOptionsStruct.D: value %d doesn't fit in 1 bytes", len((*v.D)))
+		}
+		buf = append(buf, byte(len((*v.D))))
+		for i8 := 0; i8 < len((*v.D)); i8++ {
+			if strings.IndexByte((*v.D)[i8], 0) >= 0 {
+				return nil, fmt.Errorf("ElemOptionsStruct.D: cstring can't contain zero byte")
+			}
+			buf = append(buf, (*v.D)[i8]...)
+			buf = append(buf, 0)
+		}
+	} else {
+		var z9 []string
+		if uint64(len(z9))>>8 != 0 {
+			return nil, fmt.Errorf("ElemOptionsStruct.D: value %d doesn't fit in 1 bytes", len(z9))
+		}
+		buf = append(buf, byte(len(z9)))
+		for i10 := 0; i10 < len(z9); i10++ {
+			if strings.IndexByte(z9[i10], 0) >= 0 {
+				return nil, fmt.Errorf("ElemOptionsStruct.D: cstring can't contain zero byte")
+			}
+			buf = append(buf, z9[i10]...)
+			buf = append(buf, 0)
+		}
+	}
+	if uint64(len(v.E))>>8 != 0 {
+		return nil, fmt.Errorf("ElemOptionsStruct.E: value %d doesn't fit in 1 bytes", len(v.E))
+	}
+	buf = append(buf, byte(len(v.E)))
+	for i11 := 0; i11 < len(v.E); i11++ {
+		if uint64(len(v.E[i11]))>>8 != 0 {
+			return nil, fmt.Errorf("ElemOptionsStruct.E: value %d doesn't fit in 1 bytes", len(v.E[i11]))
+		}
+		buf = append(buf, byte(len(v.E[i11])))
+		for i12 := 0; i12 < len(v.E[i11]); i12++ {
+			buf = append(buf, 0, 0)
+			endian.PutUint16(buf[len(buf)-2:], uint16(v.E[i11][i12]))
+		}
+	}
+	l13 := len(v.F)
+	if l13 > 1 {
+		l13 = 1
+	}
+	for i14 := 0; i14 < l13; i14++ {
+		l15 := len(v.F[i14])
+		if l15 > 2 {
+			l15 = 2
+		}
+		for i16 := 0; i16 < l15; i16++ {
+			l17 := len(v.F[i14][i16])
+			if l17 > 1 {
+				l17 = 1
+			}
+			buf = append(buf, v.F[i14][i16][:l17]...)
+			buf = append(buf, make([]byte, 1-l17)...)
+		}
+		if l15 < 2 {
+			buf = append(buf, make([]byte, (2-l15)*(1))...)
+		}
+	}
+	if l13 < 1 {
+		buf = append(buf, make([]byte, (1-l13)*(2))...)
+	}
+	return buf, nil
+}
+
+// UnmarshalD2B decodes ElemOptionsStruct from data and returns count of used bytes
+func (v *ElemOptionsStruct) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	b := data
+	for i1 := 0; i1 < len(v.A); i1++ {
+		if len(b) < 3 {
+			return 0, &d2b.ShortBufferError{Path: "A[" + strconv.Itoa(i1) + "]", Needed: 3, Available: len(b)}
+		}
+		s2 := b[:3]
+		for i3, c4 := range s2 {
+			if c4 == 0 {
+				s2 = s2[:i3]
+				break
+			}
+		}
+		if string(v.A[i1]) != string(s2) {
+			v.A[i1] = string(s2)
+		}
+		b = b[3:]
+	}
+	if len(v.B) < 2 {
+		s5 := make([]string, 2)
+		copy(s5, v.B)
+		v.B = s5
+	}
+	for i6 := 0; i6 < len(v.B); i6++ {
+		if len(b) < 2 {
+			return 0, &d2b.ShortBufferError{Path: "B[" + strconv.Itoa(i6) + "]", Needed: 2, Available: len(b)}
+		}
+		s7 := b[:2]
+		for len(s7) > 0 && s7[len(s7)-1] == 32 {
+			s7 = s7[:len(s7)-1]
+		}
+		if string(v.B[i6]) != string(s7) {
+			v.B[i6] = string(s7)
+		}
+		b = b[2:]
+	}
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "C", Needed: 1, Available: len(b)}
+	}
+	l8 := uint64(b[0])
+	b = b[1:]
+	n9 := int(l8)
+	c10 := n9
+	if c10 > len(b) {
+		c10 = len(b)
+	}
+	s11 := make([]string, 0, c10)
+	var z12 string
+	for i13 := 0; i13 < n9; i13++ {
+		s11 = append(s11, z12)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "C[" + strconv.Itoa(i13) + "]", Needed: 1, Available: len(b)}
+		}
+		l14 := uint64(b[0])
+		b = b[1:]
+		n15 := int(l14)
+		if len(b) < n15 {
+			return 0, &d2b.ShortBufferError{Path: "C[" + strconv.Itoa(i13) + "]", Needed: n15, Available: len(b)}
+		}
+		if string(s11[i13]) != string(b[:n15]) {
+			s11[i13] = string(b[:n15])
+		}
+		b = b[n15:]
+	}
+	v.C = s11
+	if v.D == nil {
+		v.D = new([]string)
+	}
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "D", Needed: 1, Available: len(b)}
+	}
+	l16 := uint64(b[0])
+	b = b[1:]
+	n17 := int(l16)
+	c18 := n17
+	if c18 > len(b) {
+		c18 = len(b)
+	}
+	s19 := make([]string, 0, c18)
+	var z20 string
+	for i21 := 0; i21 < n17; i21++ {
+		s19 = append(s19, z20)
+		e22 := -1
+		for i23, c24 := range b {
+			if c24 == 0 {
+				e22 = i23
+				break
+			}
+		}
+		if e22 < 0 {
+			return 0, &d2b.ShortBufferError{Path: "D[" + strconv.Itoa(i21) + "]", Needed: len(b) + 1, Available: len(b)}
+		}
+		if string(s19[i21]) != string(b[:e22]) {
+			s19[i21] = string(b[:e22])
+		}
+		b = b[e22+1:]
+	}
+	(*v.D) = s19
+	if len(b) < 1 {
+		return 0, &d2b.ShortBufferError{Path: "E", Needed: 1, Available: len(b)}
+	}
+	l25 := uint64(b[0])
+	b = b[1:]
+	n26 := int(l25)
+	c27 := n26
+	if c27 > len(b) {
+		c27 = len(b)
+	}
+	s28 := make([][]uint16, 0, c27)
+	var z29 []uint16
+	for i30 := 0; i30 < n26; i30++ {
+		s28 = append(s28, z29)
+		if len(b) < 1 {
+			return 0, &d2b.ShortBufferError{Path: "E[" + strconv.Itoa(i30) + "]", Needed: 1, Available: len(b)}
+		}
+		l31 := uint64(b[0])
+		b = b[1:]
+		n32 := int(l31)
+		e33 := int(^uint(0) >> 1)
+		if n32 <= e33/(2) {
+			e33 = n32 * (2)
+		}
+		if len(b) < e33 {
+			return 0, &d2b.ShortBufferError{Path: "E[" + strconv.Itoa(i30) + "]", Needed: e33, Available: len(b)}
+		}
+		c34 := n32
+		if c34 > len(b) {
+			c34 = len(b)
+		}
+		s35 := make([]uint16, 0, c34)
+		var z36 uint16
+		for i37 := 0; i37 < n32; i37++ {
+			s35 = append(s35, z36)
+			if len(b) < 2 {
+				return 0, &d2b.ShortBufferError{Path: "E[" + strconv.Itoa(i30) + "][" + strconv.Itoa(i37) + "]", Needed: 2, Available: len(b)}
+			}
+			s35[i37] = endian.Uint16(b)
+			b = b[2:]
+		}
+		s28[i30] = s35
+	}
+	v.E = s28
+	if len(v.F) < 1 {
+		s38 := make([][]string, 1)
+		copy(s38, v.F)
+		v.F = s38
+	}
+	for i39 := 0; i39 < len(v.F); i39++ {
+		if len(v.F[i39]) < 2 {
+			s40 := make([]string, 2)
+			copy(s40, v.F[i39])
+			v.F[i39] = s40
+		}
+		for i41 := 0; i41 < len(v.F[i39]); i41++ {
+			if len(b) < 1 {
+				return 0, &d2b.ShortBufferError{Path: "F[" + strconv.Itoa(i39) + "][" + strconv.Itoa(i41) + "]", Needed: 1, Available: len(b)}
+			}
+			s42 := b[:1]
+			for i43, c44 := range s42 {
+				if c44 == 0 {
+					s42 = s42[:i43]
+					break
+				}
+			}
+			if string(v.F[i39][i41]) != string(s42) {
+				v.F[i39][i41] = string(s42)
+			}
+			b = b[1:]
+		}
+	}
+	return len(data) - len(b), nil
+}
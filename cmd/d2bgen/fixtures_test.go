@@ -0,0 +1,581 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var update = flag.Bool("update", false, "update generated compat fixtures")
+
+const (
+	fixturesDir    = "compat"
+	fixturesFile   = "fixtures.go"
+	fixturesOutput = "fixtures_d2b.go"
+	fixturesHeader = "// Code generated by TestFixtures from d2b tests. DO NOT EDIT."
+)
+
+// fixtureDecl is a type, declared in d2b tests, which is copied to compat package
+type fixtureDecl struct {
+	spec *ast.TypeSpec
+	file *fixtureFile
+	// name is the name of type in compat package. Types, declared in functions, are prefixed with function name
+	name string
+	// plain is the name of twin of type, which refers to twins instead of fixtures, so d2b encodes it with reflection
+	plain   string
+	methods []*ast.FuncDecl
+	refs    []fixtureRef
+	imports map[string]bool
+	// embedded types are flattened, so they are never generated and they don't have twins
+	embedded bool
+	err      error
+	checked  bool
+}
+
+// fixtureRef is an identifier in copied source, which refers to another copied type
+type fixtureRef struct {
+	ident *ast.Ident
+	decl  *fixtureDecl
+}
+
+type fixtureFile struct {
+	name    string
+	src     []byte
+	imports map[string]string
+}
+
+// fixtureExtractor copies types of d2b tests, so d2bgen can generate their methods in compat package
+type fixtureExtractor struct {
+	fset     *token.FileSet
+	topLevel map[string]*fixtureDecl
+	specs    map[*ast.TypeSpec]*fixtureDecl
+	order    []*fixtureDecl
+	names    map[string]bool
+}
+
+func extractFixtures(dir string) (*fixtureExtractor, error) {
+	x := &fixtureExtractor{
+		fset:     token.NewFileSet(),
+		topLevel: make(map[string]*fixtureDecl),
+		specs:    make(map[*ast.TypeSpec]*fixtureDecl),
+		names:    make(map[string]bool),
+	}
+	names, err := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	var files []*ast.File
+	var sources []*fixtureFile
+	for _, name := range names {
+		src, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(x.fset, name, src, 0)
+		if err != nil {
+			return nil, err
+		}
+		file := &fixtureFile{name: filepath.Base(name), src: src, imports: make(map[string]string)}
+		for _, spec := range f.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := importPath[strings.LastIndex(importPath, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			file.imports[name] = importPath
+		}
+		files = append(files, f)
+		sources = append(sources, file)
+	}
+	// top level types keep their names, so they are collected first
+	for i, f := range files {
+		for _, decl := range f.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+				for _, spec := range gen.Specs {
+					x.add(spec.(*ast.TypeSpec), sources[i], "")
+				}
+			}
+		}
+	}
+	for i, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			if fn.Recv != nil {
+				if decl := x.topLevel[receiverName(fn)]; decl != nil {
+					decl.methods = append(decl.methods, fn)
+				}
+				continue
+			}
+			if fn.Body == nil {
+				continue
+			}
+			prefix := strings.TrimPrefix(fn.Name.Name, "Test")
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if spec, ok := n.(*ast.TypeSpec); ok {
+					x.add(spec, sources[i], prefix)
+				}
+				return true
+			})
+		}
+	}
+	for _, decl := range x.order {
+		decl.refs, decl.err = x.resolve(decl.spec.Type, decl, decl.spec)
+		for _, method := range decl.methods {
+			refs, err := x.resolve(method, decl, method)
+			if err != nil && decl.err == nil {
+				decl.err = errors.Wrapf(err, "method %v", method.Name.Name)
+			}
+			decl.refs = append(decl.refs, refs...)
+		}
+		for _, ident := range flattenedFields(decl.spec) {
+			if embedded := x.typeDecl(ident); embedded != nil {
+				embedded.embedded = true
+			}
+		}
+	}
+	return x, nil
+}
+
+// add adds type, declared in function with prefix or at top level, if prefix is empty
+func (x *fixtureExtractor) add(spec *ast.TypeSpec, file *fixtureFile, prefix string) {
+	name := spec.Name.Name
+	if prefix != "" {
+		name = prefix + upperFirst(name)
+	}
+	for base, i := name, 2; x.names[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	x.names[name] = true
+	decl := &fixtureDecl{spec: spec, file: file, name: name, imports: make(map[string]bool)}
+	if prefix == "" {
+		x.topLevel[name] = decl
+	}
+	x.specs[spec] = decl
+	x.order = append(x.order, decl)
+}
+
+// flattenedFields returns types of embedded fields of struct spec, which are flattened by d2b,
+// because they don't have d2b tag and they aren't pointers
+func flattenedFields(spec *ast.TypeSpec) []*ast.Ident {
+	s, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return nil
+	}
+	var result []*ast.Ident
+	for _, field := range s.Fields.List {
+		ident, ok := field.Type.(*ast.Ident)
+		if len(field.Names) != 0 || !ok {
+			continue
+		}
+		if field.Tag != nil {
+			tag, _ := strconv.Unquote(field.Tag.Value)
+			if reflect.StructTag(tag).Get("d2b") != "" {
+				continue
+			}
+		}
+		result = append(result, ident)
+	}
+	return result
+}
+
+// typeDecl returns copied type, which is referred by expr directly or by pointer
+func (x *fixtureExtractor) typeDecl(expr ast.Expr) *fixtureDecl {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	if ident.Obj != nil {
+		if spec, ok := ident.Obj.Decl.(*ast.TypeSpec); ok {
+			return x.specs[spec]
+		}
+		return nil
+	}
+	return x.topLevel[ident.Name]
+}
+
+// resolve returns references of node of decl to copied types and adds imported packages to decl imports
+// Identifiers, which can't be resolved in compat package, like variables and functions of d2b tests, are errors
+func (x *fixtureExtractor) resolve(node ast.Node, decl *fixtureDecl, scope ast.Node) ([]fixtureRef, error) {
+	var refs []fixtureRef
+	var err error
+	var inspect func(n ast.Node) bool
+	inspect = func(n ast.Node) bool {
+		if err != nil || n == nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			ast.Inspect(n.Recv, inspect)
+			ast.Inspect(n.Type, inspect)
+			ast.Inspect(n.Body, inspect)
+			return false
+		case *ast.Field:
+			ast.Inspect(n.Type, inspect)
+			return false
+		case *ast.SelectorExpr:
+			if ident, ok := n.X.(*ast.Ident); ok && ident.Obj == nil {
+				importPath, ok := decl.file.imports[ident.Name]
+				if !ok {
+					err = errors.Errorf("%v is not found", ident.Name)
+					return false
+				}
+				decl.imports[importPath] = true
+				return false
+			}
+			ast.Inspect(n.X, inspect)
+			return false
+		case *ast.KeyValueExpr:
+			if _, ok := n.Key.(*ast.Ident); !ok {
+				ast.Inspect(n.Key, inspect)
+			}
+			ast.Inspect(n.Value, inspect)
+			return false
+		case *ast.Ident:
+			if ref := x.typeDecl(n); ref != nil {
+				refs = append(refs, fixtureRef{ident: n, decl: ref})
+				return false
+			}
+			local := n.Obj != nil && n.Obj.Pos() >= scope.Pos() && n.Obj.Pos() < scope.End()
+			if !local && n.Name != "_" && types.Universe.Lookup(n.Name) == nil {
+				err = errors.Errorf("%v is not found", n.Name)
+			}
+			return false
+		}
+		return true
+	}
+	ast.Inspect(node, inspect)
+	return refs, err
+}
+
+// check returns error, if type or types it refers to can't be copied
+func (x *fixtureExtractor) check(decl *fixtureDecl) error {
+	if decl.checked {
+		return decl.err
+	}
+	decl.checked = true
+	if decl.err != nil {
+		return decl.err
+	}
+	for _, ref := range decl.refs {
+		if err := x.check(ref.decl); err != nil {
+			decl.err = errors.Wrapf(err, "%v", ref.decl.name)
+			return decl.err
+		}
+	}
+	return nil
+}
+
+// isFixture checks if methods of decl can be generated: it should be struct without methods, which isn't embedded
+func isFixture(decl *fixtureDecl) bool {
+	_, ok := decl.spec.Type.(*ast.StructType)
+	return ok && decl.spec.TypeParams == nil && len(decl.methods) == 0 && !decl.embedded && decl.err == nil
+}
+
+// checkEmbedded returns error, if struct decl uses flattened embedded struct as usual field. Methods of embedded
+// structs are not generated, so fields of d2b tests stay flattened, but such usual fields need them
+func checkEmbedded(decl *fixtureDecl) error {
+	flattened := make(map[*ast.Ident]bool)
+	for _, ident := range flattenedFields(decl.spec) {
+		flattened[ident] = true
+	}
+	for _, ref := range decl.refs {
+		_, isStruct := ref.decl.spec.Type.(*ast.StructType)
+		if ref.decl.embedded && isStruct && len(ref.decl.methods) == 0 && !flattened[ref.ident] {
+			return errors.Errorf("embedded struct %v is used as usual field", ref.decl.name)
+		}
+	}
+	return nil
+}
+
+// fixtures returns copied types, which methods can be generated, and types, they depend on
+func (x *fixtureExtractor) fixtures() (fixtures, decls []*fixtureDecl, skipped []string) {
+	used := make(map[*fixtureDecl]bool)
+	var use func(decl *fixtureDecl)
+	use = func(decl *fixtureDecl) {
+		if used[decl] {
+			return
+		}
+		used[decl] = true
+		for _, ref := range decl.refs {
+			use(ref.decl)
+		}
+	}
+	for _, decl := range x.order {
+		if err := x.check(decl); err != nil {
+			if _, ok := decl.spec.Type.(*ast.StructType); ok {
+				skipped = append(skipped, fmt.Sprintf("%v: %v", decl.name, err))
+			}
+			continue
+		}
+		if err := checkEmbedded(decl); err != nil {
+			skipped = append(skipped, fmt.Sprintf("%v: %v", decl.name, err))
+			continue
+		}
+		if isFixture(decl) {
+			fixtures = append(fixtures, decl)
+			use(decl)
+		}
+	}
+	for _, decl := range x.order {
+		if used[decl] {
+			decls = append(decls, decl)
+		}
+	}
+	// twins are needed for fixtures and types, which refer to twins
+	for changed := true; changed; {
+		changed = false
+		for _, decl := range decls {
+			if decl.plain != "" || len(decl.methods) != 0 || decl.embedded {
+				continue
+			}
+			needed := isFixture(decl)
+			for _, ref := range decl.refs {
+				needed = needed || ref.decl.plain != "" && !ref.decl.embedded
+			}
+			if needed {
+				decl.plain = "plain" + upperFirst(decl.name)
+				changed = true
+			}
+		}
+	}
+	return fixtures, decls, skipped
+}
+
+// source returns source of node from file, where references are renamed to copied types or their twins
+func (x *fixtureExtractor) source(node ast.Node, decl *fixtureDecl, plain bool) string {
+	type replacement struct {
+		pos  int
+		end  int
+		name string
+	}
+	name := decl.name
+	if plain {
+		name = decl.plain
+	}
+	var replacements []replacement
+	if node == decl.spec {
+		replacements = append(replacements, replacement{
+			pos: x.fset.Position(decl.spec.Name.Pos()).Offset, end: x.fset.Position(decl.spec.Name.End()).Offset, name: name,
+		})
+	}
+	for _, ref := range decl.refs {
+		if ref.ident.Pos() < node.Pos() || ref.ident.End() > node.End() {
+			continue
+		}
+		refName := ref.decl.name
+		if plain && ref.decl.plain != "" {
+			refName = ref.decl.plain
+		}
+		replacements = append(replacements, replacement{
+			pos: x.fset.Position(ref.ident.Pos()).Offset, end: x.fset.Position(ref.ident.End()).Offset, name: refName,
+		})
+	}
+	sort.Slice(replacements, func(i, j int) bool { return replacements[i].pos < replacements[j].pos })
+	start, end := x.fset.Position(node.Pos()).Offset, x.fset.Position(node.End()).Offset
+	result := new(bytes.Buffer)
+	for _, r := range replacements {
+		result.Write(decl.file.src[start:r.pos])
+		result.WriteString(r.name)
+		start = r.end
+	}
+	result.Write(decl.file.src[start:end])
+	return result.String()
+}
+
+// generateFixtures returns sources of compat fixtures and their generated methods
+func generateFixtures(libraryDir string) (fixturesSrc, methodsSrc []byte, err error) {
+	x, err := extractFixtures(libraryDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	fixtures, decls, skipped := x.fixtures()
+	body := new(bytes.Buffer)
+	for _, decl := range decls {
+		line := x.fset.Position(decl.spec.Pos()).Line
+		fmt.Fprintf(body, "// %v is %v from %v:%d\n", decl.name, decl.spec.Name.Name, decl.file.name, line)
+		fmt.Fprintf(body, "type %s\n\n", x.source(decl.spec, decl, false))
+		if decl.plain != "" {
+			fmt.Fprintf(body, "type %s\n\n", x.source(decl.spec, decl, true))
+		}
+		for _, method := range decl.methods {
+			fmt.Fprintf(body, "%s\n\n", x.source(method, decl, false))
+		}
+	}
+	rejected := make(map[*fixtureDecl]string)
+	src, err := fixturesSource(decls, body.Bytes(), fixtures, rejected, skipped)
+	if err != nil {
+		return nil, nil, err
+	}
+	// methods are generated in temporary directory, because other files of compat package may be outdated
+	dir, err := ioutil.TempDir("", "d2bgen")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, fixturesFile)
+	if err := ioutil.WriteFile(file, src, 0644); err != nil {
+		return nil, nil, err
+	}
+	pkg, _, err := loadPackage(file, fixturesOutput)
+	if err != nil {
+		return nil, nil, err
+	}
+	lookup := func(decl *fixtureDecl) *types.Named {
+		return pkg.Scope().Lookup(decl.name).Type().(*types.Named)
+	}
+	var accepted []*types.Named
+	for _, decl := range fixtures {
+		targets := []*types.Named{lookup(decl)}
+		for _, dep := range fixtureDeps(decl) {
+			targets = append(targets, lookup(dep))
+		}
+		g, err := newGenerator(pkg, targets, false)
+		if err == nil {
+			_, err = g.generate()
+		}
+		if err != nil {
+			rejected[decl] = err.Error()
+			continue
+		}
+		accepted = append(accepted, targets[0])
+	}
+	g, err := newGenerator(pkg, accepted, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	methodsSrc, err = g.generate()
+	if err != nil {
+		return nil, nil, err
+	}
+	fixturesSrc, err = fixturesSource(decls, body.Bytes(), fixtures, rejected, skipped)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fixturesSrc, methodsSrc, nil
+}
+
+// fixtureDeps returns fixtures, which are used by decl, so their methods should be generated too
+func fixtureDeps(decl *fixtureDecl) []*fixtureDecl {
+	var result []*fixtureDecl
+	visited := map[*fixtureDecl]bool{decl: true}
+	var visit func(decl *fixtureDecl)
+	visit = func(decl *fixtureDecl) {
+		for _, ref := range decl.refs {
+			if visited[ref.decl] {
+				continue
+			}
+			visited[ref.decl] = true
+			if isFixture(ref.decl) {
+				result = append(result, ref.decl)
+			}
+			visit(ref.decl)
+		}
+	}
+	visit(decl)
+	return result
+}
+
+func fixturesSource(decls []*fixtureDecl, body []byte, fixtures []*fixtureDecl, rejected map[*fixtureDecl]string,
+	skipped []string) ([]byte, error) {
+	src := new(bytes.Buffer)
+	fmt.Fprintf(src, "%s\n\npackage compat\n\n", fixturesHeader)
+	used := make(map[string]bool)
+	var imports []string
+	for _, decl := range decls {
+		for importPath := range decl.imports {
+			if !used[importPath] {
+				used[importPath] = true
+				imports = append(imports, importPath)
+			}
+		}
+	}
+	sort.Strings(imports)
+	if len(imports) > 0 {
+		src.WriteString("import (\n")
+		for _, importPath := range imports {
+			fmt.Fprintf(src, "%q\n", importPath)
+		}
+		src.WriteString(")\n\n")
+	}
+	if len(skipped) > 0 {
+		src.WriteString("// Structs, which can't be copied from d2b tests:\n")
+		for _, s := range skipped {
+			fmt.Fprintf(src, "//   - %s\n", s)
+		}
+		src.WriteString("\n")
+	}
+	src.WriteString("// fixture is a struct from d2b tests. Methods of value are generated, unless d2bgen rejected it\n")
+	src.WriteString("// plain has the same fields, but d2b encodes it with reflection\n")
+	src.WriteString("type fixture struct {\nname string\nvalue interface{}\nplain interface{}\nrejected string\n}\n\n")
+	src.WriteString("var fixtures = []fixture{\n")
+	for _, decl := range fixtures {
+		fmt.Fprintf(src, "{name: %q, value: new(%s), plain: new(%s)", decl.name, decl.name, decl.plain)
+		if reason, ok := rejected[decl]; ok {
+			fmt.Fprintf(src, ", rejected: %q", reason)
+		}
+		src.WriteString("},\n")
+	}
+	src.WriteString("}\n\n")
+	src.Write(body)
+	return format.Source(src.Bytes())
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func upperFirst(s string) string {
+	for i, r := range s {
+		return string(unicode.ToUpper(r)) + s[i+len(string(r)):]
+	}
+	return s
+}
+
+func TestFixtures(t *testing.T) {
+	Convey("Compat fixtures should be generated from d2b tests", t, func() {
+		fixturesSrc, methodsSrc, err := generateFixtures(filepath.Join("..", ".."))
+		So(err, ShouldBeNil)
+		files := map[string][]byte{fixturesFile: fixturesSrc, fixturesOutput: methodsSrc}
+		for name, src := range files {
+			path := filepath.Join(fixturesDir, name)
+			if *update {
+				So(ioutil.WriteFile(path, src, 0644), ShouldBeNil)
+				continue
+			}
+			expected, err := ioutil.ReadFile(path)
+			So(err, ShouldBeNil)
+			So(string(src), ShouldEqual, string(expected))
+		}
+	})
+}
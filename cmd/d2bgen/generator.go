@@ -0,0 +1,1337 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"go/format"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/saturn4er/go-data-to-bytes.v2/internal/schema"
+)
+
+const (
+	d2bPath         = "gopkg.in/saturn4er/go-data-to-bytes.v2"
+	generatedHeader = "// Code generated by d2bgen. DO NOT EDIT."
+	maxIntExpr      = "int(^uint(0) >> 1)"
+)
+
+// structInfo is a struct type, which methods are generated
+type structInfo struct {
+	named  *types.Named
+	name   string
+	s      *types.Struct
+	fields []structField
+	tags   []*schema.Tag
+}
+
+// structField is a field of generated struct
+// Embedded structs without d2b tag are flattened like in d2b, expr is the selector of field from the struct
+type structField struct {
+	*types.Var
+	expr string
+}
+
+// newStructField returns field of struct s with index path
+func newStructField(s *types.Struct, index []int) structField {
+	var selectors []string
+	var f *types.Var
+	for _, i := range index {
+		f = s.Field(i)
+		selectors = append(selectors, f.Name())
+		if embedded, ok := f.Type().Underlying().(*types.Struct); ok {
+			s = embedded
+		}
+	}
+	return structField{Var: f, expr: strings.Join(selectors, ".")}
+}
+
+// pathPart is a struct field name or a name of index variable in path of decoded value
+type pathPart struct {
+	name  string
+	index string
+}
+
+type path []pathPart
+
+func (p path) field(name string) path {
+	return append(append(path{}, p...), pathPart{name: name})
+}
+
+func (p path) index(variable string) path {
+	return append(append(path{}, p...), pathPart{index: variable})
+}
+
+type generator struct {
+	pkg      *types.Package
+	structs  map[*types.Named]*structInfo
+	order    []*structInfo
+	imports  map[string]string
+	sizes    map[*types.Named]size
+	visiting map[*types.Named]bool
+
+	// skipUnexported makes generated methods skip unexported fields like d2b.Options.SkipUnexported
+	skipUnexported bool
+
+	// state of the function, which is being generated
+	w       *bytes.Buffer
+	vars    int
+	usesErr bool
+	usesN   bool
+	// fail is the first value, returned with error
+	fail string
+}
+
+func newGenerator(pkg *types.Package, targets []*types.Named, skipUnexported bool) (*generator, error) {
+	g := &generator{
+		pkg:            pkg,
+		structs:        make(map[*types.Named]*structInfo),
+		imports:        map[string]string{"encoding/binary": "binary"},
+		sizes:          make(map[*types.Named]size),
+		visiting:       make(map[*types.Named]bool),
+		skipUnexported: skipUnexported,
+	}
+	methods := []string{"AppendD2B", "MarshalD2B", "UnmarshalD2B", "SizeD2B"}
+	for _, named := range targets {
+		name := named.Obj().Name()
+		s, ok := named.Underlying().(*types.Struct)
+		if !ok {
+			return nil, errors.Errorf("%v is not a struct", name)
+		}
+		methodSet := types.NewMethodSet(types.NewPointer(named))
+		for _, method := range methods {
+			if methodSet.Lookup(pkg, method) != nil {
+				return nil, errors.Errorf("%v already has %v method", name, method)
+			}
+		}
+		info := &structInfo{named: named, name: name, s: s}
+		g.structs[named] = info
+		g.order = append(g.order, info)
+	}
+	// fields are parsed after all structs are known, because embedded generated structs aren't flattened
+	for _, info := range g.order {
+		if err := g.parseStructTags(info); err != nil {
+			return nil, errors.Wrapf(err, "parsing %v struct tags error", info.name)
+		}
+	}
+	return g, nil
+}
+
+// generate returns formatted source code of the file with methods of all structs
+func (g *generator) generate() ([]byte, error) {
+	body := new(bytes.Buffer)
+	for _, info := range g.order {
+		if err := g.generateStruct(body, info); err != nil {
+			return nil, errors.Wrapf(err, "can't generate %v methods", info.name)
+		}
+	}
+	src := new(bytes.Buffer)
+	fmt.Fprintf(src, "%s\n\npackage %s\n\nimport (\n", generatedHeader, g.pkg.Name())
+	var std, other []string
+	for importPath := range g.imports {
+		if strings.Contains(importPath, ".") {
+			other = append(other, importPath)
+		} else {
+			std = append(std, importPath)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	for _, importPath := range std {
+		fmt.Fprintf(src, "%q\n", importPath)
+	}
+	if len(other) > 0 {
+		src.WriteString("\n")
+	}
+	for _, importPath := range other {
+		if importPath == d2bPath {
+			fmt.Fprintf(src, "d2b %q\n", importPath)
+		} else {
+			fmt.Fprintf(src, "%q\n", importPath)
+		}
+	}
+	src.WriteString(")\n\n")
+	src.Write(body.Bytes())
+	result, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "can't format generated code")
+	}
+	return result, nil
+}
+
+func (g *generator) generateStruct(w *bytes.Buffer, info *structInfo) error {
+	structSize, err := g.structSize(info)
+	if err != nil {
+		return err
+	}
+	name := info.name
+	encode, err := g.function("nil", func() error { return g.encodeStruct(info) })
+	if err != nil {
+		return err
+	}
+	decode, err := g.function("0", func() error { return g.decodeStruct(info) })
+	if err != nil {
+		return err
+	}
+
+	if !structSize.variable {
+		fmt.Fprintf(w, "// SizeD2B returns length of %s bytes representation\n", name)
+		fmt.Fprintf(w, "func (v *%s) SizeD2B() int {\nreturn %s\n}\n\n", name, structSize.expr())
+	}
+	fmt.Fprintf(w, "// MarshalD2B encodes %s to bytes\n", name)
+	fmt.Fprintf(w, "func (v *%s) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {\n", name)
+	if structSize.variable {
+		fmt.Fprintf(w, "return v.AppendD2B(nil, endian)\n}\n\n")
+	} else {
+		fmt.Fprintf(w, "return v.AppendD2B(make([]byte, 0, v.SizeD2B()), endian)\n}\n\n")
+	}
+	fmt.Fprintf(w, "// AppendD2B appends bytes representation of %s to buf\n", name)
+	fmt.Fprintf(w, "func (v *%s) AppendD2B(buf []byte, endian binary.ByteOrder) ([]byte, error) {\n", name)
+	w.Write(encode)
+	fmt.Fprintf(w, "return buf, nil\n}\n\n")
+	fmt.Fprintf(w, "// UnmarshalD2B decodes %s from data and returns count of used bytes\n", name)
+	fmt.Fprintf(w, "func (v *%s) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {\n", name)
+	fmt.Fprintf(w, "b := data\n")
+	w.Write(decode)
+	fmt.Fprintf(w, "return len(data) - len(b), nil\n}\n\n")
+	return nil
+}
+
+// function generates body of function with body callback and declares variables, which it uses
+func (g *generator) function(fail string, body func() error) ([]byte, error) {
+	g.w = new(bytes.Buffer)
+	g.vars = 0
+	g.usesErr = false
+	g.usesN = false
+	g.fail = fail
+	if err := body(); err != nil {
+		return nil, err
+	}
+	result := new(bytes.Buffer)
+	if g.usesN {
+		result.WriteString("var n int\n")
+	}
+	if g.usesErr {
+		result.WriteString("var err error\n")
+	}
+	result.Write(g.w.Bytes())
+	return result.Bytes(), nil
+}
+
+func (g *generator) p(format string, args ...interface{}) {
+	fmt.Fprintf(g.w, format+"\n", args...)
+}
+
+func (g *generator) newVar(prefix string) string {
+	g.vars++
+	return prefix + strconv.Itoa(g.vars)
+}
+
+// returnErr generates return of error, message is a format string and args are Go expressions
+func (g *generator) returnErr(ctx string, message string, args ...string) {
+	g.imports["fmt"] = "fmt"
+	format := strconv.Quote(ctx + ": " + message)
+	if len(args) == 0 {
+		g.p("return %s, fmt.Errorf(%s)", g.fail, format)
+		return
+	}
+	g.p("return %s, fmt.Errorf(%s, %s)", g.fail, format, strings.Join(args, ", "))
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == g.pkg {
+			return ""
+		}
+		g.imports[p.Path()] = p.Name()
+		return p.Name()
+	})
+}
+
+func (g *generator) d2b() string {
+	g.imports[d2bPath] = "d2b"
+	return "d2b"
+}
+
+func (g *generator) endianExpr(tag *schema.Tag, def string) string {
+	switch tag.Endian {
+	case binary.BigEndian:
+		return "binary.BigEndian"
+	case binary.LittleEndian:
+		return "binary.LittleEndian"
+	}
+	return def
+}
+
+// generated returns info of struct, if t is one of generated types
+func (g *generator) generated(t types.Type) *structInfo {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil
+	}
+	return g.structs[named]
+}
+
+// customType checks if t has it's own bytes representation and if it implements FixedSizer
+func (g *generator) customType(t types.Type) (custom bool, fixedSizer bool, err error) {
+	if _, ok := t.Underlying().(*types.Pointer); ok {
+		return false, false, nil
+	}
+	methodSet := types.NewMethodSet(types.NewPointer(t))
+	has := func(name string) bool {
+		return methodSet.Lookup(g.pkg, name) != nil
+	}
+	marshaler, unmarshaler := has("MarshalD2B"), has("UnmarshalD2B")
+	if !marshaler && !unmarshaler && !has("MarshalBinary") && !has("UnmarshalBinary") {
+		return false, false, nil
+	}
+	if !marshaler && !unmarshaler {
+		return false, false, errors.Errorf("type %v implements only encoding.BinaryMarshaler, which is not supported by d2bgen", t)
+	}
+	if !marshaler || !unmarshaler {
+		return false, false, errors.Errorf("type %v should implement both d2b.Marshaler and d2b.Unmarshaler", t)
+	}
+	return true, has("SizeD2B"), nil
+}
+
+func isEmptyStruct(t types.Type) bool {
+	s, ok := t.Underlying().(*types.Struct)
+	return ok && s.NumFields() == 0
+}
+
+func (g *generator) structSize(info *structInfo) (size, error) {
+	if result, ok := g.sizes[info.named]; ok {
+		return result, nil
+	}
+	if g.visiting[info.named] {
+		return size{}, errors.Errorf("recursive type %v is not supported by d2bgen", info.name)
+	}
+	g.visiting[info.named] = true
+	defer delete(g.visiting, info.named)
+	result := fixedSize(0)
+	for i, tag := range info.tags {
+		f := info.fields[i]
+		switch {
+		case tag.Skip || isEmptyStruct(f.Type()):
+		case tag.Bits != 0:
+			result = result.add(fixedSize(tag.BitGroupLength))
+		case tag.LengthFrom != "":
+			if _, err := g.typeSize(indirectType(f.Type()), &schema.Tag{LenPrefix: 1, Elem: tag.Elem}); err != nil {
+				return size{}, errors.Wrapf(err, "%v.%v field error", info.name, f.Name())
+			}
+			result = variableSize
+		default:
+			fieldSize, err := g.typeSize(f.Type(), tag)
+			if err != nil {
+				return size{}, errors.Wrapf(err, "%v.%v field error", info.name, f.Name())
+			}
+			result = result.add(fieldSize)
+		}
+	}
+	g.sizes[info.named] = result
+	return result, nil
+}
+
+// typeSize returns size of value with type t and checks, that code can be generated for it
+func (g *generator) typeSize(t types.Type, tag *schema.Tag) (size, error) {
+	if info := g.generated(t); info != nil {
+		return g.structSize(info)
+	}
+	custom, fixedSizer, err := g.customType(t)
+	if err != nil {
+		return size{}, err
+	}
+	if custom {
+		if fixedSizer {
+			return size{calls: []string{fmt.Sprintf("new(%s).SizeD2B()", g.typeString(t))}}, nil
+		}
+		return variableSize, nil
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return g.typeSize(u.Elem(), tag)
+	case *types.Basic:
+		switch {
+		case u.Kind() == types.Int || u.Kind() == types.Uint:
+			if tag.Size == 0 {
+				return size{}, errors.Errorf("%v needs size tag", t)
+			}
+			return fixedSize(tag.Size), nil
+		case u.Info()&types.IsFloat != 0 && tag.Float16:
+			return fixedSize(2), nil
+		case u.Info()&types.IsString != 0:
+			if tag.LenPrefix != 0 || tag.CString && tag.Length == 0 {
+				return variableSize, nil
+			}
+			if tag.Length == 0 {
+				return size{}, errors.New("need to specify length")
+			}
+			return fixedSize(tag.Length), nil
+		}
+		if n := basicSize(u); n != 0 {
+			return fixedSize(n), nil
+		}
+	case *types.Array:
+		elemSize, err := g.typeSize(u.Elem(), elemTag(tag))
+		if err != nil {
+			return size{}, errors.Wrap(err, "can't generate array element")
+		}
+		return elemSize.mul(int(u.Len())), nil
+	case *types.Slice:
+		elemSize, err := g.typeSize(u.Elem(), elemTag(tag))
+		if err != nil {
+			return size{}, errors.Wrap(err, "can't generate slice element")
+		}
+		if tag.LenPrefix != 0 {
+			return variableSize, nil
+		}
+		if tag.Length == 0 {
+			return size{}, errors.New("need to specify length")
+		}
+		return elemSize.mul(tag.Length), nil
+	case *types.Struct:
+		if u.NumFields() == 0 {
+			return fixedSize(0), nil
+		}
+		return size{}, errors.Errorf("methods of struct %v should be generated too", t)
+	case *types.Map:
+		return size{}, errors.New("maps are not supported by d2bgen")
+	}
+	return size{}, errors.Errorf("unsupported type %v", t)
+}
+
+// pathExpr returns Go expression, which builds path of decoded value
+func pathExpr(p path) string {
+	var parts []string
+	literal := ""
+	for i, part := range p {
+		if part.index == "" {
+			if i > 0 {
+				literal += "."
+			}
+			literal += part.name
+			continue
+		}
+		parts = append(parts, strconv.Quote(literal+"["), "strconv.Itoa("+part.index+")")
+		literal = "]"
+	}
+	if literal != "" || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func (g *generator) pathString(p path) string {
+	for _, part := range p {
+		if part.index != "" {
+			g.imports["strconv"] = "strconv"
+		}
+	}
+	return pathExpr(p)
+}
+
+// checkLength generates check, that there are at least needed bytes left
+func (g *generator) checkLength(p path, needed string) {
+	g.p("if len(b) < %s {", needed)
+	g.p("return 0, &%s.ShortBufferError{Path: %s, Needed: %s, Available: len(b)}", g.d2b(), g.pathString(p), needed)
+	g.p("}")
+}
+
+// wrapDecodeErr generates return of err, returned by nested UnmarshalD2B
+func (g *generator) wrapDecodeErr(p path, ctx string) {
+	g.p("if sb, ok := err.(*%s.ShortBufferError); ok {", g.d2b())
+	g.p("return 0, sb.PrependPath(%s)", g.pathString(p))
+	g.p("}")
+	g.returnErr(ctx, "%v", "err")
+}
+
+// appendUint generates appending of unsigned integer value, which takes size bytes
+func (g *generator) appendUint(value string, size int, endian string) {
+	if size == 1 {
+		g.p("buf = append(buf, byte(%s))", value)
+		return
+	}
+	g.p("buf = append(buf, %s)", strings.TrimSuffix(strings.Repeat("0, ", size), ", "))
+	g.p("%s.PutUint%d(buf[len(buf)-%d:], uint%d(%s))", endian, size*8, size, size*8, value)
+}
+
+// readUint returns expression, which reads unsigned integer of size bytes as uint64
+func readUint(size int, endian string) string {
+	if size == 1 {
+		return "uint64(b[0])"
+	}
+	if size == 8 {
+		return endian + ".Uint64(b)"
+	}
+	return fmt.Sprintf("uint64(%s.Uint%d(b))", endian, size*8)
+}
+
+func (g *generator) encodeStruct(info *structInfo) error {
+	for i, tag := range info.tags {
+		f := info.fields[i]
+		if tag.Skip || isEmptyStruct(f.Type()) {
+			continue
+		}
+		endian := g.endianExpr(tag, "endian")
+		ctx := info.name + "." + f.Name()
+		var err error
+		switch {
+		case tag.Bits != 0:
+			if tag.BitGroupLength != 0 {
+				g.encodeBits(info, i)
+			}
+		case len(tag.LengthOf) > 0:
+			err = g.encodeLengthField(info, i, endian, ctx)
+		case tag.LengthFrom != "":
+			err = g.encodeLengthFrom(info, i, endian, ctx)
+		case f.Name() == "_":
+			err = g.encodeZero(f.Type(), tag, endian, ctx)
+		default:
+			err = g.encodeValue("v."+f.expr, f.Type(), tag, endian, ctx)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "%v field error", ctx)
+		}
+	}
+	return nil
+}
+
+func (g *generator) encodeValue(expr string, t types.Type, tag *schema.Tag, endian, ctx string) error {
+	if info := g.generated(t); info != nil {
+		g.usesErr = true
+		g.p("if buf, err = %s.AppendD2B(buf, %s); err != nil {", expr, endian)
+		g.returnErr(ctx, "%v", "err")
+		g.p("}")
+		return nil
+	}
+	custom, fixedSizer, err := g.customType(t)
+	if err != nil {
+		return err
+	}
+	if custom {
+		g.usesErr = true
+		m := g.newVar("m")
+		g.p("var %s []byte", m)
+		g.p("if %s, err = %s.MarshalD2B(%s); err != nil {", m, expr, endian)
+		g.returnErr(ctx, fmt.Sprintf("can't marshal %s: %%v", g.typeString(t)), "err")
+		g.p("}")
+		if fixedSizer {
+			g.p("if len(%s) != %s.SizeD2B() {", m, expr)
+			g.returnErr(ctx, "marshaled to %d bytes, but it's size is %d", "len("+m+")", expr+".SizeD2B()")
+			g.p("}")
+		}
+		g.p("buf = append(buf, %s...)", m)
+		return nil
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		if isEmptyStruct(u.Elem()) {
+			return nil
+		}
+		g.p("if %s != nil {", expr)
+		if err := g.encodeValue("(*"+expr+")", u.Elem(), tag, endian, ctx); err != nil {
+			return err
+		}
+		g.p("} else {")
+		if err := g.encodeZero(u.Elem(), tag, endian, ctx); err != nil {
+			return err
+		}
+		g.p("}")
+		return nil
+	case *types.Basic:
+		return g.encodeBasic(expr, u, tag, endian, ctx)
+	case *types.Array:
+		if isEmptyStruct(u.Elem()) {
+			return nil
+		}
+		i := g.newVar("i")
+		g.p("for %s := 0; %s < len(%s); %s++ {", i, i, expr, i)
+		if err := g.encodeValue(expr+"["+i+"]", u.Elem(), elemTag(tag), endian, ctx); err != nil {
+			return err
+		}
+		g.p("}")
+		return nil
+	case *types.Slice:
+		return g.encodeSlice(expr, t, u, tag, endian, ctx)
+	}
+	_, err = g.typeSize(t, tag)
+	return err
+}
+
+// encodeZero generates encoding of zero value of t, which nil pointer points to
+func (g *generator) encodeZero(t types.Type, tag *schema.Tag, endian, ctx string) error {
+	custom, fixedSizer, err := g.customType(t)
+	if err != nil {
+		return err
+	}
+	if custom && g.generated(t) == nil {
+		if !fixedSizer {
+			g.returnErr(ctx, fmt.Sprintf("type %s doesn't implement FixedSizer", g.typeString(t)))
+			return nil
+		}
+		g.p("buf = append(buf, make([]byte, new(%s).SizeD2B())...)", g.typeString(t))
+		return nil
+	}
+	z := g.newVar("z")
+	g.p("var %s %s", z, g.typeString(t))
+	return g.encodeValue(z, t, tag, endian, ctx)
+}
+
+func (g *generator) encodeBasic(expr string, basic *types.Basic, tag *schema.Tag, endian, ctx string) error {
+	kind := basic.Kind()
+	switch {
+	case kind == types.Bool:
+		g.p("if %s {", expr)
+		g.p("buf = append(buf, 1)")
+		g.p("} else {")
+		g.p("buf = append(buf, 0)")
+		g.p("}")
+	case kind == types.Int || kind == types.Uint:
+		if tag.Size == 0 {
+			return errors.Errorf("%v needs size tag", basic)
+		}
+		g.encodeSizedInt(expr, kind == types.Int, tag.Size, endian, ctx)
+	case kind == types.Int8 || kind == types.Uint8:
+		g.p("buf = append(buf, byte(%s))", expr)
+	case kind == types.Int16 || kind == types.Uint16:
+		g.appendUint(expr, 2, endian)
+	case kind == types.Int32 || kind == types.Uint32:
+		g.appendUint(expr, 4, endian)
+	case kind == types.Int64 || kind == types.Uint64:
+		g.appendUint(expr, 8, endian)
+	case basic.Info()&types.IsFloat != 0 && tag.Float16:
+		g.appendUint(fmt.Sprintf("%s.Float16Bits(float32(%s))", g.d2b(), expr), 2, endian)
+	case kind == types.Float32:
+		g.imports["math"] = "math"
+		g.appendUint(fmt.Sprintf("math.Float32bits(float32(%s))", expr), 4, endian)
+	case kind == types.Float64:
+		g.imports["math"] = "math"
+		g.appendUint(fmt.Sprintf("math.Float64bits(float64(%s))", expr), 8, endian)
+	case basic.Info()&types.IsString != 0:
+		if tag.LenPrefix != 0 {
+			g.encodeLengthPrefix("len("+expr+")", tag, endian, ctx)
+			g.p("buf = append(buf, %s...)", expr)
+			return nil
+		}
+		if tag.CString && tag.Length == 0 {
+			g.imports["strings"] = "strings"
+			g.p("if strings.IndexByte(%s, 0) >= 0 {", expr)
+			g.returnErr(ctx, "cstring can't contain zero byte")
+			g.p("}")
+			g.p("buf = append(buf, %s...)", expr)
+			g.p("buf = append(buf, 0)")
+			return nil
+		}
+		if tag.Length == 0 {
+			return errors.New("need to specify length")
+		}
+		g.encodeFixedString(expr, tag, ctx)
+	default:
+		return errors.Errorf("unsupported type %v", basic)
+	}
+	return nil
+}
+
+// encodeFixedString generates encoding of string to tag.Length bytes, padded with tag.Pad bytes
+func (g *generator) encodeFixedString(expr string, tag *schema.Tag, ctx string) {
+	max := tag.Length
+	if tag.CString {
+		max--
+	}
+	l := g.newVar("l")
+	g.p("%s := len(%s)", l, expr)
+	g.p("if %s > %d {", l, max)
+	if tag.Strict {
+		g.returnErr(ctx, fmt.Sprintf("string length %%d is greater than %d", max), l)
+	} else {
+		g.p("%s = %d", l, max)
+	}
+	g.p("}")
+	g.p("buf = append(buf, %s[:%s]...)", expr, l)
+	if tag.Pad == 0 {
+		g.p("buf = append(buf, make([]byte, %d-%s)...)", tag.Length, l)
+		return
+	}
+	i := g.newVar("i")
+	g.p("for %s := %s; %s < %d; %s++ {", i, l, i, tag.Length, i)
+	g.p("buf = append(buf, %d)", tag.Pad)
+	g.p("}")
+}
+
+// encodeSizedInt generates encoding of int or uint, which takes size bytes
+func (g *generator) encodeSizedInt(expr string, signed bool, size int, endian, ctx string) {
+	x := g.newVar("x")
+	bits := uint(size) * 8
+	if signed {
+		g.p("%s := int64(%s)", x, expr)
+		if bits < 64 {
+			g.p("if %s < %d || %s >= %d {", x, -1<<(bits-1), x, 1<<(bits-1))
+			g.returnErr(ctx, fmt.Sprintf("value %%d doesn't fit in %d bytes", size), x)
+			g.p("}")
+		}
+	} else {
+		g.p("%s := uint64(%s)", x, expr)
+		if bits < 64 {
+			g.p("if %s>>%d != 0 {", x, bits)
+			g.returnErr(ctx, fmt.Sprintf("value %%d doesn't fit in %d bytes", size), x)
+			g.p("}")
+		}
+	}
+	g.appendUint(x, size, endian)
+}
+
+// encodeLengthPrefix generates encoding of string/slice length, checking it's max length
+func (g *generator) encodeLengthPrefix(length string, tag *schema.Tag, endian, ctx string) {
+	if tag.Length != 0 {
+		g.p("if %s > %d {", length, tag.Length)
+		g.returnErr(ctx, fmt.Sprintf("length %%d is greater than max length %d", tag.Length), length)
+		g.p("}")
+	}
+	if tag.LenPrefix < 8 {
+		g.p("if uint64(%s)>>%d != 0 {", length, tag.LenPrefix*8)
+		g.returnErr(ctx, fmt.Sprintf("value %%d doesn't fit in %d bytes", tag.LenPrefix), length)
+		g.p("}")
+	}
+	g.appendUint(length, tag.LenPrefix, endian)
+}
+
+func (g *generator) encodeSlice(expr string, t types.Type, slice *types.Slice, tag *schema.Tag, endian, ctx string) error {
+	if tag.LenPrefix != 0 {
+		g.encodeLengthPrefix("len("+expr+")", tag, endian, ctx)
+		return g.encodeElems(expr, slice, tag, endian, ctx)
+	}
+	if tag.Length == 0 {
+		return errors.New("need to specify length")
+	}
+	elemSize, err := g.typeSize(slice.Elem(), elemTag(tag))
+	if err != nil {
+		return errors.Wrap(err, "can't generate slice element")
+	}
+	l := g.newVar("l")
+	g.p("%s := len(%s)", l, expr)
+	g.p("if %s > %d {", l, tag.Length)
+	g.p("%s = %d", l, tag.Length)
+	g.p("}")
+	i := g.newVar("i")
+	g.p("for %s := 0; %s < %s; %s++ {", i, i, l, i)
+	if err := g.encodeValue(expr+"["+i+"]", slice.Elem(), elemTag(tag), endian, ctx); err != nil {
+		return err
+	}
+	g.p("}")
+	g.p("if %s < %d {", l, tag.Length)
+	if elemSize.variable {
+		g.returnErr(ctx, fmt.Sprintf("can't calculate slice element type length: type %s has variable length", g.typeString(slice.Elem())))
+	} else {
+		g.p("buf = append(buf, make([]byte, (%d-%s)*(%s))...)", tag.Length, l, elemSize.expr())
+	}
+	g.p("}")
+	return nil
+}
+
+// encodeElems generates encoding of all string bytes or slice elements without any padding
+func (g *generator) encodeElems(expr string, t types.Type, tag *schema.Tag, endian, ctx string) error {
+	slice, ok := t.Underlying().(*types.Slice)
+	if !ok {
+		g.p("buf = append(buf, %s...)", expr)
+		return nil
+	}
+	i := g.newVar("i")
+	g.p("for %s := 0; %s < len(%s); %s++ {", i, i, expr, i)
+	if err := g.encodeValue(expr+"["+i+"]", slice.Elem(), elemTag(tag), endian, ctx); err != nil {
+		return err
+	}
+	g.p("}")
+	return nil
+}
+
+// lengthOf generates variable with length of string or slice, nil pointers have zero length
+func (g *generator) lengthOf(expr string, t types.Type) string {
+	l := g.newVar("l")
+	depth := 0
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+		if depth == 0 {
+			g.p("%s := 0", l)
+		}
+		g.p("if %s != nil {", expr)
+		expr, t = "(*"+expr+")", ptr.Elem()
+		depth++
+	}
+	if depth == 0 {
+		g.p("%s := len(%s)", l, expr)
+		return l
+	}
+	g.p("%s = len(%s)", l, expr)
+	g.p(strings.TrimSuffix(strings.Repeat("}\n", depth), "\n"))
+	return l
+}
+
+// lengthValue generates variable with value of integer field, which contains length of another field
+func (g *generator) lengthValue(expr string, t types.Type, ctx string) string {
+	n := g.newVar("n")
+	g.p("var %s int", n)
+	depth := 0
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+		g.p("if %s != nil {", expr)
+		expr, t = "(*"+expr+")", ptr.Elem()
+		depth++
+	}
+	x := g.newVar("x")
+	if isBasicKind(t, types.IsUnsigned) {
+		g.p("if %s := uint64(%s); %s > uint64(^uint(0)>>1) {", x, expr, x)
+	} else {
+		g.p("if %s := int64(%s); %s < 0 || uint64(%s) > uint64(^uint(0)>>1) {", x, expr, x, x)
+	}
+	g.returnErr(ctx, "invalid length %d", x)
+	g.p("} else {")
+	g.p("%s = int(%s)", n, x)
+	g.p("}")
+	if depth > 0 {
+		g.p(strings.TrimSuffix(strings.Repeat("}\n", depth), "\n"))
+	}
+	return n
+}
+
+// encodeLengthField generates encoding of length of autofill fields, which refer to i-th field, instead of it's value
+func (g *generator) encodeLengthField(info *structInfo, i int, endian, ctx string) error {
+	tag := info.tags[i]
+	first := info.fields[tag.LengthOf[0]]
+	length := g.lengthOf("v."+first.expr, first.Type())
+	for _, j := range tag.LengthOf[1:] {
+		dependent := info.fields[j]
+		l := g.lengthOf("v."+dependent.expr, dependent.Type())
+		g.p("if %s != %s {", l, length)
+		g.returnErr(ctx, fmt.Sprintf("%s and %s fields have different length: %%d and %%d", first.Name(), dependent.Name()), length, l)
+		g.p("}")
+	}
+	valueType := indirectType(info.fields[i].Type())
+	basic := valueType.Underlying().(*types.Basic)
+	if width := basicSize(basic); width != 0 && width < 8 {
+		bits := uint(width) * 8
+		if basic.Info()&types.IsUnsigned == 0 {
+			bits--
+		}
+		g.p("if uint64(%s) > %d {", length, uint64(1)<<bits-1)
+		g.returnErr(ctx, fmt.Sprintf("length %%d overflows %s", g.typeString(valueType)), length)
+		g.p("}")
+	}
+	value := g.newVar("l")
+	g.p("%s := %s(%s)", value, g.typeString(valueType), length)
+	return g.encodeValue(value, valueType, tag, endian, ctx)
+}
+
+// encodeLengthFrom generates encoding of i-th field, which length is stored in another field
+func (g *generator) encodeLengthFrom(info *structInfo, i int, endian, ctx string) error {
+	tag := info.tags[i]
+	f := info.fields[i]
+	var length string
+	if !tag.AutoFill || tag.Length != 0 {
+		length = g.lengthOf("v."+f.expr, f.Type())
+	}
+	if !tag.AutoFill {
+		from := info.fields[tag.LengthFromIndex]
+		expected := g.lengthValue("v."+from.expr, from.Type(), ctx)
+		g.p("if %s != %s {", length, expected)
+		g.returnErr(ctx, fmt.Sprintf("length %%d doesn't match %s field value %%d", tag.LengthFrom), length, expected)
+		g.p("}")
+	}
+	if tag.Length != 0 {
+		g.p("if %s > %d {", length, tag.Length)
+		g.returnErr(ctx, fmt.Sprintf("length %%d is greater than max length %d", tag.Length), length)
+		g.p("}")
+	}
+	expr, t, depth := "v."+f.expr, f.Type(), 0
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+		g.p("if %s != nil {", expr)
+		expr, t = "(*"+expr+")", ptr.Elem()
+		depth++
+	}
+	if err := g.encodeElems(expr, t, tag, endian, ctx); err != nil {
+		return err
+	}
+	if depth > 0 {
+		g.p(strings.TrimSuffix(strings.Repeat("}\n", depth), "\n"))
+	}
+	return nil
+}
+
+// encodeBits generates encoding of group of bit fields, which starts from i-th field
+func (g *generator) encodeBits(info *structInfo, start int) {
+	group := info.tags[start]
+	acc := g.newVar("acc")
+	g.p("var %s uint64", acc)
+	var offset uint
+	for j := start; j < group.BitGroupEnd; j++ {
+		f := info.fields[j]
+		bits := uint(info.tags[j].Bits)
+		if f.Name() == "_" {
+			// reserved bits are always zero
+			if group.BitOrder == schema.BitOrderMSB {
+				g.p("%s <<= %d", acc, bits)
+			}
+			offset += bits
+			continue
+		}
+		value := g.bitFieldValue("v."+f.expr, f.Type(), bits, info.name+"."+f.Name())
+		if group.BitOrder == schema.BitOrderLSB {
+			g.p("%s |= %s << %d", acc, value, offset)
+		} else {
+			g.p("%s = %s<<%d | %s", acc, acc, bits, value)
+		}
+		offset += bits
+	}
+	a := g.newVar("a")
+	g.p("var %s [8]byte", a)
+	if group.BitOrder == schema.BitOrderLSB {
+		g.p("binary.LittleEndian.PutUint64(%s[:], %s)", a, acc)
+		g.p("buf = append(buf, %s[:%d]...)", a, group.BitGroupLength)
+	} else {
+		g.p("binary.BigEndian.PutUint64(%s[:], %s)", a, acc)
+		g.p("buf = append(buf, %s[%d:]...)", a, 8-group.BitGroupLength)
+	}
+}
+
+// bitFieldValue generates variable with bits of integer or bool field, checking, that value fits in them
+func (g *generator) bitFieldValue(expr string, t types.Type, bits uint, ctx string) string {
+	x := g.newVar("x")
+	mask := uint64(1)<<bits - 1
+	switch {
+	case isBasicKind(t, types.IsBoolean):
+		g.p("var %s uint64", x)
+		g.p("if %s {", expr)
+		g.p("%s = 1", x)
+		g.p("}")
+		return x
+	case isBasicKind(t, types.IsUnsigned):
+		g.p("%s := uint64(%s)", x, expr)
+		if bits < 64 {
+			g.p("if %s&^%#x != 0 {", x, mask)
+			g.returnErr(ctx, fmt.Sprintf("value %%d doesn't fit in %d bits", bits), x)
+			g.p("}")
+		}
+		return x
+	}
+	g.p("%s := int64(%s)", x, expr)
+	if bits < 64 {
+		g.p("if %s < %d || %s >= %d {", x, -1<<(bits-1), x, 1<<(bits-1))
+		g.returnErr(ctx, fmt.Sprintf("value %%d doesn't fit in %d bits", bits), x)
+		g.p("}")
+	}
+	return fmt.Sprintf("(uint64(%s) & %#x)", x, mask)
+}
+
+func (g *generator) decodeStruct(info *structInfo) error {
+	for i, tag := range info.tags {
+		f := info.fields[i]
+		if tag.Skip || isEmptyStruct(f.Type()) {
+			continue
+		}
+		endian := g.endianExpr(tag, "endian")
+		ctx := info.name + "." + f.Name()
+		p := path{}.field(f.Name())
+		var err error
+		switch {
+		case tag.Bits != 0:
+			if tag.BitGroupLength != 0 {
+				g.decodeBits(info, i, p)
+			}
+		case tag.LengthFrom != "":
+			err = g.decodeLengthFrom(info, i, endian, ctx, p)
+		case f.Name() == "_":
+			z := g.newVar("z")
+			g.p("%s := new(%s)", z, g.typeString(f.Type()))
+			err = g.decodeValue("(*"+z+")", f.Type(), tag, endian, ctx, p)
+		default:
+			err = g.decodeValue("v."+f.expr, f.Type(), tag, endian, ctx, p)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "%v field error", ctx)
+		}
+	}
+	return nil
+}
+
+func (g *generator) decodeValue(expr string, t types.Type, tag *schema.Tag, endian, ctx string, p path) error {
+	if info := g.generated(t); info != nil {
+		g.usesErr, g.usesN = true, true
+		g.p("if n, err = %s.UnmarshalD2B(b, %s); err != nil {", expr, endian)
+		g.wrapDecodeErr(p, ctx)
+		g.p("}")
+		g.p("b = b[n:]")
+		return nil
+	}
+	custom, fixedSizer, err := g.customType(t)
+	if err != nil {
+		return err
+	}
+	if custom {
+		g.usesErr, g.usesN = true, true
+		data, length := "b", "len(b)"
+		if fixedSizer {
+			length = g.newVar("s")
+			g.p("%s := %s.SizeD2B()", length, expr)
+			g.checkLength(p, length)
+			data = "b[:" + length + "]"
+		}
+		g.p("if n, err = %s.UnmarshalD2B(%s, %s); err != nil {", expr, data, endian)
+		g.wrapDecodeErr(p, ctx)
+		g.p("}")
+		g.p("if n < 0 || n > %s {", length)
+		g.returnErr(ctx, fmt.Sprintf("%s unmarshaler used %%d bytes of %%d", g.typeString(t)), "n", length)
+		g.p("}")
+		g.p("b = b[n:]")
+		return nil
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		if isEmptyStruct(u.Elem()) {
+			return nil
+		}
+		g.p("if %s == nil {", expr)
+		g.p("%s = new(%s)", expr, g.typeString(u.Elem()))
+		g.p("}")
+		return g.decodeValue("(*"+expr+")", u.Elem(), tag, endian, ctx, p)
+	case *types.Basic:
+		return g.decodeBasic(expr, t, u, tag, endian, ctx, p)
+	case *types.Array:
+		if isEmptyStruct(u.Elem()) {
+			return nil
+		}
+		i := g.newVar("i")
+		g.p("for %s := 0; %s < len(%s); %s++ {", i, i, expr, i)
+		if err := g.decodeValue(expr+"["+i+"]", u.Elem(), elemTag(tag), endian, ctx, p.index(i)); err != nil {
+			return err
+		}
+		g.p("}")
+		return nil
+	case *types.Slice:
+		return g.decodeSlice(expr, t, u, tag, endian, ctx, p)
+	}
+	_, err = g.typeSize(t, tag)
+	return err
+}
+
+func (g *generator) decodeBasic(expr string, t types.Type, basic *types.Basic, tag *schema.Tag, endian, ctx string, p path) error {
+	typeName := g.typeString(t)
+	kind := basic.Kind()
+	length := basicSize(basic)
+	var value, valueType string
+	switch {
+	case kind == types.Bool:
+		g.checkLength(p, "1")
+		if tag.StrictBool {
+			g.p("if b[0] > 1 {")
+			g.returnErr(ctx, "invalid bool value %d", "b[0]")
+			g.p("}")
+		}
+		value, valueType = "b[0] != 0", "bool"
+	case kind == types.Int || kind == types.Uint:
+		if tag.Size == 0 {
+			return errors.Errorf("%v needs size tag", t)
+		}
+		length = tag.Size
+		g.checkLength(p, strconv.Itoa(length))
+		x := g.newVar("x")
+		if kind == types.Uint {
+			g.p("%s := %s", x, readUint(length, endian))
+			if length == 8 {
+				g.p("if uint64(uint(%s)) != %s {", x, x)
+				g.returnErr(ctx, fmt.Sprintf("value %%d overflows %s", typeName), x)
+				g.p("}")
+			}
+		} else {
+			shift := 64 - length*8
+			if shift == 0 {
+				g.p("%s := int64(%s)", x, readUint(length, endian))
+				g.p("if int64(int(%s)) != %s {", x, x)
+				g.returnErr(ctx, fmt.Sprintf("value %%d overflows %s", typeName), x)
+				g.p("}")
+			} else {
+				g.p("%s := int64(%s<<%d) >> %d", x, readUint(length, endian), shift, shift)
+			}
+		}
+		value = x
+	case kind == types.Int8:
+		g.checkLength(p, "1")
+		value, valueType = "b[0]", "uint8"
+	case kind == types.Uint8:
+		g.checkLength(p, "1")
+		value, valueType = "b[0]", "uint8"
+	case kind == types.Int16:
+		g.checkLength(p, "2")
+		value, valueType = fmt.Sprintf("%s.Uint16(b)", endian), "uint16"
+	case kind == types.Uint16:
+		g.checkLength(p, "2")
+		value, valueType = fmt.Sprintf("%s.Uint16(b)", endian), "uint16"
+	case kind == types.Int32:
+		g.checkLength(p, "4")
+		value, valueType = fmt.Sprintf("%s.Uint32(b)", endian), "uint32"
+	case kind == types.Uint32:
+		g.checkLength(p, "4")
+		value, valueType = fmt.Sprintf("%s.Uint32(b)", endian), "uint32"
+	case kind == types.Int64:
+		g.checkLength(p, "8")
+		value, valueType = fmt.Sprintf("%s.Uint64(b)", endian), "uint64"
+	case kind == types.Uint64:
+		g.checkLength(p, "8")
+		value, valueType = fmt.Sprintf("%s.Uint64(b)", endian), "uint64"
+	case basic.Info()&types.IsFloat != 0 && tag.Float16:
+		length = 2
+		g.checkLength(p, "2")
+		value, valueType = fmt.Sprintf("%s.Float16FromBits(%s.Uint16(b))", g.d2b(), endian), "float32"
+	case kind == types.Float32:
+		g.imports["math"] = "math"
+		g.checkLength(p, "4")
+		value, valueType = fmt.Sprintf("math.Float32frombits(%s.Uint32(b))", endian), "float32"
+	case kind == types.Float64:
+		g.imports["math"] = "math"
+		g.checkLength(p, "8")
+		value, valueType = fmt.Sprintf("math.Float64frombits(%s.Uint64(b))", endian), "float64"
+	case basic.Info()&types.IsString != 0:
+		return g.decodeString(expr, typeName, tag, endian, ctx, p)
+	default:
+		return errors.Errorf("unsupported type %v", t)
+	}
+	if typeName == valueType {
+		g.p("%s = %s", expr, value)
+	} else {
+		g.p("%s = %s(%s)", expr, typeName, value)
+	}
+	g.p("b = b[%d:]", length)
+	return nil
+}
+
+func (g *generator) decodeString(expr, typeName string, tag *schema.Tag, endian, ctx string, p path) error {
+	if tag.LenPrefix != 0 {
+		length := g.decodeLengthPrefix(tag, endian, ctx, p)
+		g.decodeStringBytes(expr, typeName, length, p)
+		return nil
+	}
+	if tag.CString && tag.Length == 0 {
+		e, i, c := g.newVar("e"), g.newVar("i"), g.newVar("c")
+		g.p("%s := -1", e)
+		g.p("for %s, %s := range b {", i, c)
+		g.p("if %s == 0 {", c)
+		g.p("%s = %s", e, i)
+		g.p("break")
+		g.p("}")
+		g.p("}")
+		g.p("if %s < 0 {", e)
+		g.p("return 0, &%s.ShortBufferError{Path: %s, Needed: len(b) + 1, Available: len(b)}", g.d2b(), g.pathString(p))
+		g.p("}")
+		g.p("if string(%s) != string(b[:%s]) {", expr, e)
+		g.p("%s = %s(b[:%s])", expr, typeName, e)
+		g.p("}")
+		g.p("b = b[%s+1:]", e)
+		return nil
+	}
+	if tag.Length == 0 {
+		return errors.New("need to specify length")
+	}
+	g.checkLength(p, strconv.Itoa(tag.Length))
+	s := g.newVar("s")
+	g.p("%s := b[:%d]", s, tag.Length)
+	switch {
+	case tag.TrimRight:
+		g.p("for len(%s) > 0 && %s[len(%s)-1] == %d {", s, s, s, tag.Pad)
+		g.p("%s = %s[:len(%s)-1]", s, s, s)
+		g.p("}")
+	case tag.Pad == 0:
+		i, c := g.newVar("i"), g.newVar("c")
+		g.p("for %s, %s := range %s {", i, c, s)
+		g.p("if %s == 0 {", c)
+		g.p("%s = %s[:%s]", s, s, i)
+		g.p("break")
+		g.p("}")
+		g.p("}")
+	}
+	g.p("if string(%s) != string(%s) {", expr, s)
+	g.p("%s = %s(%s)", expr, typeName, s)
+	g.p("}")
+	g.p("b = b[%d:]", tag.Length)
+	return nil
+}
+
+// decodeStringBytes generates decoding of string, which takes length bytes
+// String isn't reallocated, if it's not changed
+func (g *generator) decodeStringBytes(expr, typeName, length string, p path) {
+	g.checkLength(p, length)
+	g.p("if string(%s) != string(b[:%s]) {", expr, length)
+	g.p("%s = %s(b[:%s])", expr, typeName, length)
+	g.p("}")
+	g.p("b = b[%s:]", length)
+}
+
+// decodeLengthPrefix generates variable with decoded length of string/slice, checking it's max length
+func (g *generator) decodeLengthPrefix(tag *schema.Tag, endian, ctx string, p path) string {
+	g.checkLength(p, strconv.Itoa(tag.LenPrefix))
+	prefix := g.newVar("l")
+	g.p("%s := %s", prefix, readUint(tag.LenPrefix, endian))
+	g.p("b = b[%d:]", tag.LenPrefix)
+	if tag.Length != 0 {
+		g.p("if %s > %d {", prefix, tag.Length)
+		g.returnErr(ctx, fmt.Sprintf("length %%d is greater than max length %d", tag.Length), prefix)
+		g.p("}")
+	}
+	if tag.LenPrefix >= 4 {
+		g.p("if %s > uint64(^uint(0)>>1) {", prefix)
+		g.returnErr(ctx, "length %d is too big", prefix)
+		g.p("}")
+	}
+	length := g.newVar("n")
+	g.p("%s := int(%s)", length, prefix)
+	return length
+}
+
+func (g *generator) decodeSlice(expr string, t types.Type, slice *types.Slice, tag *schema.Tag, endian, ctx string, p path) error {
+	if tag.LenPrefix != 0 {
+		length := g.decodeLengthPrefix(tag, endian, ctx, p)
+		return g.decodeSliceElems(expr, t, slice, tag, length, endian, ctx, p)
+	}
+	if tag.Length == 0 {
+		return errors.New("need to specify length")
+	}
+	g.p("if len(%s) < %d {", expr, tag.Length)
+	s := g.newVar("s")
+	g.p("%s := make(%s, %d)", s, g.typeString(t), tag.Length)
+	g.p("copy(%s, %s)", s, expr)
+	g.p("%s = %s", expr, s)
+	g.p("}")
+	i := g.newVar("i")
+	g.p("for %s := 0; %s < len(%s); %s++ {", i, i, expr, i)
+	if err := g.decodeValue(expr+"["+i+"]", slice.Elem(), elemTag(tag), endian, ctx, p.index(i)); err != nil {
+		return err
+	}
+	g.p("}")
+	return nil
+}
+
+// decodeSliceElems generates decoding of slice with length elements
+func (g *generator) decodeSliceElems(expr string, t types.Type, slice *types.Slice, tag *schema.Tag, length, endian, ctx string, p path) error {
+	elemSize, err := g.typeSize(slice.Elem(), elemTag(tag))
+	if err != nil {
+		return errors.Wrap(err, "can't generate slice element")
+	}
+	if elemSize.isConst() && elemSize.n == 0 {
+		return errors.Errorf("length of slice with zero size elements %v can't be stored in bytes", slice.Elem())
+	}
+	// check, that all elements are present before allocating them
+	if !elemSize.variable {
+		es := elemSize.expr()
+		if !elemSize.isConst() {
+			es = g.newVar("es")
+			g.p("if %s := %s; %s > 0 {", es, elemSize.expr(), es)
+		}
+		needed := g.newVar("e")
+		g.p("%s := %s", needed, maxIntExpr)
+		g.p("if %s <= %s/(%s) {", length, needed, es)
+		g.p("%s = %s * (%s)", needed, length, es)
+		g.p("}")
+		g.checkLength(p, needed)
+		if !elemSize.isConst() {
+			g.p("}")
+		}
+	}
+	capacity, s, z, i := g.newVar("c"), g.newVar("s"), g.newVar("z"), g.newVar("i")
+	g.p("%s := %s", capacity, length)
+	g.p("if %s > len(b) {", capacity)
+	g.p("%s = len(b)", capacity)
+	g.p("}")
+	g.p("%s := make(%s, 0, %s)", s, g.typeString(t), capacity)
+	g.p("var %s %s", z, g.typeString(slice.Elem()))
+	g.p("for %s := 0; %s < %s; %s++ {", i, i, length, i)
+	g.p("%s = append(%s, %s)", s, s, z)
+	if err := g.decodeValue(s+"["+i+"]", slice.Elem(), elemTag(tag), endian, ctx, p.index(i)); err != nil {
+		return err
+	}
+	g.p("}")
+	g.p("%s = %s", expr, s)
+	return nil
+}
+
+// decodeLengthFrom generates decoding of i-th field, which length is stored in already decoded field
+func (g *generator) decodeLengthFrom(info *structInfo, i int, endian, ctx string, p path) error {
+	tag := info.tags[i]
+	from := info.fields[tag.LengthFromIndex]
+	length := g.lengthValue("v."+from.expr, from.Type(), ctx)
+	if tag.Length != 0 {
+		g.p("if %s > %d {", length, tag.Length)
+		g.returnErr(ctx, fmt.Sprintf("length %%d is greater than max length %d", tag.Length), length)
+		g.p("}")
+	}
+	f := info.fields[i]
+	expr, t := "v."+f.expr, f.Type()
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+		g.p("if %s == nil {", expr)
+		g.p("%s = new(%s)", expr, g.typeString(ptr.Elem()))
+		g.p("}")
+		expr, t = "(*"+expr+")", ptr.Elem()
+	}
+	if slice, ok := t.Underlying().(*types.Slice); ok {
+		return g.decodeSliceElems(expr, t, slice, tag, length, endian, ctx, p)
+	}
+	g.decodeStringBytes(expr, g.typeString(t), length, p)
+	return nil
+}
+
+// decodeBits generates decoding of group of bit fields, which starts from i-th field
+func (g *generator) decodeBits(info *structInfo, start int, p path) {
+	group := info.tags[start]
+	g.checkLength(p, strconv.Itoa(group.BitGroupLength))
+	a, acc := g.newVar("a"), g.newVar("acc")
+	g.p("var %s [8]byte", a)
+	if group.BitOrder == schema.BitOrderLSB {
+		g.p("copy(%s[:], b[:%d])", a, group.BitGroupLength)
+		g.p("%s := binary.LittleEndian.Uint64(%s[:])", acc, a)
+	} else {
+		g.p("copy(%s[%d:], b[:%d])", a, 8-group.BitGroupLength, group.BitGroupLength)
+		g.p("%s := binary.BigEndian.Uint64(%s[:])", acc, a)
+	}
+	var offset uint
+	remaining := uint(group.BitGroupLength * 8)
+	for j := start; j < group.BitGroupEnd; j++ {
+		f := info.fields[j]
+		bits := uint(info.tags[j].Bits)
+		var shift uint
+		if group.BitOrder == schema.BitOrderLSB {
+			shift = offset
+		} else {
+			remaining -= bits
+			shift = remaining
+		}
+		offset += bits
+		if f.Name() == "_" {
+			continue
+		}
+		value := fmt.Sprintf("(%s >> %d & %#x)", acc, shift, uint64(1)<<bits-1)
+		typeName := g.typeString(f.Type())
+		switch {
+		case isBasicKind(f.Type(), types.IsBoolean):
+			g.p("v.%s = %s(%s != 0)", f.expr, typeName, value)
+		case isBasicKind(f.Type(), types.IsUnsigned):
+			g.p("v.%s = %s(%s)", f.expr, typeName, value)
+		default:
+			g.p("v.%s = %s(int64(%s<<%d) >> %d)", f.expr, typeName, value, 64-bits, 64-bits)
+		}
+	}
+	g.p("b = b[%d:]", group.BitGroupLength)
+}
@@ -0,0 +1,145 @@
+// d2bgen generates MarshalD2B, UnmarshalD2B, AppendD2B and SizeD2B methods of structs,
+// which encode them the same way as d2b does, but without reflection
+//
+// Usage:
+//
+//	//go:generate d2bgen -type Header,Record
+//
+// By default methods of all structs, declared in the file, are generated to <file>_d2b.go
+// With -skipunexported flag unexported fields are skipped like with d2b.Options.SkipUnexported
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct types, all structs of the file by default")
+	output := flag.String("output", "", "output file name, <file>_d2b.go by default")
+	skipUnexported := flag.Bool("skipunexported", false, "skip unexported fields like d2b.Options.SkipUnexported")
+	flag.Parse()
+
+	file := flag.Arg(0)
+	if file == "" {
+		file = os.Getenv("GOFILE")
+	}
+	if file == "" {
+		fmt.Fprintln(os.Stderr, "usage: d2bgen [-type T1,T2] [-output file] [-skipunexported] file.go")
+		os.Exit(2)
+	}
+	if *output == "" {
+		*output = strings.TrimSuffix(file, ".go") + "_d2b.go"
+	}
+	var names []string
+	if *typeNames != "" {
+		names = strings.Split(*typeNames, ",")
+	}
+	src, err := generateFile(file, names, *output, *skipUnexported)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "d2bgen:", err)
+		os.Exit(1)
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "d2bgen:", err)
+		os.Exit(1)
+	}
+}
+
+// generateFile generates methods of types from file, which is type checked with the rest of it's package
+// If names is empty, methods of all structs, declared in the file, are generated
+func generateFile(file string, names []string, output string, skipUnexported bool) ([]byte, error) {
+	pkg, fileScope, err := loadPackage(file, output)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		names = fileScope
+	}
+	var targets []*types.Named
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, errors.Errorf("type %v is not found", name)
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, errors.Errorf("%v is not a named type", name)
+		}
+		targets = append(targets, named)
+	}
+	g, err := newGenerator(pkg, targets, skipUnexported)
+	if err != nil {
+		return nil, err
+	}
+	return g.generate()
+}
+
+// loadPackage type checks package of file and returns names of struct types, declared in file
+// output file isn't loaded, so old generated methods don't affect the new ones
+func loadPackage(file, output string) (*types.Package, []string, error) {
+	dir := filepath.Dir(file)
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	var structs []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") ||
+			name == filepath.Base(output) {
+			continue
+		}
+		if ok, err := build.Default.MatchFile(dir, name); err != nil || !ok {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, f)
+		if name == filepath.Base(file) {
+			structs = structTypes(f)
+		}
+	}
+	if len(files) == 0 {
+		return nil, nil, errors.Errorf("no go files in %v", dir)
+	}
+	// errors are ignored, because generated methods can be used by the package
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil), Error: func(error) {}}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, nil)
+	return pkg, structs, nil
+}
+
+// structTypes returns names of struct types, declared in f
+func structTypes(f *ast.File) []string {
+	var result []string
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.TypeSpec)
+			if _, ok := spec.Type.(*ast.StructType); ok && spec.TypeParams == nil {
+				result = append(result, spec.Name.Name)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenerate(t *testing.T) {
+	Convey("Test d2bgen", t, func() {
+		Convey("Should generate only requested types", func() {
+			src, err := generateSource(`
+type A struct{ X uint8 }
+type B struct{ Y int }`, false, "A")
+			So(err, ShouldBeNil)
+			So(src, ShouldContainSubstring, "func (v *A) UnmarshalD2B")
+			So(src, ShouldNotContainSubstring, "func (v *B)")
+		})
+		Convey("Should skip unexported fields like d2b, if it's requested", func() {
+			decls := "type S struct{ A uint8\nb uint16\n_ uint8 `d2b:\"bits:4\"`\nC uint8 `d2b:\"bits:4\"` }"
+			src, err := generateSource(decls, true, "S")
+			So(err, ShouldBeNil)
+			So(src, ShouldContainSubstring, "func (v *S) SizeD2B() int {\n\treturn 2\n}")
+			So(src, ShouldNotContainSubstring, "v.b")
+			src, err = generateSource(decls, false, "S")
+			So(err, ShouldBeNil)
+			So(src, ShouldContainSubstring, "v.b")
+
+			decls = "type S struct{ n uint8\nA []uint8 `d2b:\"lengthfrom:n\"` }"
+			_, err = generateSource(decls, true, "S")
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should return error if type is not supported", func() {
+			cases := map[string]string{
+				"unknown tag option": "type S struct{ A uint8 `d2b:\"unknown\"` }",
+				"int without size":   "type S struct{ A int }",
+				"string length":      "type S struct{ A string }",
+				"blank prefixed":     "type S struct{ _ []uint8 `d2b:\"lenprefix:uint8\"` }",
+				"not generated":      "type S struct{ A T }\ntype T struct{ B uint8 }",
+				"existing methods":   "type S struct{ A uint8 }\nfunc (s *S) SizeD2B() int { return 1 }",
+				"lengthfrom":         "type S struct{ A []uint8 `d2b:\"lengthfrom:B\"`\nB uint8 }",
+				"autofilled bits":    "type S struct{ A uint8 `d2b:\"bits:8\"`\nB []uint8 `d2b:\"lengthfrom:A,autofill\"` }",
+				"zero size elements": "type S struct{ A []struct{} `d2b:\"lenprefix:uint32\"` }",
+				"elem of string":     "type S struct{ A string `d2b:\"length:2,elem.length:2\"` }",
+			}
+			for name, src := range cases {
+				src := src
+				Convey(name, func() {
+					_, err := generateSource(src, false, "S")
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+		Convey("Should report options, which d2bgen doesn't support", func() {
+			cases := map[string]string{
+				"varint":           "type S struct{ A uint32 `d2b:\"varint\"` }",
+				"zigzag":           "type S struct{ A int32 `d2b:\"zigzag\"` }",
+				"lenprefix:varint": "type S struct{ A []uint8 `d2b:\"lenprefix:varint\"` }",
+				"charset":          "type S struct{ A string `d2b:\"length:2,charset:utf16le\"` }",
+				"reserved":         "type S struct{ A uint8 `d2b:\"reserved:2\"` }",
+				"const":            "type S struct{ A uint8 `d2b:\"const:1\"` }",
+				"align":            "type S struct{ A uint8 `d2b:\"align:4\"` }",
+				"natural":          "type S struct{ _ struct{} `d2b:\"natural\"`\nA uint8 }",
+				"offset":           "type S struct{ A uint8 `d2b:\"offset:4\"` }",
+				"elem.charset":     "type S struct{ A [2]string `d2b:\"elem.length:2,elem.charset:utf16le\"` }",
+				"maps":             "type S struct{ A map[int]int }",
+				"recursive":        "type S struct{ A *S }",
+				"BinaryMarshaler":  "type S struct{ A T }\ntype T [2]byte\nfunc (t T) MarshalBinary() ([]byte, error) { return t[:], nil }",
+			}
+			for option, src := range cases {
+				src := src
+				Convey(option, func() {
+					_, err := generateSource(src, false, "S")
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldContainSubstring, option)
+					So(err.Error(), ShouldContainSubstring, "not supported by d2bgen")
+				})
+			}
+		})
+	})
+}
+
+// generateSource generates methods of types from declarations, placed to temporary package
+func generateSource(decls string, skipUnexported bool, types ...string) (string, error) {
+	dir, err := ioutil.TempDir("", "d2bgen")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "types.go")
+	if err := ioutil.WriteFile(file, []byte("package test\n"+decls+"\n"), 0644); err != nil {
+		return "", err
+	}
+	src, err := generateFile(file, types, filepath.Join(dir, "types_d2b.go"), skipUnexported)
+	return string(src), err
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"go/types"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"gopkg.in/saturn4er/go-data-to-bytes.v2/internal/schema"
+)
+
+// tagParser parses tags like d2b does, but unknown options are errors
+// Charsets and constants aren't checked, because d2bgen doesn't support them
+var tagParser = &schema.Parser{RejectUnknown: true}
+
+// sourceType describes type of parsed source code for schema package
+type sourceType struct {
+	types.Type
+	g *generator
+}
+
+var basicKinds = map[types.BasicKind]reflect.Kind{
+	types.Bool:          reflect.Bool,
+	types.Int:           reflect.Int,
+	types.Int8:          reflect.Int8,
+	types.Int16:         reflect.Int16,
+	types.Int32:         reflect.Int32,
+	types.Int64:         reflect.Int64,
+	types.Uint:          reflect.Uint,
+	types.Uint8:         reflect.Uint8,
+	types.Uint16:        reflect.Uint16,
+	types.Uint32:        reflect.Uint32,
+	types.Uint64:        reflect.Uint64,
+	types.Uintptr:       reflect.Uintptr,
+	types.Float32:       reflect.Float32,
+	types.Float64:       reflect.Float64,
+	types.Complex64:     reflect.Complex64,
+	types.Complex128:    reflect.Complex128,
+	types.String:        reflect.String,
+	types.UnsafePointer: reflect.UnsafePointer,
+}
+
+func (t sourceType) Kind() reflect.Kind {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return basicKinds[u.Kind()]
+	case *types.Pointer:
+		return reflect.Ptr
+	case *types.Array:
+		return reflect.Array
+	case *types.Slice:
+		return reflect.Slice
+	case *types.Map:
+		return reflect.Map
+	case *types.Struct:
+		return reflect.Struct
+	case *types.Chan:
+		return reflect.Chan
+	case *types.Signature:
+		return reflect.Func
+	case *types.Interface:
+		return reflect.Interface
+	}
+	return reflect.Invalid
+}
+
+func (t sourceType) Elem() schema.Type {
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return sourceType{u.Elem(), t.g}
+	case *types.Array:
+		return sourceType{u.Elem(), t.g}
+	case *types.Slice:
+		return sourceType{u.Elem(), t.g}
+	case *types.Map:
+		return sourceType{u.Elem(), t.g}
+	}
+	return nil
+}
+
+func (t sourceType) Key() schema.Type {
+	return sourceType{t.Underlying().(*types.Map).Key(), t.g}
+}
+
+func (t sourceType) Bits() int {
+	return basicSize(t.Underlying().(*types.Basic)) * 8
+}
+
+func (t sourceType) NumField() int {
+	return t.Underlying().(*types.Struct).NumFields()
+}
+
+func (t sourceType) Field(i int) schema.Field {
+	s := t.Underlying().(*types.Struct)
+	f := s.Field(i)
+	return schema.Field{
+		Name:      f.Name(),
+		Type:      sourceType{f.Type(), t.g},
+		Tag:       reflect.StructTag(s.Tag(i)),
+		Anonymous: f.Embedded(),
+		Exported:  f.Exported(),
+		Index:     []int{i},
+	}
+}
+
+// Custom checks if type has it's own bytes representation. Generated structs have it too
+func (t sourceType) Custom() bool {
+	if t.g.generated(t.Type) != nil {
+		return true
+	}
+	custom, _, err := t.g.customType(t.Type)
+	return custom || err != nil
+}
+
+// parseStructTags parses tags of struct fields and resolves references between them
+// Unexported fields are marked as skipped, if skipUnexported is set
+func (g *generator) parseStructTags(info *structInfo) error {
+	parsed, err := tagParser.ParseStruct(sourceType{info.named, g})
+	if err != nil {
+		return err
+	}
+	info.fields = make([]structField, len(parsed.Fields))
+	for i, field := range parsed.Fields {
+		info.fields[i] = newStructField(info.s, field.Index)
+		tag := parsed.Tags[i]
+		if err := checkSupported(tag, ""); err != nil {
+			return errors.Wrapf(err, "%v field tag error", field.Name)
+		}
+		if field.Name == "_" && (tag.LengthFrom != "" || tag.LenPrefix != 0) {
+			return errors.New("blank field with variable length is not supported by d2bgen")
+		}
+		if g.skipUnexported && !tag.Skip {
+			skip, err := parsed.SkipUnexported(i)
+			if err != nil {
+				return errors.Wrapf(err, "%v field error", field.Name)
+			}
+			tag.Skip = skip
+		}
+	}
+	info.tags = parsed.Tags
+	return nil
+}
+
+// checkSupported returns error, if tag or tags of it's elements have options, which d2bgen doesn't support
+// prefix is the prefix of options of elements. Options of maps are not checked, because maps aren't supported at all
+func checkSupported(tag *schema.Tag, prefix string) error {
+	var option string
+	switch {
+	case tag.Varint:
+		option = "varint"
+	case tag.ZigZag:
+		option = "zigzag"
+	case tag.LenPrefix == schema.LenPrefixVarint:
+		option = "lenprefix:varint"
+	case tag.Charset != "":
+		option = "charset"
+	case tag.Reserved != 0:
+		option = "reserved"
+	case tag.Const != "":
+		option = "const"
+	case tag.Align != 0:
+		option = "align"
+	case tag.Layout == schema.LayoutNatural:
+		option = "natural"
+	case tag.HasOffset:
+		option = "offset"
+	}
+	if option != "" {
+		return errors.Errorf("tag option %s%s is not supported by d2bgen", prefix, option)
+	}
+	if tag.Elem != nil {
+		return checkSupported(tag.Elem, prefix+"elem.")
+	}
+	return nil
+}
+
+// elemTag returns options of elements of array or slice with tag
+func elemTag(tag *schema.Tag) *schema.Tag {
+	if tag.Elem == nil {
+		return &schema.Tag{}
+	}
+	return tag.Elem
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// indirectType returns type, pointers point to
+func indirectType(t types.Type) types.Type {
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			return t
+		}
+		t = ptr.Elem()
+	}
+}
+
+// isBasicKind checks if t is a basic type with info flag, e.g. types.IsInteger
+func isBasicKind(t types.Type, info types.BasicInfo) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&info != 0
+}
+
+// basicSize returns size in bytes of fixed size number or bool, zero for other types
+func basicSize(basic *types.Basic) int {
+	switch basic.Kind() {
+	case types.Bool, types.Int8, types.Uint8:
+		return 1
+	case types.Int16, types.Uint16:
+		return 2
+	case types.Int32, types.Uint32, types.Float32:
+		return 4
+	case types.Int64, types.Uint64, types.Float64:
+		return 8
+	}
+	return 0
+}
+
+// size is length of bytes representation, which is known while generating code
+// calls contain expressions of FixedSizer custom types, which are known only at runtime
+type size struct {
+	n        int
+	calls    []string
+	variable bool
+}
+
+func fixedSize(n int) size {
+	return size{n: n}
+}
+
+var variableSize = size{variable: true}
+
+func (s size) add(other size) size {
+	if s.variable || other.variable {
+		return variableSize
+	}
+	return size{n: s.n + other.n, calls: append(append([]string{}, s.calls...), other.calls...)}
+}
+
+func (s size) mul(k int) size {
+	if s.variable {
+		return variableSize
+	}
+	result := size{n: s.n * k}
+	if k == 0 {
+		return result
+	}
+	for _, call := range s.calls {
+		if k == 1 {
+			result.calls = append(result.calls, call)
+		} else {
+			result.calls = append(result.calls, fmt.Sprintf("%d*%s", k, call))
+		}
+	}
+	return result
+}
+
+// isConst checks if size is fixed and known while generating code
+func (s size) isConst() bool {
+	return !s.variable && len(s.calls) == 0
+}
+
+// expr returns Go expression of fixed size
+func (s size) expr() string {
+	parts := s.calls
+	if s.n != 0 || len(parts) == 0 {
+		parts = append([]string{fmt.Sprint(s.n)}, parts...)
+	}
+	return strings.Join(parts, " + ")
+}
@@ -0,0 +1,556 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gopkg.in/saturn4er/go-data-to-bytes.v2/internal/schema"
+)
+
+// codec is a compiled plan of encoding and decoding values of one type
+type codec interface {
+	// encode appends bytes representation of v to buf
+	encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error)
+	// decode updates v from bytes at the input position and moves it after them
+	decode(in *input, v reflect.Value, endian binary.ByteOrder) error
+	// size returns length of bytes representation or -1 if it's variable
+	size() int
+}
+
+// Codec encodes and decodes values of one type without walking it's reflect.Type on every call
+type Codec struct {
+	t    reflect.Type
+	root codec
+}
+
+type codecKey struct {
+	t    reflect.Type
+	opts Options
+}
+
+var codecsMx sync.RWMutex
+var codecs = make(map[codecKey]*Codec)
+
+// Compile returns Codec for type t. Codecs are cached, so it's cheap to call Compile for the same type again
+func Compile(t reflect.Type) (*Codec, error) {
+	return CompileWithOptions(t, Options{})
+}
+
+// CompileWithOptions returns Codec for type t, which uses options
+func CompileWithOptions(t reflect.Type, opts Options) (*Codec, error) {
+	if t == nil {
+		return nil, errors.New("can't compile nil type")
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	key := codecKey{t: t, opts: opts}
+	codecsMx.RLock()
+	if c, ok := codecs[key]; ok {
+		codecsMx.RUnlock()
+		return c, nil
+	}
+	codecsMx.RUnlock()
+	compiler := &compiler{opts: opts, visiting: make(map[reflect.Type]bool)}
+	root, err := compiler.compileType(t)
+	if err != nil {
+		return nil, err
+	}
+	c := &Codec{t: t, root: root}
+	codecsMx.Lock()
+	codecs[key] = c
+	codecsMx.Unlock()
+	return c, nil
+}
+
+// Encode converts data, which should have Codec's type or be a pointer to it, to bytes array
+func (c *Codec) Encode(data interface{}, endian binary.ByteOrder) ([]byte, error) {
+	return c.AppendEncode(nil, data, endian)
+}
+
+// AppendEncode appends bytes representation of data to dst and returns the extended buffer
+// dst is returned unchanged on error
+func (c *Codec) AppendEncode(dst []byte, data interface{}, endian binary.ByteOrder) ([]byte, error) {
+	v, err := c.value(data)
+	if err != nil {
+		return dst, err
+	}
+	buf, err := c.root.encode(dst, v, endian)
+	if err != nil {
+		return dst, err
+	}
+	return buf, nil
+}
+
+// EncodeTo writes bytes representation of data to dst and returns count of written bytes
+// Returns io.ErrShortBuffer if dst is too small. In this case part of dst can be already overwritten
+func (c *Codec) EncodeTo(dst []byte, data interface{}, endian binary.ByteOrder) (int, error) {
+	v, err := c.value(data)
+	if err != nil {
+		return 0, err
+	}
+	if size, ok := c.Size(); ok && size > len(dst) {
+		return 0, io.ErrShortBuffer
+	}
+	// limit capacity, so encoding of variable length values can't write after len(dst)
+	buf, err := c.root.encode(dst[:0:len(dst)], v, endian)
+	if err != nil {
+		return 0, err
+	}
+	if len(buf) > len(dst) {
+		return 0, io.ErrShortBuffer
+	}
+	return len(buf), nil
+}
+
+// Decode writes byte array to data, which should be a pointer to Codec's type
+func (c *Codec) Decode(bytes []byte, endian binary.ByteOrder, data interface{}) error {
+	in := inputs.Get().(*input)
+	*in = input{buf: bytes}
+	err := c.decode(in, endian, data)
+	// pool shouldn't keep decoded bytes
+	*in = input{}
+	inputs.Put(in)
+	return err
+}
+
+// decode writes bytes at the input position to data
+func (c *Codec) decode(in *input, endian binary.ByteOrder, data interface{}) error {
+	v := reflect.ValueOf(data)
+	if !v.IsValid() || v.Kind() != reflect.Ptr || v.Type().Elem() != c.t {
+		return errors.Errorf("data should be pointer to %v", c.t)
+	}
+	if v.IsNil() {
+		return errors.New("can't decode to nil pointer")
+	}
+	return c.root.decode(in, v.Elem(), endian)
+}
+
+// Size returns length of bytes representation of Codec's type. ok is false if it's variable
+func (c *Codec) Size() (size int, ok bool) {
+	size = c.root.size()
+	return size, size >= 0
+}
+
+// ValueSize returns length of bytes representation of data, which should have Codec's type or be a pointer to it
+func (c *Codec) ValueSize(data interface{}) (int, error) {
+	v, err := c.value(data)
+	if err != nil {
+		return 0, err
+	}
+	return valueSize(c.root, v)
+}
+
+// value returns reflect.Value of data, which should have Codec's type or be a pointer to it
+// nil pointer is replaced with zero value
+func (c *Codec) value(data interface{}) (reflect.Value, error) {
+	if data == nil {
+		return reflect.Value{}, errors.New("can't encode nil")
+	}
+	v := reflect.ValueOf(data)
+	if v.Type() == c.t {
+		return v, nil
+	}
+	if v.Kind() != reflect.Ptr || v.Type().Elem() != c.t {
+		return reflect.Value{}, errors.Errorf("data should be %v or pointer to it", c.t)
+	}
+	if v.IsNil() {
+		return reflect.Zero(c.t), nil
+	}
+	return v.Elem(), nil
+}
+
+// SizeOf returns length of bytes representation of type t
+// Returns error if t has variable length, use Size to get length of value with such type
+func SizeOf(t reflect.Type) (int, error) {
+	return getTypeBytesLength(t, Options{})
+}
+
+// Size returns length of bytes representation of data, calculating length of variable length fields
+func Size(data interface{}) (int, error) {
+	c, err := compileValueType(data, Options{})
+	if err != nil {
+		return 0, err
+	}
+	return c.ValueSize(data)
+}
+
+// getTypeBytesLength returns reflect.Type's length in bytes
+func getTypeBytesLength(t reflect.Type, opts Options) (int, error) {
+	c, err := CompileWithOptions(t, opts)
+	if err != nil {
+		return 0, err
+	}
+	size, ok := c.Size()
+	if !ok {
+		return 0, errors.Errorf("type %v has variable length", t)
+	}
+	return size, nil
+}
+
+// variableCodec is implemented by codecs, which size can be variable
+type variableCodec interface {
+	codec
+	// valueSize returns length of bytes representation of v
+	valueSize(v reflect.Value) (int, error)
+}
+
+// valueSize returns length of bytes representation of v, encoded with c
+func valueSize(c codec, v reflect.Value) (int, error) {
+	if size := c.size(); size >= 0 {
+		return size, nil
+	}
+	return c.(variableCodec).valueSize(v)
+}
+
+// compiler builds codecs of type and all of it's nested types
+type compiler struct {
+	opts     Options
+	visiting map[reflect.Type]bool
+}
+
+// compileType builds codec of value, which isn't a struct field, so it has no tags
+func (c *compiler) compileType(t reflect.Type) (codec, error) {
+	if isCustomType(t) {
+		return newCustomCodec(t), nil
+	}
+	kind := t.Kind()
+	switch kind {
+	case reflect.Ptr:
+		elem, err := c.compileType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return newPtrCodec(t, elem), nil
+	case reflect.Struct:
+		return c.compileStruct(t)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return numberCodec{kind: kind, length: int(t.Size())}, nil
+	case reflect.Bool:
+		return boolCodec{}, nil
+	case reflect.Int, reflect.Uint:
+		if c.opts.IntSize == 0 {
+			return nil, errors.New("unsupported type: " + kind.String())
+		}
+		return sizedIntCodec{length: c.opts.IntSize}, nil
+	case reflect.Array:
+		elem, err := c.compileType(t.Elem())
+		if err != nil {
+			return nil, errors.Wrap(err, "can't compile array element")
+		}
+		return arrayCodec{length: t.Len(), elem: elem}, nil
+	}
+	return nil, errors.New("unsupported type: " + kind.String())
+}
+
+// compileField builds codec of struct field, relying on it's tag
+func (c *compiler) compileField(t reflect.Type, tag *schema.Tag) (codec, error) {
+	if tag.Const != "" && t.Kind() != reflect.Ptr {
+		return c.compileConst(t, tag)
+	}
+	if isCustomType(t) {
+		return newCustomCodec(t), nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem, err := c.compileField(t.Elem(), tag)
+		if err != nil {
+			return nil, err
+		}
+		return newPtrCodec(t, elem), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if tag.Varint || tag.ZigZag {
+			return varintCodec{zigzag: tag.ZigZag}, nil
+		}
+		if tag.Size != 0 {
+			return sizedIntCodec{length: tag.Size}, nil
+		}
+	case reflect.Bool:
+		return boolCodec{strict: tag.StrictBool}, nil
+	case reflect.Float32, reflect.Float64:
+		if tag.Float16 {
+			return float16Codec{}, nil
+		}
+	case reflect.String:
+		return compileString(tag)
+	case reflect.Slice:
+		if tag.LenPrefix != 0 {
+			elems, err := c.compileElems(t, tag)
+			if err != nil {
+				return nil, err
+			}
+			return prefixedCodec{prefix: tag.LenPrefix, max: tag.Length, elems: elems}, nil
+		}
+		if tag.Length == 0 {
+			return nil, errors.New("need to specify length")
+		}
+		elem, err := c.compileElem(t.Elem(), tag.Elem)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't compile slice element")
+		}
+		return fixedSliceCodec{length: tag.Length, elemType: t.Elem(), elem: elem}, nil
+	case reflect.Array:
+		elem, err := c.compileElem(t.Elem(), tag.Elem)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't compile array element")
+		}
+		return arrayCodec{length: t.Len(), elem: elem}, nil
+	case reflect.Map:
+		if tag.LenPrefix == 0 {
+			return nil, errors.New("need to specify length prefix")
+		}
+		elems, err := c.compileElems(t, tag)
+		if err != nil {
+			return nil, err
+		}
+		return prefixedCodec{prefix: tag.LenPrefix, max: tag.Length, elems: elems}, nil
+	}
+	return c.compileType(t)
+}
+
+// compileString builds codec of string field
+func compileString(tag *schema.Tag) (codec, error) {
+	text := newTextCodec(tag)
+	if tag.LenPrefix != 0 {
+		return prefixedCodec{
+			prefix: tag.LenPrefix,
+			max:    tag.Length,
+			elems:  stringElemsCodec{text: text, inBytes: tag.LengthInBytes},
+		}, nil
+	}
+	if tag.CString && tag.Length == 0 {
+		return cstringCodec{text: text}, nil
+	}
+	if tag.Length == 0 {
+		return nil, errors.New("need to specify length")
+	}
+	result := fixedStringCodec{
+		length:  tag.Length,
+		pad:     tag.Pad,
+		trim:    tag.TrimRight,
+		strict:  tag.Strict,
+		cstring: tag.CString,
+		text:    text,
+	}
+	if text == nil {
+		return result, nil
+	}
+	if !tag.LengthInBytes {
+		result.length *= text.unit
+	}
+	result.padUnit = make([]byte, text.unit)
+	if tag.Pad == 0 {
+		return result, nil
+	}
+	padUnit, _ := text.charset.AppendRune(nil, rune(tag.Pad))
+	if len(padUnit) != text.unit {
+		return nil, errors.Errorf("padding can't be encoded as one code unit of %s", text.name)
+	}
+	result.padUnit = padUnit
+	return result, nil
+}
+
+// newTextCodec returns converter of strings to charset, which is set by tag, or nil
+// Charset is looked up while parsing tags, so it's registered
+func newTextCodec(tag *schema.Tag) *textCodec {
+	if tag.Charset == "" {
+		return nil
+	}
+	charset, _ := LookupCharset(tag.Charset)
+	replace := tag.Replace
+	if replace == "" {
+		replace = schema.ReplaceDefault
+	}
+	return &textCodec{name: tag.Charset, charset: charset, unit: charset.UnitSize(), replace: replace}
+}
+
+// compileElems builds codec of string bytes, slice elements or map entries, which count is stored somewhere else
+func (c *compiler) compileElems(t reflect.Type, tag *schema.Tag) (elemsCodec, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem, err := c.compileElems(t.Elem(), tag)
+		if err != nil {
+			return nil, err
+		}
+		return ptrElemsCodec{elemType: t.Elem(), elem: elem}, nil
+	case reflect.String:
+		return stringElemsCodec{text: newTextCodec(tag), inBytes: tag.LengthInBytes}, nil
+	case reflect.Slice:
+		elem, err := c.compileElem(t.Elem(), tag.Elem)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't compile slice element")
+		}
+		// decoder would append such elements, while count allows, without reading any byte
+		if elem.size() == 0 {
+			return nil, errors.Errorf("length of slice with zero size elements %v can't be stored in bytes", t.Elem())
+		}
+		return sliceElemsCodec{t: t, elem: elem}, nil
+	case reflect.Map:
+		key, err := c.compileElem(t.Key(), tag.Key)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't compile map key")
+		}
+		value, err := c.compileElem(t.Elem(), tag.Elem)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't compile map value")
+		}
+		result := mapElemsCodec{t: t, key: key, value: value, sorted: tag.Sorted}
+		if result.entrySize() == 0 {
+			return nil, errors.Errorf("length of map with zero size entries %v can't be stored in bytes", t)
+		}
+		return result, nil
+	}
+	return nil, errors.New("unsupported type: " + t.Kind().String())
+}
+
+// compileElem builds codec of array/slice element, map key or value with options, pushed down to it by tag
+func (c *compiler) compileElem(t reflect.Type, tag *schema.Tag) (codec, error) {
+	if tag == nil {
+		return c.compileType(t)
+	}
+	return c.compileField(t, tag)
+}
+
+func (c *compiler) compileStruct(t reflect.Type) (codec, error) {
+	if c.visiting[t] {
+		return nil, errors.Errorf("recursive type %v is not supported", t)
+	}
+	c.visiting[t] = true
+	defer delete(c.visiting, t)
+
+	info, err := getStructFields(t)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %v struct tags error", t.Name())
+	}
+	result := &structCodec{t: t, length: 0, align: 1, widest: 1}
+	layout := info.parsed.Layout()
+	for i, ft := range info.fields {
+		tag := info.tags[i]
+		if tag.Skip {
+			continue
+		}
+		if isUnexported(ft) {
+			if c.opts.SkipUnexported {
+				skip, err := info.parsed.SkipUnexported(i)
+				if err != nil {
+					return nil, errors.Wrapf(err, "%v.%v field error", t.Name(), ft.Name)
+				}
+				if skip {
+					continue
+				}
+			}
+			// zero size fields, like bit order marker, are never accessed
+			result.unexported = result.unexported || ft.Type.Size() != 0
+		}
+		field := structFieldCodec{index: ft.Index, name: ft.Name, endian: tag.Endian}
+		var err error
+		switch {
+		case tag.Bits != 0:
+			if tag.BitGroupLength == 0 {
+				if tag.Align != 0 || tag.Reserved != 0 || tag.HasOffset {
+					return nil, errors.Errorf("%v.%v field error: align, reserved and offset can be set only on the first bit field of group", t.Name(), ft.Name)
+				}
+				continue
+			}
+			field.codec = newBitGroupCodec(info, i)
+			field.whole = true
+		case len(tag.LengthOf) > 0:
+			field.codec, err = c.compileLengthField(info, i)
+			field.whole = true
+		case tag.LengthFrom != "":
+			field.codec, err = c.compileLengthFromField(info, i)
+			field.whole = true
+		default:
+			field.codec, err = c.compileField(ft.Type, tag)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "%v.%v field error", t.Name(), ft.Name)
+		}
+		// codecs of whole struct, like bit groups, skip "_" fields themselves
+		if ft.Name == "_" && !field.whole {
+			field.blank = ft.Type
+		}
+		field.reserved, field.fill, field.strictReserved = tag.Reserved, tag.Fill, tag.StrictReserved
+		field.offset, field.hasOffset = tag.Offset, tag.HasOffset
+		field.align = tag.Align
+		align := field.align
+		if align == 0 && !field.hasOffset {
+			align = naturalAlign(field.codec)
+		}
+		if align > result.widest {
+			result.widest = align
+		}
+		if layout == schema.LayoutNatural {
+			field.align = align
+			if align > result.align {
+				result.align = align
+			}
+		}
+		if result.length >= 0 && field.hasOffset {
+			// overlapping of fields after variable length fields is checked while encoding and decoding
+			if _, err := offsetPadding(result.length, field.offset); err != nil {
+				return nil, errors.Wrapf(err, "%v.%v field error", t.Name(), ft.Name)
+			}
+			result.length = field.offset
+		}
+		if result.length >= 0 {
+			if size := field.codec.size(); size >= 0 {
+				result.length += field.reserved
+				result.length += alignPadding(result.length, field.align) + size
+			} else {
+				result.length = -1
+			}
+		}
+		result.fields = append(result.fields, field)
+	}
+	if result.length >= 0 {
+		result.length += alignPadding(result.length, result.align)
+	}
+	return result, nil
+}
+
+func (c *compiler) compileLengthField(info *structFields, i int) (codec, error) {
+	ft := info.fields[i]
+	valueType := indirectType(ft.Type)
+	value, err := c.compileField(valueType, info.tags[i])
+	if err != nil {
+		return nil, err
+	}
+	field, err := c.compileField(ft.Type, info.tags[i])
+	if err != nil {
+		return nil, err
+	}
+	result := &lengthFieldCodec{index: ft.Index, valueType: valueType, value: value, field: field}
+	for _, j := range info.tags[i].LengthOf {
+		count, err := c.compileElems(info.fields[j].Type, info.tags[j])
+		if err != nil {
+			return nil, err
+		}
+		result.dependents = append(result.dependents, info.fields[j].Index)
+		result.names = append(result.names, info.fields[j].Name)
+		result.counts = append(result.counts, count)
+	}
+	return result, nil
+}
+
+func (c *compiler) compileLengthFromField(info *structFields, i int) (codec, error) {
+	tag := info.tags[i]
+	elems, err := c.compileElems(info.fields[i].Type, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &lengthFromCodec{
+		index:    info.fields[i].Index,
+		from:     info.fields[tag.LengthFromIndex].Index,
+		fromName: tag.LengthFrom,
+		autoFill: tag.AutoFill,
+		max:      tag.Length,
+		elems:    elems,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCompile(t *testing.T) {
+	Convey("Test Compile", t, func() {
+		type Struct struct {
+			A uint16
+			B string `d2b:"length:3"`
+			C []int8 `d2b:"lenprefix:uint8"`
+		}
+		Convey("Should return the same codec for the same type", func() {
+			first, err := Compile(reflect.TypeOf(Struct{}))
+			So(err, ShouldBeNil)
+			second, err := Compile(reflect.TypeOf(Struct{}))
+			So(err, ShouldBeNil)
+			So(first, ShouldEqual, second)
+		})
+		Convey("Should encode and decode values and pointers to them", func() {
+			c, err := Compile(reflect.TypeOf(Struct{}))
+			So(err, ShouldBeNil)
+			data := Struct{A: 1, B: "ab", C: []int8{-1, 2}}
+			bytes, err := c.Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{1, 0, 'a', 'b', 0, 2, 255, 2})
+			fromPtr, err := c.Encode(&data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(fromPtr, ShouldResemble, bytes)
+			var result Struct
+			So(c.Decode(bytes, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+		Convey("Should return size of fixed length types", func() {
+			type Fixed struct {
+				A uint16
+				B [3]int32
+				C *bool
+			}
+			c, err := Compile(reflect.TypeOf(Fixed{}))
+			So(err, ShouldBeNil)
+			size, ok := c.Size()
+			So(ok, ShouldBeTrue)
+			So(size, ShouldEqual, 15)
+			c, err = Compile(reflect.TypeOf(Struct{}))
+			So(err, ShouldBeNil)
+			_, ok = c.Size()
+			So(ok, ShouldBeFalse)
+		})
+		Convey("Should return error for values of another type", func() {
+			c, err := Compile(reflect.TypeOf(Struct{}))
+			So(err, ShouldBeNil)
+			_, err = c.Encode(int8(1), binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			_, err = c.Encode(nil, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			var result int8
+			So(c.Decode([]byte{1}, binary.LittleEndian, &result), ShouldNotBeNil)
+			So(c.Decode([]byte{1}, binary.LittleEndian, Struct{}), ShouldNotBeNil)
+			So(c.Decode([]byte{1}, binary.LittleEndian, nil), ShouldNotBeNil)
+		})
+		Convey("Should return error for unsupported and recursive types", func() {
+			type Node struct {
+				Value uint8
+				Next  *Node
+			}
+			_, err := Compile(reflect.TypeOf(Node{}))
+			So(err, ShouldNotBeNil)
+			_, err = Compile(reflect.TypeOf([]int8{}))
+			So(err, ShouldNotBeNil)
+			_, err = Compile(nil)
+			So(err, ShouldNotBeNil)
+			_, err = CompileWithOptions(reflect.TypeOf(int(0)), Options{IntSize: 3})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,135 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gopkg.in/saturn4er/go-data-to-bytes.v2/internal/schema"
+)
+
+// constCodec always encodes the constant instead of field's value and checks, that decoded value is equal to it
+type constCodec struct {
+	// value encodes and decodes values with the type of the constant
+	value    codec
+	expected reflect.Value
+}
+
+func (c constCodec) size() int {
+	return c.value.size()
+}
+
+func (c constCodec) valueSize(v reflect.Value) (int, error) {
+	return valueSize(c.value, c.expected)
+}
+
+func (c constCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	return c.value.encode(buf, c.expected, endian)
+}
+
+func (c constCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	actual := reflect.New(c.expected.Type()).Elem()
+	err := c.value.decode(in, actual, endian)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(actual.Interface(), c.expected.Interface()) {
+		return &ConstMismatchError{Expected: c.expected.Interface(), Actual: actual.Interface()}
+	}
+	v.Set(actual)
+	return nil
+}
+
+// rawStringCodec encodes string as it's bytes, which take exactly length bytes. It's used for string constants
+type rawStringCodec struct {
+	length int
+}
+
+func (c rawStringCodec) size() int {
+	return c.length
+}
+
+func (c rawStringCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	if v.Len() != c.length {
+		return nil, errors.Errorf("string length %d doesn't match %d", v.Len(), c.length)
+	}
+	return append(buf, v.String()...), nil
+}
+
+func (c rawStringCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	bytes, err := in.next(c.length)
+	if err != nil {
+		return err
+	}
+	setStringBytes(v, bytes)
+	return nil
+}
+
+// compileConst builds codec of field with const option
+func (c *compiler) compileConst(t reflect.Type, tag *schema.Tag) (codec, error) {
+	expected, err := parseConst(tag.Const, t)
+	if err != nil {
+		return nil, err
+	}
+	if t.Kind() == reflect.String {
+		return constCodec{value: rawStringCodec{length: expected.Len()}, expected: expected}, nil
+	}
+	plain := *tag
+	plain.Const = ""
+	if t.Kind() == reflect.Slice {
+		plain.Length = expected.Len()
+	}
+	value, err := c.compileField(t, &plain)
+	if err != nil {
+		return nil, err
+	}
+	return constCodec{value: value, expected: expected}, nil
+}
+
+// parseConst parses value of const option for value with type t
+// Integers are written as Go literals, e.g. 0xCAFEBABE, strings and bytes - as quoted Go strings, e.g. "\x89PNG"
+func parseConst(s string, t reflect.Type) (reflect.Value, error) {
+	t = indirectType(t)
+	result := reflect.New(t).Elem()
+	switch k := t.Kind(); {
+	case schema.IsSignedKind(k):
+		n, err := strconv.ParseInt(s, 0, t.Bits())
+		if err != nil {
+			return reflect.Value{}, errors.Wrap(err, "invalid constant")
+		}
+		result.SetInt(n)
+	case schema.IsIntegerKind(k):
+		n, err := strconv.ParseUint(s, 0, t.Bits())
+		if err != nil {
+			return reflect.Value{}, errors.Wrap(err, "invalid constant")
+		}
+		result.SetUint(n)
+	case k == reflect.String || isBytesType(t):
+		b, err := strconv.Unquote(s)
+		if err != nil {
+			return reflect.Value{}, errors.Errorf("constant %s should be quoted", s)
+		}
+		switch k {
+		case reflect.String:
+			result.SetString(b)
+		case reflect.Slice:
+			result.Set(reflect.MakeSlice(t, len(b), len(b)))
+			reflect.Copy(result, reflect.ValueOf([]byte(b)))
+		case reflect.Array:
+			if len(b) != t.Len() {
+				return reflect.Value{}, errors.Errorf("constant has %d bytes, but %v has %d", len(b), t, t.Len())
+			}
+			reflect.Copy(result, reflect.ValueOf([]byte(b)))
+		}
+	default:
+		return reflect.Value{}, errors.Errorf("const can't be used with %v", k)
+	}
+	return result, nil
+}
+
+// isBytesType returns true if t is a slice or an array of bytes
+func isBytesType(t reflect.Type) bool {
+	k := t.Kind()
+	return (k == reflect.Slice || k == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
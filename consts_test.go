@@ -0,0 +1,107 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testMagicHeader struct {
+	Magic   uint32 `d2b:"const:0xCAFEBABE"`
+	Version uint16
+}
+
+func TestConst(t *testing.T) {
+	Convey("Test constant fields", t, func() {
+		Convey("Should always encode constants", func() {
+			type Struct struct {
+				A [4]byte `d2b:"const:\"\\x89PNG\""`
+				B uint32  `d2b:"const:0xCAFEBABE,endian:big"`
+				C string  `d2b:"const:\"a,b\""`
+				D []byte  `d2b:"const:\"\\r\\n\\x00\""`
+				E *int8   `d2b:"const:-2"`
+				F uint8
+			}
+			size, err := SizeOf(reflect.TypeOf(Struct{}))
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, 16)
+			expected := []byte{0x89, 'P', 'N', 'G', 0xCA, 0xFE, 0xBA, 0xBE, 'a', ',', 'b', '\r', '\n', 0, 0xFE, 7}
+			for _, data := range []Struct{{F: 7}, {A: [4]byte{1, 2, 3, 4}, B: 5, C: "c", D: []byte{6}, F: 7}} {
+				encoded, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldBeNil)
+				So(encoded, ShouldResemble, expected)
+			}
+
+			var result Struct
+			So(Decode(expected, binary.LittleEndian, &result), ShouldBeNil)
+			e := int8(-2)
+			So(result, ShouldResemble, Struct{
+				A: [4]byte{0x89, 'P', 'N', 'G'},
+				B: 0xCAFEBABE,
+				C: "a,b",
+				D: []byte{'\r', '\n', 0},
+				E: &e,
+				F: 7,
+			})
+		})
+		Convey("Should return ConstMismatchError with path of field", func() {
+			type Struct struct {
+				Headers [2]testMagicHeader
+			}
+			encoded, err := Encode(Struct{}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded[:4], ShouldResemble, []byte{0xBE, 0xBA, 0xFE, 0xCA})
+
+			encoded[6] = 1
+			var result Struct
+			err = Decode(encoded, binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ConstMismatchError{
+				Path:     "Headers[1].Magic",
+				Expected: uint32(0xCAFEBABE),
+				Actual:   uint32(0xCAFEBA01),
+			})
+			So(err.Error(), ShouldContainSubstring, "Headers[1].Magic")
+		})
+		Convey("Should compare constant strings and bytes", func() {
+			type Struct struct {
+				A [4]byte `d2b:"const:\"\\x89PNG\""`
+				C string  `d2b:"const:\"ok\""`
+			}
+			var result Struct
+			err := Decode([]byte{0x89, 'P', 'N', 'G', 'n', 'o'}, binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ConstMismatchError{Path: "C", Expected: "ok", Actual: "no"})
+			err = Decode([]byte{0x89, 'P', 'N', 'G', 'o'}, binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "C", Needed: 2, Available: 1})
+		})
+		Convey("Should return error if const options are invalid", func() {
+			type Overflow struct {
+				A uint8 `d2b:"const:256"`
+			}
+			type NotQuoted struct {
+				A string `d2b:"const:PNG"`
+			}
+			type WrongLength struct {
+				A [2]byte `d2b:"const:\"abc\""`
+			}
+			type Float struct {
+				A float32 `d2b:"const:1"`
+			}
+			type WithLength struct {
+				A string `d2b:"const:\"ab\",length:2"`
+			}
+			type WithBits struct {
+				A uint8 `d2b:"const:1,bits:8"`
+			}
+			type AutoFilled struct {
+				N uint8  `d2b:"const:2"`
+				A []byte `d2b:"lengthfrom:N,autofill"`
+			}
+			for _, data := range []interface{}{Overflow{}, NotQuoted{}, WrongLength{}, Float{}, WithLength{}, WithBits{}, AutoFilled{}} {
+				_, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -59,6 +59,27 @@ func TestDecode(t *testing.T) {
 			So(result, ShouldEqual, uint64(18446744073709551615))
 		})
 
+		Convey("Should decode encoded unsigned integers back", func() {
+			type Struct struct {
+				A uint16
+				B uint32
+				C uint64
+			}
+			for _, endian := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+				for _, data := range []Struct{
+					{A: 0x8000, B: 0x8000, C: 0x8000},
+					{A: 0xFFFF, B: 0x18000, C: 0x80000000},
+					{A: 0xBEEF, B: 0xDEADBEEF, C: 0xDEADBEEFCAFEBABE},
+				} {
+					encoded, err := Encode(data, endian)
+					So(err, ShouldBeNil)
+					var result Struct
+					So(Decode(encoded, endian, &result), ShouldBeNil)
+					So(result, ShouldResemble, data)
+				}
+			}
+		})
+
 		Convey("Should create new value for nil pointers and decode there data", func() {
 			var result *int32
 			err := Decode([]byte{1, 2, 3, 4}, binary.LittleEndian, &result)
@@ -102,12 +123,240 @@ func TestDecode(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(result, ShouldResemble, Struct{
 				A:     &[]int32{67305985, 67305985},
-				B:     &[]uint32{513, 513},
+				B:     &[]uint32{67305985, 67305985},
 				C:     [2]int32{67305985, 67305985},
 				Test:  "Hell",
 				Test1: "Hell",
 			})
 		})
+		Convey("Should return ShortBufferError if there's not enough bytes", func() {
+			var result int32
+			err := Decode([]byte{1, 2}, binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Needed: 4, Available: 2})
+			So(result, ShouldEqual, 0)
+		})
+		Convey("Should return ShortBufferError with field path if there's not enough bytes for struct field", func() {
+			type Item struct {
+				ID   int16
+				Name string `d2b:"length:4"`
+			}
+			type Struct struct {
+				A     int8
+				Items []Item `d2b:"length:2"`
+			}
+			var result Struct
+			err := Decode([]byte{
+				1,
+				1, 0, 'a', 'b', 'c', 'd',
+				2, 0, 'a', 'b',
+			}, binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "Items[1].Name", Needed: 4, Available: 2})
+		})
+		Convey("Should return ShortBufferError with index path if there's not enough bytes for array element", func() {
+			var result [3]uint16
+			err := Decode([]byte{1, 0, 2, 0, 3}, binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "[2]", Needed: 2, Available: 1})
+		})
+		Convey("Should return ShortBufferError on empty input for every supported type", func() {
+			values := []interface{}{new(int8), new(int16), new(int32), new(int64), new(uint8), new(uint16),
+				new(uint32), new(uint64), new(float32), new(float64), new([2]int8)}
+			for _, value := range values {
+				err := Decode([]byte{}, binary.LittleEndian, value)
+				_, ok := err.(*ShortBufferError)
+				So(ok, ShouldBeTrue)
+			}
+		})
+		Convey("Should decode length prefixed strings and slices", func() {
+			type Item struct {
+				ID   uint8
+				Name string `d2b:"lenprefix:uint8"`
+			}
+			type Struct struct {
+				A string   `d2b:"lenprefix:uint8"`
+				B []uint16 `d2b:"lenprefix:uint16"`
+				C []Item   `d2b:"lenprefix:uint8"`
+			}
+			result := Struct{B: []uint16{5, 5, 5}}
+			err := Decode([]byte{
+				5, 'h', 'e', 0, 'l', 'o',
+				0, 2, 0, 1, 0, 2,
+				2, 1, 1, 'a', 2, 0,
+			}, binary.BigEndian, &result)
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, Struct{
+				A: "he\x00lo",
+				B: []uint16{1, 2},
+				C: []Item{{ID: 1, Name: "a"}, {ID: 2, Name: ""}},
+			})
+		})
+		Convey("Should return ShortBufferError if length prefixed field is truncated", func() {
+			type Struct struct {
+				A []uint32 `d2b:"lenprefix:uint16"`
+			}
+			var result Struct
+			err := Decode([]byte{255, 255, 1, 2}, binary.BigEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "A", Needed: 262140, Available: 2})
+			So(result, ShouldResemble, Struct{})
+
+			err = Decode([]byte{0}, binary.BigEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "A", Needed: 2, Available: 1})
+		})
+		Convey("Should return error if length prefix is greater than max length", func() {
+			type Struct struct {
+				A string `d2b:"lenprefix:uint8,length:2"`
+			}
+			var result Struct
+			err := Decode([]byte{3, 'a', 'b', 'c'}, binary.BigEndian, &result)
+			So(err, ShouldNotBeNil)
+			So(result, ShouldResemble, Struct{})
+		})
+		Convey("Should decode fields with length from another field", func() {
+			type Struct struct {
+				Count   uint8
+				Size    *int16
+				Records []int16 `d2b:"lengthfrom:Count"`
+				Text    string  `d2b:"lengthfrom:Size"`
+			}
+			result := Struct{Records: []int16{5, 5, 5}}
+			err := Decode([]byte{2, 0, 3, 0, 1, 0, 2, 'a', 'b', 'c', 'd'}, binary.BigEndian, &result)
+			So(err, ShouldBeNil)
+			So(*result.Size, ShouldEqual, 3)
+			result.Size = nil
+			So(result, ShouldResemble, Struct{Count: 2, Records: []int16{1, 2}, Text: "abc"})
+		})
+		Convey("Should return error if length from another field is invalid", func() {
+			type Struct struct {
+				Count   int8
+				Records []int16 `d2b:"lengthfrom:Count,length:2"`
+			}
+			var result Struct
+			err := Decode([]byte{3, 0, 1, 0, 2, 0, 3}, binary.BigEndian, &result)
+			So(err, ShouldNotBeNil)
+			err = Decode([]byte{255}, binary.BigEndian, &result)
+			So(err, ShouldNotBeNil)
+			err = Decode([]byte{2, 0, 1, 0}, binary.BigEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "Records", Needed: 4, Available: 3})
+		})
+		Convey("Should return error if elements of value with variable length have zero size", func() {
+			type Prefixed struct {
+				A []struct{} `d2b:"lenprefix:uint32"`
+			}
+			type LengthFrom struct {
+				Count uint32
+				A     []struct{} `d2b:"lengthfrom:Count"`
+			}
+			type Map struct {
+				A map[struct{}]struct{} `d2b:"lenprefix:uint32"`
+			}
+			for _, result := range []interface{}{&Prefixed{}, &LengthFrom{}, &Map{}} {
+				err := Decode([]byte{0xFF, 0xFF, 0xFF, 0x7F}, binary.LittleEndian, result)
+				So(err, ShouldNotBeNil)
+			}
+		})
+		Convey("Should use byte order from field tag for field and it's nested values", func() {
+			type Payload struct {
+				A uint16
+				B uint16 `d2b:"endian:big"`
+			}
+			type Frame struct {
+				Length  uint16    `d2b:"endian:big"`
+				Payload *Payload  `d2b:"endian:little"`
+				Array   [2]uint16 `d2b:"endian:little"`
+				Slice   []uint16  `d2b:"lengthfrom:Length,endian:little"`
+				Tail    uint16
+			}
+			var result Frame
+			err := Decode([]byte{
+				0, 1,
+				2, 0, 0, 3,
+				4, 0, 5, 0,
+				6, 0,
+				0, 7,
+			}, binary.BigEndian, &result)
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, Frame{Length: 1, Payload: &Payload{A: 2, B: 3}, Array: [2]uint16{4, 5}, Slice: []uint16{6}, Tail: 7})
+		})
+		Convey("Should decode bool", func() {
+			type Struct struct {
+				A bool
+				B *bool
+				C [3]bool
+				D bool `d2b:"bool:strict"`
+			}
+			var result Struct
+			err := Decode([]byte{1, 0, 0, 2, 255, 1}, binary.LittleEndian, &result)
+			So(err, ShouldBeNil)
+			So(result.A, ShouldBeTrue)
+			So(*result.B, ShouldBeFalse)
+			So(result.C, ShouldResemble, [3]bool{false, true, true})
+			So(result.D, ShouldBeTrue)
+
+			bytes, err := Encode(result, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{1, 0, 0, 1, 1, 1})
+		})
+		Convey("Should return error if strict bool is not 0 or 1", func() {
+			type Struct struct {
+				A bool `d2b:"bool:strict"`
+			}
+			var result Struct
+			err := Decode([]byte{2}, binary.LittleEndian, &result)
+			So(err, ShouldNotBeNil)
+			So(result, ShouldResemble, Struct{})
+			err = Decode([]byte{}, binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "A", Needed: 1, Available: 0})
+
+			type BadTag struct {
+				A uint8 `d2b:"bool:strict"`
+			}
+			err = Decode([]byte{1}, binary.LittleEndian, &BadTag{})
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should decode int and uint fields with size tag", func() {
+			type Struct struct {
+				A int  `d2b:"size:1"`
+				B uint `d2b:"size:2"`
+				C *int `d2b:"size:4"`
+			}
+			var result Struct
+			err := Decode([]byte{
+				255,
+				255, 255,
+				255, 255, 255, 254,
+			}, binary.BigEndian, &result)
+			So(err, ShouldBeNil)
+			So(result.A, ShouldEqual, -1)
+			So(result.B, ShouldEqual, 65535)
+			So(*result.C, ShouldEqual, -2)
+
+			err = Decode([]byte{1, 2, 3, 4}, binary.BigEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "C", Needed: 4, Available: 1})
+		})
+		Convey("Should decode null-terminated strings", func() {
+			type Struct struct {
+				A string `d2b:"cstring"`
+				B string `d2b:"cstring"`
+				C string `d2b:"length:4,cstring"`
+			}
+			var result Struct
+			err := Decode([]byte{'h', 'i', 0, 0, 'a', 'b', 0, 'c'}, binary.LittleEndian, &result)
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, Struct{A: "hi", B: "", C: "ab"})
+
+			err = Decode([]byte{'h', 'i'}, binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "A", Needed: 3, Available: 2})
+		})
+		Convey("Should keep or trim padding of fixed length strings", func() {
+			type Struct struct {
+				A string `d2b:"length:4,pad:space"`
+				B string `d2b:"length:4,pad:space,trim:right"`
+				C string `d2b:"length:4,trim:right"`
+			}
+			var result Struct
+			err := Decode([]byte{'a', ' ', ' ', ' ', ' ', 'b', ' ', ' ', 'c', 0, 'd', 0}, binary.LittleEndian, &result)
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, Struct{A: "a   ", B: " b", C: "c\x00d"})
+		})
 		Convey("Should return error if trying to decode unsupported type", func() {
 			var result int
 			err := Decode([]byte{1, 2, 3, 4}, binary.LittleEndian, &result)
@@ -0,0 +1,85 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"io"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Decoder reads and decodes values from an input stream
+type Decoder struct {
+	r      io.Reader
+	endian binary.ByteOrder
+	opts   Options
+	// in keeps bytes of the last value, so next values reuse it's buffer
+	in input
+	// err is the error of reading the stream, which stopped decoding
+	err error
+}
+
+// NewDecoder returns a new decoder that reads from r
+func NewDecoder(r io.Reader, endian binary.ByteOrder) *Decoder {
+	return &Decoder{r: r, endian: endian}
+}
+
+// Decode reads exactly as many bytes as data type needs from the stream and decodes them to data
+// Values with variable length are read in parts, when their fields need more bytes
+// Returns io.EOF if stream is ended before first byte and io.ErrUnexpectedEOF if it's ended in the middle of value
+func (d *Decoder) Decode(data interface{}) error {
+	t := reflect.TypeOf(data)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return errors.New("data should be pointer")
+	}
+	if reflect.ValueOf(data).IsNil() {
+		return errors.New("can't decode to nil pointer")
+	}
+	c, err := CompileWithOptions(t.Elem(), d.opts)
+	if err != nil {
+		return err
+	}
+	d.err = nil
+	d.in = input{buf: d.in.buf[:0], stream: d}
+	if length, ok := c.Size(); ok {
+		// values with fixed size are read at once
+		if err := d.in.need(length); err != nil {
+			return err
+		}
+	}
+	err = c.decode(&d.in, d.endian, data)
+	if d.err != nil {
+		return d.err
+	}
+	return err
+}
+
+// SetOptions sets options, used by all next Decode calls
+func (d *Decoder) SetOptions(opts Options) {
+	d.opts = opts
+}
+
+// read appends n bytes from the stream to buf
+// Buffer grows while bytes are coming, so broken length can't make it allocate too much memory
+func (d *Decoder) read(buf []byte, n int) ([]byte, error) {
+	for n > 0 {
+		l := len(buf)
+		if l == cap(buf) {
+			buf = append(buf, 0)[:l]
+		}
+		chunk := cap(buf) - l
+		if chunk > n {
+			chunk = n
+		}
+		buf = buf[:l+chunk]
+		if _, err := io.ReadFull(d.r, buf[l:]); err != nil {
+			if err == io.EOF && l > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			d.err = err
+			return nil, err
+		}
+		n -= chunk
+	}
+	return buf, nil
+}
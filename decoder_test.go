@@ -0,0 +1,143 @@
+package d2b
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testCountedByte is a byte, which counts how many times it's decoded
+type testCountedByte uint8
+
+var testCountedBytes int
+
+func (b testCountedByte) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return []byte{byte(b)}, nil
+}
+
+func (b *testCountedByte) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	testCountedBytes++
+	*b = testCountedByte(data[0])
+	return 1, nil
+}
+
+func (b testCountedByte) SizeD2B() int {
+	return 1
+}
+
+func TestDecoder(t *testing.T) {
+	Convey("Test Decoder", t, func() {
+		type Record struct {
+			ID   uint16
+			Name string `d2b:"length:4"`
+			Data [2]int8
+		}
+		Convey("Should decode successive records from stream", func() {
+			r := bytes.NewReader([]byte{
+				1, 0, 'a', 'b', 0, 0, 1, 2,
+				2, 0, 'c', 'd', 'e', 'f', 3, 4,
+			})
+			d := NewDecoder(r, binary.LittleEndian)
+			var first, second Record
+			So(d.Decode(&first), ShouldBeNil)
+			So(d.Decode(&second), ShouldBeNil)
+			So(first, ShouldResemble, Record{ID: 1, Name: "ab", Data: [2]int8{1, 2}})
+			So(second, ShouldResemble, Record{ID: 2, Name: "cdef", Data: [2]int8{3, 4}})
+			So(d.Decode(&first), ShouldEqual, io.EOF)
+		})
+		Convey("Should decode records with variable length", func() {
+			type Message struct {
+				Type uint8
+				Body []uint16 `d2b:"lenprefix:uint8"`
+				Text string   `d2b:"lenprefix:uint8"`
+			}
+			r := bytes.NewReader([]byte{
+				1, 2, 1, 0, 2, 0, 2, 'h', 'i',
+				2, 0, 0,
+				3,
+			})
+			d := NewDecoder(r, binary.LittleEndian)
+			var first, second Message
+			So(d.Decode(&first), ShouldBeNil)
+			So(first, ShouldResemble, Message{Type: 1, Body: []uint16{1, 2}, Text: "hi"})
+			So(d.Decode(&second), ShouldBeNil)
+			So(second, ShouldResemble, Message{Type: 2, Body: []uint16{}})
+			So(d.Decode(&first), ShouldEqual, io.ErrUnexpectedEOF)
+		})
+		Convey("Should decode null-terminated strings byte by byte", func() {
+			type Struct struct {
+				A string `d2b:"cstring"`
+				B uint8
+			}
+			r := bytes.NewReader([]byte{'a', 'b', 'c', 0, 7, 8})
+			d := NewDecoder(r, binary.LittleEndian)
+			var result Struct
+			So(d.Decode(&result), ShouldBeNil)
+			So(result, ShouldResemble, Struct{A: "abc", B: 7})
+			So(r.Len(), ShouldEqual, 1)
+		})
+		Convey("Should decode null-terminated strings with charset by code units", func() {
+			type Struct struct {
+				A string `d2b:"cstring,charset:utf16le"`
+				B uint8
+			}
+			r := bytes.NewReader([]byte{'a', 0, 0, 1, 0, 0, 7, 8})
+			d := NewDecoder(r, binary.LittleEndian)
+			var result Struct
+			So(d.Decode(&result), ShouldBeNil)
+			So(result, ShouldResemble, Struct{A: "a\u0100", B: 7})
+			So(r.Len(), ShouldEqual, 1)
+		})
+		Convey("Should decode every field of variable length value once", func() {
+			type Item struct {
+				ID   testCountedByte
+				Name string `d2b:"lenprefix:uint8"`
+			}
+			type Struct struct {
+				Items []Item `d2b:"lenprefix:uint16"`
+			}
+			data := Struct{Items: make([]Item, 100)}
+			for i := range data.Items {
+				data.Items[i] = Item{ID: testCountedByte(i), Name: "item"}
+			}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+
+			testCountedBytes = 0
+			var result Struct
+			So(NewDecoder(bytes.NewReader(encoded), binary.LittleEndian).Decode(&result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+			So(testCountedBytes, ShouldEqual, 100)
+		})
+		Convey("Should not read more bytes than value needs", func() {
+			r := bytes.NewReader([]byte{1, 2, 3, 4, 5})
+			d := NewDecoder(r, binary.LittleEndian)
+			var result int32
+			So(d.Decode(&result), ShouldBeNil)
+			So(result, ShouldEqual, 67305985)
+			So(r.Len(), ShouldEqual, 1)
+		})
+		Convey("Should return io.ErrUnexpectedEOF if stream ends in the middle of value", func() {
+			d := NewDecoder(bytes.NewReader([]byte{1, 0, 'a'}), binary.LittleEndian)
+			var result Record
+			So(d.Decode(&result), ShouldEqual, io.ErrUnexpectedEOF)
+		})
+		Convey("Should return error if value length can't be detected", func() {
+			type Struct struct {
+				A string
+			}
+			d := NewDecoder(bytes.NewReader([]byte{1, 2, 3, 4}), binary.LittleEndian)
+			var result Struct
+			So(d.Decode(&result), ShouldNotBeNil)
+		})
+		Convey("Should return error if trying to decode to non-pointer or nil pointer", func() {
+			d := NewDecoder(bytes.NewReader([]byte{1, 2, 3, 4}), binary.LittleEndian)
+			var result *int32
+			So(d.Decode(result), ShouldNotBeNil)
+			So(d.Decode(int32(0)), ShouldNotBeNil)
+		})
+	})
+}
@@ -2,6 +2,8 @@ package d2b
 
 import (
 	"encoding/binary"
+	"io"
+	"reflect"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -59,6 +61,291 @@ func TestEncode(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(bytes, ShouldHaveLength, 83)
 		})
+		Convey("Should encode length prefixed strings and slices", func() {
+			type Struct struct {
+				A string   `d2b:"lenprefix:uint8"`
+				B []uint16 `d2b:"lenprefix:uint16"`
+				C *string  `d2b:"lenprefix:uint32"`
+				D []int8   `d2b:"lenprefix:uint8,length:2"`
+			}
+			bytes, err := Encode(Struct{A: "hello", B: []uint16{1, 2}, D: []int8{-1}}, binary.BigEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{
+				5, 'h', 'e', 'l', 'l', 'o',
+				0, 2, 0, 1, 0, 2,
+				0, 0, 0, 0,
+				1, 255,
+			})
+		})
+		Convey("Should return error if length prefixed field is too long", func() {
+			type Struct struct {
+				A []int8 `d2b:"lenprefix:uint8,length:2"`
+			}
+			bytes, err := Encode(Struct{A: []int8{1, 2, 3}}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			So(bytes, ShouldBeEmpty)
+
+			type Struct2 struct {
+				A []int8 `d2b:"lenprefix:uint8"`
+			}
+			bytes, err = Encode(Struct2{A: make([]int8, 256)}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			So(bytes, ShouldBeEmpty)
+		})
+		Convey("Should return error if length prefix tag is invalid", func() {
+			type Struct struct {
+				A []int8 `d2b:"lenprefix:int128"`
+			}
+			_, err := Encode(Struct{}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			type Struct2 struct {
+				A int8 `d2b:"lenprefix:uint8"`
+			}
+			_, err = Encode(Struct2{}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should encode fields with length from another field", func() {
+			type Struct struct {
+				Count   uint8
+				Name    string  `d2b:"length:2"`
+				Records []int16 `d2b:"lengthfrom:Count"`
+			}
+			bytes, err := Encode(Struct{Count: 2, Name: "ab", Records: []int16{1, 2}}, binary.BigEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{2, 'a', 'b', 0, 1, 0, 2})
+
+			_, err = Encode(Struct{Count: 3, Records: []int16{1, 2}}, binary.BigEndian)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should fill length field with autofill option", func() {
+			type Struct struct {
+				Count  *int16
+				Keys   []uint8 `d2b:"lengthfrom:Count,autofill"`
+				Values []uint8 `d2b:"lengthfrom:Count,autofill"`
+				Size   uint8
+				Text   *string `d2b:"lengthfrom:Size,autofill,length:4"`
+			}
+			text := "abc"
+			bytes, err := Encode(Struct{Keys: []uint8{1, 2}, Values: []uint8{3, 4}, Text: &text}, binary.BigEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{0, 2, 1, 2, 3, 4, 3, 'a', 'b', 'c'})
+
+			_, err = Encode(Struct{Keys: []uint8{1, 2}, Values: []uint8{3}}, binary.BigEndian)
+			So(err, ShouldNotBeNil)
+			text = "hello"
+			_, err = Encode(Struct{Text: &text}, binary.BigEndian)
+			So(err, ShouldNotBeNil)
+			_, err = Encode(Struct{Keys: make([]uint8, 1<<15), Values: make([]uint8, 1<<15)}, binary.BigEndian)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should return error if lengthfrom tag is invalid", func() {
+			type Missing struct {
+				A []int8 `d2b:"lengthfrom:Count"`
+			}
+			type After struct {
+				A     []int8 `d2b:"lengthfrom:Count"`
+				Count uint8
+			}
+			type NotInteger struct {
+				Count string `d2b:"length:1"`
+				A     []int8 `d2b:"lengthfrom:Count"`
+			}
+			type NotSlice struct {
+				Count uint8
+				A     int8 `d2b:"lengthfrom:Count"`
+			}
+			type WithPrefix struct {
+				Count uint8
+				A     []int8 `d2b:"lengthfrom:Count,lenprefix:uint8"`
+			}
+			type WithoutLengthFrom struct {
+				A []int8 `d2b:"length:2,autofill"`
+			}
+			values := []interface{}{Missing{}, After{}, NotInteger{}, NotSlice{}, WithPrefix{}, WithoutLengthFrom{}}
+			for _, value := range values {
+				bytes, err := Encode(value, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+				So(bytes, ShouldBeEmpty)
+			}
+		})
+		Convey("Should use byte order from field tag for field and it's nested values", func() {
+			type Payload struct {
+				A uint16
+				B uint16 `d2b:"endian:big"`
+			}
+			type Frame struct {
+				Length  uint16    `d2b:"endian:big"`
+				Payload Payload   `d2b:"endian:little"`
+				Array   [2]uint16 `d2b:"endian:little"`
+				Slice   []uint16  `d2b:"lenprefix:uint16,endian:little"`
+				Tail    uint16
+			}
+			data := Frame{Length: 1, Payload: Payload{A: 2, B: 3}, Array: [2]uint16{4, 5}, Slice: []uint16{6}, Tail: 7}
+			bytes, err := Encode(data, binary.BigEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{
+				0, 1,
+				2, 0, 0, 3,
+				4, 0, 5, 0,
+				1, 0, 6, 0,
+				0, 7,
+			})
+			bytes, err = Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{
+				0, 1,
+				2, 0, 0, 3,
+				4, 0, 5, 0,
+				1, 0, 6, 0,
+				7, 0,
+			})
+		})
+		Convey("Should return error if endian tag is invalid", func() {
+			type Struct struct {
+				A uint16 `d2b:"endian:middle"`
+			}
+			bytes, err := Encode(Struct{}, binary.BigEndian)
+			So(err, ShouldNotBeNil)
+			So(bytes, ShouldBeEmpty)
+		})
+		Convey("Should encode floats", func() {
+			type Struct struct {
+				A float32
+				B *float64
+				C [2]float32
+				D float32  `d2b:"float16"`
+				E *float64 `d2b:"float16"`
+			}
+			b := 5.447603722011605e-270
+			bytes, err := Encode(Struct{A: 1.5399896e-36, B: &b, C: [2]float32{1, -2}, D: 1, E: nil}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{
+				1, 2, 3, 4,
+				1, 2, 3, 4, 5, 6, 7, 8,
+				0, 0, 0x80, 0x3F, 0, 0, 0, 0xC0,
+				0, 0x3C,
+				0, 0,
+			})
+			var result Struct
+			So(Decode(bytes, binary.LittleEndian, &result), ShouldBeNil)
+			So(result.A, ShouldEqual, 1.5399896e-36)
+			So(*result.B, ShouldEqual, b)
+			So(result.C, ShouldResemble, [2]float32{1, -2})
+			So(result.D, ShouldEqual, 1)
+			So(*result.E, ShouldEqual, 0)
+		})
+		Convey("Should return error if float16 is used with non float field", func() {
+			type Struct struct {
+				A int32 `d2b:"float16"`
+			}
+			bytes, err := Encode(Struct{}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			So(bytes, ShouldBeEmpty)
+		})
+		Convey("Should encode nil pointer to struct with bool field", func() {
+			type StructWithBool struct {
+				A bool
+				B int16
+			}
+			var data *StructWithBool
+			bytes, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{0, 0, 0})
+			length, err := getTypeBytesLength(reflect.TypeOf(data), Options{})
+			So(err, ShouldBeNil)
+			So(length, ShouldEqual, 3)
+		})
+		Convey("Should encode int and uint fields with size tag", func() {
+			type Struct struct {
+				A int  `d2b:"size:1"`
+				B uint `d2b:"size:2"`
+				C *int `d2b:"size:4"`
+				D int  `d2b:"size:8"`
+			}
+			c := -2
+			bytes, err := Encode(Struct{A: -1, B: 65535, C: &c, D: 1}, binary.BigEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{
+				255,
+				255, 255,
+				255, 255, 255, 254,
+				0, 0, 0, 0, 0, 0, 0, 1,
+			})
+		})
+		Convey("Should return error if int field overflows it's size", func() {
+			type Struct struct {
+				A int  `d2b:"size:1"`
+				B uint `d2b:"size:2"`
+			}
+			values := []interface{}{Struct{A: 128}, Struct{A: -129}, Struct{B: 65536}}
+			for _, value := range values {
+				bytes, err := Encode(value, binary.BigEndian)
+				So(err, ShouldNotBeNil)
+				So(bytes, ShouldBeEmpty)
+			}
+		})
+		Convey("Should return error if size tag is invalid", func() {
+			type WrongSize struct {
+				A int `d2b:"size:3"`
+			}
+			type WrongType struct {
+				A int16 `d2b:"size:4"`
+			}
+			for _, value := range []interface{}{WrongSize{}, WrongType{}} {
+				bytes, err := Encode(value, binary.BigEndian)
+				So(err, ShouldNotBeNil)
+				So(bytes, ShouldBeEmpty)
+			}
+		})
+		Convey("Should encode strings with configured padding and terminator", func() {
+			type Struct struct {
+				A string  `d2b:"cstring"`
+				B string  `d2b:"length:6,pad:space"`
+				C *string `d2b:"length:4,cstring"`
+				D string  `d2b:"length:3"`
+			}
+			c := "abcdef"
+			bytes, err := Encode(Struct{A: "hi", B: "ATM", C: &c, D: "long"}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{'h', 'i', 0, 'A', 'T', 'M', ' ', ' ', ' ', 'a', 'b', 'c', 0, 'l', 'o', 'n'})
+
+			_, err = Encode(Struct{A: "a\x00b"}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should return error if strict string is longer than it's length", func() {
+			type Struct struct {
+				A string `d2b:"length:3,strict"`
+				B string `d2b:"length:3,cstring,strict"`
+			}
+			bytes, err := Encode(Struct{A: "abc", B: "ab"}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(bytes, ShouldResemble, []byte{'a', 'b', 'c', 'a', 'b', 0})
+			_, err = Encode(Struct{A: "abcd"}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			_, err = Encode(Struct{B: "abc"}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should return error if string options are invalid", func() {
+			type NotString struct {
+				A []byte `d2b:"length:2,pad:space"`
+			}
+			type UnknownPad struct {
+				A string `d2b:"length:2,pad:tab"`
+			}
+			type PrefixedCString struct {
+				A string `d2b:"cstring,lenprefix:uint8"`
+			}
+			type PaddedCString struct {
+				A string `d2b:"cstring,length:2,pad:space"`
+			}
+			type VariableTrim struct {
+				A string `d2b:"lenprefix:uint8,trim:right"`
+			}
+			for _, data := range []interface{}{NotString{}, UnknownPad{}, PrefixedCString{}, PaddedCString{}, VariableTrim{}} {
+				_, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+			}
+		})
 		Convey("Should return error if struct tag length contains wrong value", func() {
 			type ErrTestStruct struct {
 				Field string `d2b:"length:1qwe"`
@@ -189,3 +476,50 @@ func TestEncode(t *testing.T) {
 		})
 	})
 }
+
+func TestEncodeTo(t *testing.T) {
+	Convey("Test EncodeTo and AppendEncode", t, func() {
+		type Struct struct {
+			A uint16
+			B string `d2b:"lenprefix:uint8"`
+		}
+		data := Struct{A: 1, B: "abc"}
+		Convey("Should write bytes to dst and return their count", func() {
+			dst := make([]byte, 10)
+			n, err := EncodeTo(dst, data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 6)
+			So(dst[:n], ShouldResemble, []byte{1, 0, 3, 'a', 'b', 'c'})
+		})
+		Convey("Should return io.ErrShortBuffer if dst is too small", func() {
+			n, err := EncodeTo(make([]byte, 5), data, binary.LittleEndian)
+			So(err, ShouldEqual, io.ErrShortBuffer)
+			So(n, ShouldEqual, 0)
+			n, err = EncodeTo(make([]byte, 1), uint16(1), binary.LittleEndian)
+			So(err, ShouldEqual, io.ErrShortBuffer)
+			So(n, ShouldEqual, 0)
+		})
+		Convey("Should return encoding errors", func() {
+			_, err := EncodeTo(make([]byte, 10), int(1), binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			_, err = EncodeTo(make([]byte, 10), nil, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should append bytes to dst", func() {
+			dst, err := AppendEncode([]byte{9}, data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(dst, ShouldResemble, []byte{9, 1, 0, 3, 'a', 'b', 'c'})
+			dst, err = AppendEncode(dst[:1], uint16(2), binary.BigEndian)
+			So(err, ShouldBeNil)
+			So(dst, ShouldResemble, []byte{9, 0, 2})
+		})
+		Convey("Should return dst unchanged on error", func() {
+			type Limited struct {
+				A []uint8 `d2b:"lenprefix:uint8,length:1"`
+			}
+			dst, err := AppendEncode([]byte{9}, Limited{A: []uint8{1, 2}}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			So(dst, ShouldResemble, []byte{9})
+		})
+	})
+}
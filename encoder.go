@@ -0,0 +1,36 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// Encoder encodes values and writes them to an output stream
+type Encoder struct {
+	w      io.Writer
+	endian binary.ByteOrder
+	opts   Options
+	buf    []byte
+}
+
+// NewEncoder returns a new encoder that writes to w
+func NewEncoder(w io.Writer, endian binary.ByteOrder) *Encoder {
+	return &Encoder{w: w, endian: endian}
+}
+
+// Encode writes bytes representation of data to the stream
+// data is encoded to the internal buffer first, so nothing is written if encoding fails
+func (e *Encoder) Encode(data interface{}) error {
+	buf, err := appendEncode(e.buf[:0], data, e.endian, e.opts)
+	if err != nil {
+		return err
+	}
+	e.buf = buf
+	_, err = e.w.Write(buf)
+	return err
+}
+
+// SetOptions sets options, used by all next Encode calls
+func (e *Encoder) SetOptions(opts Options) {
+	e.opts = opts
+}
@@ -0,0 +1,66 @@
+package d2b
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, errors.New("write limit exceeded")
+	}
+	return w.buf.Write(p)
+}
+
+func TestEncoder(t *testing.T) {
+	Convey("Test Encoder", t, func() {
+		type Record struct {
+			ID    uint16
+			Name  string `d2b:"length:4"`
+			Data  []int8 `d2b:"length:2"`
+			Value *uint32
+		}
+		Convey("Should write successive records to stream", func() {
+			var buf bytes.Buffer
+			e := NewEncoder(&buf, binary.LittleEndian)
+			So(e.Encode(Record{ID: 1, Name: "ab", Data: []int8{1}}), ShouldBeNil)
+			So(e.Encode(&Record{ID: 2, Name: "cdefg", Data: []int8{3, 4}}), ShouldBeNil)
+			So(buf.Bytes(), ShouldResemble, []byte{
+				1, 0, 'a', 'b', 0, 0, 1, 0, 0, 0, 0, 0,
+				2, 0, 'c', 'd', 'e', 'f', 3, 4, 0, 0, 0, 0,
+			})
+		})
+		Convey("Should produce the same bytes as Encode", func() {
+			value := uint32(10)
+			data := Record{ID: 1, Name: "ab", Data: []int8{1, 2}, Value: &value}
+			var buf bytes.Buffer
+			So(NewEncoder(&buf, binary.BigEndian).Encode(data), ShouldBeNil)
+			expected, err := Encode(data, binary.BigEndian)
+			So(err, ShouldBeNil)
+			So(buf.Bytes(), ShouldResemble, expected)
+		})
+		Convey("Should return write errors", func() {
+			for limit := 0; limit < 12; limit++ {
+				w := &limitedWriter{limit: limit}
+				err := NewEncoder(w, binary.LittleEndian).Encode(&Record{Name: "ab"})
+				So(err, ShouldNotBeNil)
+			}
+			var nilRecord *Record
+			err := NewEncoder(&limitedWriter{}, binary.LittleEndian).Encode(nilRecord)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should return error if unsupported type passed", func() {
+			var buf bytes.Buffer
+			So(NewEncoder(&buf, binary.LittleEndian).Encode(int(0)), ShouldNotBeNil)
+		})
+	})
+}
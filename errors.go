@@ -0,0 +1,84 @@
+package d2b
+
+import "fmt"
+
+// ShortBufferError is returned by Decode when there are not enough bytes to decode a value
+type ShortBufferError struct {
+	// Path of the value which can't be decoded, e.g. "Header.Items[2].ID". Empty for top level value
+	Path string
+	// Needed is the number of bytes the value requires
+	Needed int
+	// Available is the number of bytes that were left
+	Available int
+}
+
+func (e *ShortBufferError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("not enough bytes to decode value: need %d, have %d", e.Needed, e.Available)
+	}
+	return fmt.Sprintf("not enough bytes to decode %s: need %d, have %d", e.Path, e.Needed, e.Available)
+}
+
+// PrependPath adds path of the parent value, e.g. struct field name or "[2]", to e.Path
+// It's used by Decode and by generated code to report path from the top level value
+func (e *ShortBufferError) PrependPath(path string) *ShortBufferError {
+	e.Path = joinPath(path, e.Path)
+	return e
+}
+
+func (e *ShortBufferError) prependPath(path string) {
+	e.PrependPath(path)
+}
+
+// ConstMismatchError is returned by Decode when value of field with const option differs from the constant
+type ConstMismatchError struct {
+	// Path of the field, e.g. "Header.Magic"
+	Path string
+	// Expected is the constant and Actual is the decoded value. They have type of the field
+	Expected interface{}
+	Actual   interface{}
+}
+
+func (e *ConstMismatchError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("constant value mismatch: expected %#v, got %#v", e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("constant %s mismatch: expected %#v, got %#v", e.Path, e.Expected, e.Actual)
+}
+
+// PrependPath adds path of the parent value, e.g. struct field name or "[2]", to e.Path
+func (e *ConstMismatchError) PrependPath(path string) *ConstMismatchError {
+	e.Path = joinPath(path, e.Path)
+	return e
+}
+
+func (e *ConstMismatchError) prependPath(path string) {
+	e.PrependPath(path)
+}
+
+// pathError is an error, which reports path of the value, where it happened
+// Such errors are returned by Decode without wrapping, so their type can be checked
+type pathError interface {
+	error
+	prependPath(path string)
+}
+
+// joinPath returns path of value, which is nested to the value with parent path
+func joinPath(parent, path string) string {
+	if path == "" || path[0] == '[' {
+		return parent + path
+	}
+	return parent + "." + path
+}
+
+// prependFieldPath adds struct field name to the path of err
+func prependFieldPath(err pathError, name string) error {
+	err.prependPath(name)
+	return err
+}
+
+// prependIndexPath adds array/slice index to the path of err
+func prependIndexPath(err pathError, index int) error {
+	err.prependPath(fmt.Sprintf("[%d]", index))
+	return err
+}
@@ -0,0 +1,74 @@
+package d2b
+
+import (
+	"reflect"
+	"unsafe"
+
+	"gopkg.in/saturn4er/go-data-to-bytes.v2/internal/schema"
+)
+
+// structFields contains fields of struct with their tags
+// Embedded structs without d2b tag are flattened: their fields are placed instead of them
+// and Index of such fields is the path from the struct
+type structFields struct {
+	fields []reflect.StructField
+	tags   []*schema.Tag
+	// parsed is the same struct, described by schema
+	parsed *schema.Struct
+}
+
+// reflectType describes reflect type for schema package
+type reflectType struct {
+	reflect.Type
+}
+
+func (t reflectType) Elem() schema.Type {
+	return reflectType{t.Type.Elem()}
+}
+
+func (t reflectType) Key() schema.Type {
+	return reflectType{t.Type.Key()}
+}
+
+func (t reflectType) Field(i int) schema.Field {
+	ft := t.Type.Field(i)
+	return schema.Field{
+		Name:      ft.Name,
+		Type:      reflectType{ft.Type},
+		Tag:       ft.Tag,
+		Anonymous: ft.Anonymous,
+		Exported:  !isUnexported(ft),
+		Index:     ft.Index,
+	}
+}
+
+func (t reflectType) Custom() bool {
+	return isCustomType(t.Type)
+}
+
+// isUnexported checks if field can't be accessed with reflect without unsafe
+func isUnexported(ft reflect.StructField) bool {
+	return ft.PkgPath != ""
+}
+
+// structField returns field of struct v with index path
+// Unexported fields of addressable structs are accessed with unsafe, so they can be read and set like exported ones
+func structField(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		v = v.Field(i)
+		if !v.CanSet() && v.CanAddr() {
+			v = reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+		}
+	}
+	return v
+}
+
+// addressable returns v, if it's addressable, or it's copy
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	result := reflect.New(v.Type()).Elem()
+	result.Set(v)
+	return result
+}
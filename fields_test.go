@@ -0,0 +1,179 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testHeader struct {
+	Type   uint8
+	Length uint16
+}
+
+type testHeaderWithIP struct {
+	testHeader
+	Addr testIPv4
+}
+
+type testUnexported struct {
+	a    uint16
+	B    uint8
+	name string `d2b:"lenprefix:uint8"`
+	addr testIPv4
+	_    [2]byte
+	ptr  *int8
+}
+
+func TestUnexportedFields(t *testing.T) {
+	Convey("Test unexported struct fields", t, func() {
+		n := int8(-1)
+		data := testUnexported{a: 0x102, B: 3, name: "ab", addr: 0x7F000001, ptr: &n}
+		expected := []byte{2, 1, 3, 2, 'a', 'b', 0x7F, 0, 0, 1, 0, 0, 0xFF}
+		Convey("Should encode and decode unexported fields", func() {
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, expected)
+			encoded, err = Encode(&data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, expected)
+			size, err := Size(data)
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, len(expected))
+
+			var result testUnexported
+			So(Decode(expected, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+		Convey("Should not store blank fields while decoding", func() {
+			type Struct struct {
+				A uint8 `d2b:"bits:4"`
+				_ uint8 `d2b:"bits:4"`
+				_ [2]byte
+				B uint8
+			}
+			var result Struct
+			So(Decode([]byte{0x12, 3, 4, 5}, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, Struct{A: 1, B: 5})
+			encoded, err := Encode(result, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{0x10, 0, 0, 5})
+		})
+		Convey("Should encode unexported fields of slice elements and map values", func() {
+			type Struct struct {
+				A []testUnexported         `d2b:"lenprefix:uint8"`
+				B map[uint8]testUnexported `d2b:"lenprefix:uint8"`
+			}
+			value := Struct{A: []testUnexported{data}, B: map[uint8]testUnexported{1: data}}
+			encoded, err := Encode(value, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, append(append(append([]byte{1}, expected...), 1, 1), expected...))
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, value)
+		})
+		Convey("Should skip unexported fields, if it's set by options", func() {
+			opts := Options{SkipUnexported: true}
+			encoded, err := EncodeWithOptions(data, binary.LittleEndian, opts)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{3})
+
+			result := testUnexported{a: 5}
+			So(DecodeWithOptions([]byte{7}, binary.LittleEndian, &result, opts), ShouldBeNil)
+			So(result, ShouldResemble, testUnexported{a: 5, B: 7})
+		})
+		Convey("Should keep blank bit fields, if unexported fields are skipped", func() {
+			type Struct struct {
+				_ uint8 `d2b:"bits:4"`
+				A uint8 `d2b:"bits:2"`
+				_ uint8 `d2b:"bits:2"`
+				b uint8
+				C uint8
+			}
+			opts := Options{SkipUnexported: true}
+			encoded, err := EncodeWithOptions(Struct{A: 1, b: 2, C: 3}, binary.LittleEndian, opts)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{0x04, 3})
+
+			var result Struct
+			So(DecodeWithOptions([]byte{0xF7, 3}, binary.LittleEndian, &result, opts), ShouldBeNil)
+			So(result, ShouldResemble, Struct{A: 1, C: 3})
+		})
+		Convey("Should return error if skipped unexported field is used by other fields", func() {
+			type Bits struct {
+				a uint8 `d2b:"bits:4"`
+				B uint8 `d2b:"bits:4"`
+			}
+			type Length struct {
+				n uint8
+				A []uint8 `d2b:"lengthfrom:n"`
+			}
+			opts := Options{SkipUnexported: true}
+			for _, value := range []interface{}{Bits{}, Length{}} {
+				_, err := EncodeWithOptions(value, binary.LittleEndian, opts)
+				So(err, ShouldNotBeNil)
+				_, err = Encode(value, binary.LittleEndian)
+				So(err, ShouldBeNil)
+			}
+		})
+	})
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	Convey("Test embedded structs", t, func() {
+		Convey("Should flatten embedded structs", func() {
+			type Packet struct {
+				testHeader
+				Payload []byte `d2b:"lengthfrom:Length,autofill"`
+			}
+			data := Packet{testHeader: testHeader{Type: 1}, Payload: []byte{5, 6}}
+			encoded, err := Encode(data, binary.BigEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{1, 0, 2, 5, 6})
+
+			var result Packet
+			So(Decode(encoded, binary.BigEndian, &result), ShouldBeNil)
+			So(result.Type, ShouldEqual, 1)
+			So(result.Length, ShouldEqual, 2)
+			So(result.Payload, ShouldResemble, []byte{5, 6})
+
+			err = Decode(encoded[:2], binary.BigEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "Length", Needed: 2, Available: 1})
+		})
+		Convey("Should flatten nested embedded structs and bit fields", func() {
+			type Flags struct {
+				A uint8 `d2b:"bits:4"`
+			}
+			type Packet struct {
+				testHeaderWithIP
+				Flags
+				B uint8 `d2b:"bits:4"`
+			}
+			data := Packet{testHeaderWithIP{testHeader{Type: 1, Length: 2}, 0x0A000001}, Flags{A: 3}, 4}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{1, 2, 0, 0x0A, 0, 0, 1, 0x34})
+
+			var result Packet
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+		Convey("Should encode embedded pointers and structs with tag as usual fields", func() {
+			type Packet struct {
+				*testHeader
+				Body             testHeader
+				testHeaderWithIP `d2b:"endian:big"`
+			}
+			data := Packet{testHeader: &testHeader{Type: 1, Length: 2}}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{1, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+
+			var result Packet
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package d2b
+
+import "math"
+
+// Float16Bits converts float32 to IEEE 754 half-precision bits, rounding to nearest even
+// It is used by float16 fields and by generated code
+// Values, which are too big for half-precision, become infinities
+func Float16Bits(f float32) uint16 {
+	b := math.Float32bits(f)
+	sign := uint16(b>>16) & 0x8000
+	exp := int(b>>23&0xFF) - 127 + 15
+	mant := b & 0x7FFFFF
+	switch {
+	case b&0x7FFFFFFF > 0x7F800000:
+		return sign | 0x7E00
+	case exp >= 0x1F:
+		return sign | 0x7C00
+	case exp <= 0:
+		if exp < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint(14 - exp)
+		half := mant >> shift
+		rem := mant & (1<<shift - 1)
+		halfway := uint32(1) << (shift - 1)
+		if rem > halfway || rem == halfway && half&1 == 1 {
+			half++
+		}
+		return sign | uint16(half)
+	}
+	half := uint32(exp)<<10 | mant>>13
+	rem := mant & 0x1FFF
+	if rem > 0x1000 || rem == 0x1000 && half&1 == 1 {
+		// overflow of mantissa increments exponent, which is correct rounding up to the next power of two or infinity
+		half++
+	}
+	return sign | uint16(half)
+}
+
+// Float16FromBits converts IEEE 754 half-precision bits to float32
+func Float16FromBits(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h >> 10 & 0x1F)
+	mant := uint32(h & 0x3FF)
+	switch exp {
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		// normalize subnormal number
+		exp = 127 - 15 + 1
+		for mant&0x400 == 0 {
+			mant <<= 1
+			exp--
+		}
+		return math.Float32frombits(sign | exp<<23 | (mant&0x3FF)<<13)
+	case 0x1F:
+		return math.Float32frombits(sign | 0x7F800000 | mant<<13)
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
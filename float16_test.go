@@ -0,0 +1,63 @@
+package d2b
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFloat16(t *testing.T) {
+	Convey("Test float16 conversion", t, func() {
+		Convey("Should convert float32 to float16", func() {
+			cases := map[float32]uint16{
+				0:                             0x0000,
+				float32(math.Copysign(0, -1)): 0x8000,
+				1:                             0x3C00,
+				-2:                            0xC000,
+				0.5:                           0x3800,
+				65504:                         0x7BFF,
+				65520:                         0x7C00,
+				1e10:                          0x7C00,
+				6.1035156e-05:                 0x0400,
+				5.9604645e-08:                 0x0001,
+				2.9802322e-08:                 0x0000,
+				2.9802326e-08:                 0x0001,
+				1.0009765625:                  0x3C01,
+				1.00048828125:                 0x3C00,
+				1.00146484375:                 0x3C02,
+				float32(math.Inf(1)):          0x7C00,
+			}
+			for f, h := range cases {
+				So(Float16Bits(f), ShouldEqual, h)
+			}
+			So(Float16Bits(float32(math.NaN())), ShouldEqual, 0x7E00)
+		})
+		Convey("Should convert float16 to float32", func() {
+			cases := map[uint16]float32{
+				0x0000: 0,
+				0x3C00: 1,
+				0xC000: -2,
+				0x7BFF: 65504,
+				0x0400: 6.1035156e-05,
+				0x0001: 5.9604645e-08,
+				0x03FF: 6.097555e-05,
+				0x7C00: float32(math.Inf(1)),
+				0xFC00: float32(math.Inf(-1)),
+			}
+			for h, f := range cases {
+				So(Float16FromBits(h), ShouldEqual, f)
+			}
+			So(math.IsNaN(float64(Float16FromBits(0x7E00))), ShouldBeTrue)
+		})
+		Convey("Should convert all float16 values back and forth", func() {
+			for h := 0; h <= 0xFFFF; h++ {
+				f := Float16FromBits(uint16(h))
+				if f != f {
+					continue
+				}
+				So(Float16Bits(f), ShouldEqual, h)
+			}
+		})
+	})
+}
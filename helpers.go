@@ -1,10 +1,191 @@
 package d2b
 
-func bytesToStr(bytes []byte) string {
+import (
+	"encoding/binary"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+const maxInt = int(^uint(0) >> 1)
+
+// bytesBeforeZero returns bytes before the first zero byte
+func bytesBeforeZero(bytes []byte) []byte {
 	for key, value := range bytes {
 		if value == '\u0000' {
-			return string(bytes[:key])
+			return bytes[:key]
+		}
+	}
+	return bytes
+}
+
+// indexZero returns index of the first zero byte or -1
+func indexZero(bytes []byte) int {
+	for i, b := range bytes {
+		if b == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// trimRight returns bytes without trailing pad bytes
+func trimRight(bytes []byte, pad byte) []byte {
+	end := len(bytes)
+	for end > 0 && bytes[end-1] == pad {
+		end--
+	}
+	return bytes[:end]
+}
+
+// setStringBytes sets string value, if it differs from bytes
+// Strings are not reallocated, when values are decoded to the same variable again
+func setStringBytes(v reflect.Value, bytes []byte) {
+	if v.String() != string(bytes) {
+		v.SetString(string(bytes))
+	}
+}
+
+// indirectType returns type, pointers point to
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// readUint reads unsigned integer, which takes size bytes
+func readUint(in *input, size int, endian binary.ByteOrder) (uint64, error) {
+	bytes, err := in.next(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(bytes[0]), nil
+	case 2:
+		return uint64(endian.Uint16(bytes)), nil
+	case 4:
+		return uint64(endian.Uint32(bytes)), nil
+	case 8:
+		return endian.Uint64(bytes), nil
+	}
+	return 0, errors.Errorf("unsupported integer size %d", size)
+}
+
+// extend grows buf by n zero bytes and returns it with the added part
+func extend(buf []byte, n int) ([]byte, []byte) {
+	l := len(buf)
+	if cap(buf)-l < n {
+		grown := make([]byte, l, 2*cap(buf)+n)
+		copy(grown, buf)
+		buf = grown
+	}
+	buf = buf[:l+n]
+	added := buf[l:]
+	for i := range added {
+		added[i] = 0
+	}
+	return buf, added
+}
+
+// appendUint appends unsigned integer, which takes size bytes, to buf
+func appendUint(buf []byte, value uint64, size int, endian binary.ByteOrder) ([]byte, error) {
+	if size < 8 && value>>(uint(size)*8) != 0 {
+		return nil, errors.Errorf("value %d doesn't fit in %d bytes", value, size)
+	}
+	buf, b := extend(buf, size)
+	switch size {
+	case 1:
+		b[0] = byte(value)
+	case 2:
+		endian.PutUint16(b, uint16(value))
+	case 4:
+		endian.PutUint32(b, uint32(value))
+	case 8:
+		endian.PutUint64(b, value)
+	default:
+		return nil, errors.Errorf("unsupported integer size %d", size)
+	}
+	return buf, nil
+}
+
+// lengthFieldValue returns value of integer field, which contains length of another field
+// nil pointers are treated as zero
+func lengthFieldValue(v reflect.Value) (int, error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0, nil
 		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		if n < 0 || uint64(n) > uint64(maxInt) {
+			return 0, errors.Errorf("invalid length %d", n)
+		}
+		return int(n), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := v.Uint()
+		if n > uint64(maxInt) {
+			return 0, errors.Errorf("invalid length %d", n)
+		}
+		return int(n), nil
+	}
+	return 0, errors.Errorf("length can't be stored in %v", v.Kind())
+}
+
+// setLengthFieldValue sets value of integer field, which contains length of another field
+func setLengthFieldValue(v reflect.Value, length int) error {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.OverflowInt(int64(length)) {
+			return errors.Errorf("length %d overflows %v", length, v.Type())
+		}
+		v.SetInt(int64(length))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.OverflowUint(uint64(length)) {
+			return errors.Errorf("length %d overflows %v", length, v.Type())
+		}
+		v.SetUint(uint64(length))
+		return nil
+	}
+	return errors.Errorf("length can't be stored in %v", v.Kind())
+}
+
+// appendSizedInt appends int or uint value, which takes size bytes, to buf
+func appendSizedInt(buf []byte, v reflect.Value, size int, endian binary.ByteOrder) ([]byte, error) {
+	if v.Kind() != reflect.Int {
+		return appendUint(buf, v.Uint(), size, endian)
+	}
+	n := v.Int()
+	bits := uint(size) * 8
+	if bits < 64 && (n < -1<<(bits-1) || n >= 1<<(bits-1)) {
+		return nil, errors.Errorf("value %d doesn't fit in %d bytes", n, size)
+	}
+	return appendUint(buf, uint64(n)&(1<<bits-1), size, endian)
+}
+
+// updateSizedInt decodes int or uint value, which takes size bytes, sign-extending int values
+func updateSizedInt(v reflect.Value, in *input, size int, endian binary.ByteOrder) error {
+	val, err := readUint(in, size, endian)
+	if err != nil {
+		return err
+	}
+	if v.Kind() != reflect.Int {
+		if v.OverflowUint(val) {
+			return errors.Errorf("value %d overflows %v", val, v.Type())
+		}
+		v.SetUint(val)
+		return nil
+	}
+	shift := 64 - uint(size)*8
+	n := int64(val<<shift) >> shift
+	if v.OverflowInt(n) {
+		return errors.Errorf("value %d overflows %v", n, v.Type())
 	}
-	return string(bytes[:])
+	v.SetInt(n)
+	return nil
 }
@@ -0,0 +1,56 @@
+package d2b
+
+import "sync"
+
+// input is a position in decoded bytes. Codecs decode value at the position and move it after the value
+// Input of Decoder reads bytes from the stream, when codec needs more of them, so every byte is decoded once
+type input struct {
+	buf []byte
+	pos int
+	// stream is nil if all bytes are in buf
+	stream *Decoder
+}
+
+// inputs keeps inputs of Codec.Decode, so decoding of byte slices doesn't allocate them
+var inputs = sync.Pool{New: func() interface{} { return new(input) }}
+
+// rest returns bytes after the position, which are already read
+func (in *input) rest() []byte {
+	return in.buf[in.pos:]
+}
+
+// need reads bytes from the stream until there are at least n bytes after the position
+// Returns ShortBufferError if input has no stream and there are less bytes
+func (in *input) need(n int) error {
+	available := len(in.buf) - in.pos
+	if available >= n {
+		return nil
+	}
+	if in.stream == nil {
+		return &ShortBufferError{Needed: n, Available: available}
+	}
+	buf, err := in.stream.read(in.buf, n-available)
+	if err != nil {
+		return err
+	}
+	in.buf = buf
+	return nil
+}
+
+// next returns n bytes after the position and moves position after them
+func (in *input) next(n int) ([]byte, error) {
+	if len(in.buf)-in.pos < n {
+		if err := in.need(n); err != nil {
+			return nil, err
+		}
+	}
+	b := in.buf[in.pos : in.pos+n]
+	in.pos += n
+	return b, nil
+}
+
+// skip moves position after n bytes
+func (in *input) skip(n int) error {
+	_, err := in.next(n)
+	return err
+}
@@ -0,0 +1,226 @@
+package schema
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Struct contains fields of struct with their tags
+// Embedded structs without d2b tag are flattened: their fields are placed instead of them
+// and Index of such fields is the path from the struct
+type Struct struct {
+	Fields []Field
+	Tags   []*Tag
+}
+
+// ParseStruct parses tags of fields of struct type t and resolves references between them
+func (p *Parser) ParseStruct(t Type) (*Struct, error) {
+	fields := flattenFields(t, nil, nil)
+	tags := make([]*Tag, len(fields))
+	for i, field := range fields {
+		tag, err := p.ParseField(field)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%v field tag error", field.Name)
+		}
+		tags[i] = tag
+	}
+	result := &Struct{Fields: fields, Tags: tags}
+	if err := result.resolveLengthFromFields(t); err != nil {
+		return nil, err
+	}
+	if err := result.resolveBitGroups(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Layout returns layout of struct, which is set by struct level pack or natural option
+func (s *Struct) Layout() string {
+	layout := LayoutPack
+	for _, tag := range s.Tags {
+		if tag.Layout != "" {
+			layout = tag.Layout
+		}
+	}
+	return layout
+}
+
+// SkipUnexported checks if i-th field is skipped, when unexported fields are skipped
+// Blank bit fields are padding bits of their group, so they are kept with it
+// It returns error, if field is unexported, but other fields depend on it
+func (s *Struct) SkipUnexported(i int) (bool, error) {
+	field, tag := s.Fields[i], s.Tags[i]
+	if field.Exported || field.Name == "_" && tag.Bits != 0 {
+		return false, nil
+	}
+	if tag.Bits != 0 {
+		return false, errors.New("unexported bit field can't be skipped")
+	}
+	for _, other := range s.Tags {
+		if other.LengthFrom != "" && !other.Skip && other.LengthFromIndex == i {
+			return false, errors.New("unexported field, which contains length of another field, can't be skipped")
+		}
+	}
+	return true, nil
+}
+
+// flattenFields appends fields of struct t, which is placed by index path, to result
+func flattenFields(t Type, index []int, result []Field) []Field {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		field.Index = append(append([]int(nil), index...), i)
+		if isFlattened(field) {
+			result = flattenFields(field.Type, field.Index, result)
+			continue
+		}
+		result = append(result, field)
+	}
+	return result
+}
+
+// isFlattened checks if field is embedded struct, which fields are encoded as fields of it's parent
+// Embedded structs with d2b tag and custom types are encoded as usual fields
+func isFlattened(field Field) bool {
+	tagged := field.Tag.Get("d2b") != ""
+	return field.Anonymous && !tagged && field.Type.Kind() == reflect.Struct && !field.Type.Custom()
+}
+
+// fieldPosition returns position of field with index path in fields or -1
+func fieldPosition(fields []Field, index []int) int {
+	for i, field := range fields {
+		if len(field.Index) != len(index) {
+			continue
+		}
+		equal := true
+		for k := range index {
+			equal = equal && field.Index[k] == index[k]
+		}
+		if equal {
+			return i
+		}
+	}
+	return -1
+}
+
+// fieldByName returns index path of field of struct t with name, which is resolved like Go selector:
+// field of struct shadows fields of embedded structs with the same name and ambiguous names aren't found
+func fieldByName(t Type, name string) ([]int, bool) {
+	type candidate struct {
+		t     Type
+		index []int
+	}
+	current := []candidate{{t: t}}
+	// recursive types can embed pointers to themselves
+	visited := make(map[Type]bool)
+	for len(current) > 0 {
+		var next []candidate
+		var found []int
+		count := 0
+		for _, c := range current {
+			if visited[c.t] {
+				continue
+			}
+			visited[c.t] = true
+			for i := 0; i < c.t.NumField(); i++ {
+				field := c.t.Field(i)
+				index := append(append([]int(nil), c.index...), i)
+				if field.Name == name {
+					found = index
+					count++
+					continue
+				}
+				if !field.Anonymous {
+					continue
+				}
+				embedded := field.Type
+				if embedded.Kind() == reflect.Ptr {
+					embedded = embedded.Elem()
+				}
+				if embedded.Kind() == reflect.Struct {
+					next = append(next, candidate{t: embedded, index: index})
+				}
+			}
+		}
+		if count == 1 {
+			return found, true
+		}
+		if count > 1 {
+			return nil, false
+		}
+		current = next
+	}
+	return nil, false
+}
+
+// resolveLengthFromFields finds fields of struct t, referred by lengthfrom tag option
+// Fields of flattened embedded structs are referred by their promoted names
+func (s *Struct) resolveLengthFromFields(t Type) error {
+	for i, tag := range s.Tags {
+		if tag.LengthFrom == "" || tag.Skip {
+			continue
+		}
+		name := s.Fields[i].Name
+		index, ok := fieldByName(t, tag.LengthFrom)
+		j := fieldPosition(s.Fields, index)
+		if !ok || j < 0 {
+			return errors.Errorf("%v field tag error: field %v doesn't exist", name, tag.LengthFrom)
+		}
+		if j >= i {
+			return errors.Errorf("%v field tag error: field %v should be declared before", name, tag.LengthFrom)
+		}
+		if s.Tags[j].Skip {
+			return errors.Errorf("%v field tag error: field %v is skipped", name, tag.LengthFrom)
+		}
+		if k := Indirect(s.Fields[j].Type).Kind(); !IsIntegerKind(k) {
+			return errors.Errorf("%v field tag error: field %v has non integer type %v", name, tag.LengthFrom, k)
+		}
+		tag.LengthFromIndex = j
+		if tag.AutoFill && s.Tags[j].Const != "" {
+			return errors.Errorf("%v field tag error: field %v is constant, so it can't be autofilled", name, tag.LengthFrom)
+		}
+		if tag.AutoFill && s.Tags[j].Bits != 0 {
+			return errors.Errorf("%v field tag error: field %v is bit field, so it can't be autofilled", name, tag.LengthFrom)
+		}
+		if tag.AutoFill {
+			s.Tags[j].LengthOf = append(s.Tags[j].LengthOf, i)
+		}
+	}
+	return nil
+}
+
+// resolveBitGroups splits consecutive bit fields into groups, which should take whole bytes
+func (s *Struct) resolveBitGroups() error {
+	tags := s.Tags
+	bitOrder := BitOrderMSB
+	for _, tag := range tags {
+		if tag.BitOrder != "" {
+			bitOrder = tag.BitOrder
+		}
+	}
+	for i := 0; i < len(tags); i++ {
+		if tags[i].Bits == 0 {
+			continue
+		}
+		start, bits := i, 0
+		for ; i < len(tags) && tags[i].Bits != 0; i++ {
+			if tags[i].Skip {
+				return errors.Errorf("%v field tag error: bit field can't be skipped", s.Fields[i].Name)
+			}
+			tags[i].BitOrder = bitOrder
+			bits += tags[i].Bits
+		}
+		if bits%8 != 0 {
+			return errors.Errorf("bit fields from %v to %v take %d bits, which is not a whole number of bytes",
+				s.Fields[start].Name, s.Fields[i-1].Name, bits)
+		}
+		if bits > 64 {
+			return errors.Errorf("bit fields from %v to %v take %d bits, but group can't be longer than 64 bits",
+				s.Fields[start].Name, s.Fields[i-1].Name, bits)
+		}
+		tags[start].BitGroupLength = bits / 8
+		tags[start].BitGroupEnd = i
+		i--
+	}
+	return nil
+}
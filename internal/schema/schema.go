@@ -0,0 +1,93 @@
+// Package schema parses d2b tags and resolves fields of structs. It's shared by d2b, which describes types
+// with reflect, and d2bgen, which describes types of parsed source code, so both of them read tags the same way
+package schema
+
+import "reflect"
+
+const (
+	// BitOrderMSB packs bit fields from the most significant bit. It's default
+	BitOrderMSB = "msb"
+	// BitOrderLSB packs bit fields from the least significant bit
+	BitOrderLSB = "lsb"
+)
+
+const (
+	// LayoutPack places struct fields one after another, unless they have align option. It's default
+	LayoutPack = "pack"
+	// LayoutNatural aligns struct fields by their size, like C compiler does
+	LayoutNatural = "natural"
+)
+
+// Replacement policies of characters, which can't be represented in charset, and invalid bytes
+const (
+	ReplaceDefault = "replace"
+	ReplaceError   = "error"
+	ReplaceSkip    = "skip"
+)
+
+// LenPrefixVarint is the LenPrefix of lengths, which are encoded as unsigned LEB128 varints
+const LenPrefixVarint = -1
+
+// MaxAlign is the max alignment, which can be set by align option
+const MaxAlign = 4096
+
+// MaxReserved is the max count of reserved bytes before field
+const MaxReserved = 1 << 20
+
+// Type is a type of tagged value. Methods, which don't make sense for it's kind, are not called
+type Type interface {
+	Kind() reflect.Kind
+	// Elem returns type of pointer target, array and slice elements or map values
+	Elem() Type
+	// Key returns type of map keys
+	Key() Type
+	// Bits returns size of sized number in bits
+	Bits() int
+	NumField() int
+	Field(i int) Field
+	// Custom checks if type has it's own bytes representation, so it's fields are not encoded
+	Custom() bool
+}
+
+// Field is a field of struct type
+type Field struct {
+	Name      string
+	Type      Type
+	Tag       reflect.StructTag
+	Anonymous bool
+	Exported  bool
+	// Index is the path of field from the struct, which fields are parsed
+	Index []int
+}
+
+// Indirect returns type, pointers point to
+func Indirect(t Type) Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// IsIntegerKind checks if k is a kind of signed or unsigned integer
+func IsIntegerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// IsSignedKind checks if k is a kind of signed integer
+func IsSignedKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// IsValidIntSize checks if int or uint can be stored in size bytes
+func IsValidIntSize(size int) bool {
+	return size == 1 || size == 2 || size == 4 || size == 8
+}
@@ -0,0 +1,128 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testType describes reflect type for tests, like d2b does
+type testType struct {
+	reflect.Type
+}
+
+func (t testType) Elem() Type {
+	return testType{t.Type.Elem()}
+}
+
+func (t testType) Key() Type {
+	return testType{t.Type.Key()}
+}
+
+func (t testType) Field(i int) Field {
+	ft := t.Type.Field(i)
+	return Field{Name: ft.Name, Type: testType{ft.Type}, Tag: ft.Tag, Anonymous: ft.Anonymous, Exported: ft.PkgPath == "", Index: ft.Index}
+}
+
+func (t testType) Custom() bool {
+	return false
+}
+
+func typeOf(v interface{}) Type {
+	return testType{reflect.TypeOf(v)}
+}
+
+func TestParse(t *testing.T) {
+	Convey("Test parsing of tags", t, func() {
+		parser := new(Parser)
+		Convey("Should not split quoted constants", func() {
+			So(splitTag(`const:"a,\",b",length:2`), ShouldResemble, []string{`const:"a,\",b"`, "length:2"})
+			tag, err := parser.Parse(`endian:big,const:"a,\",b"`, typeOf(""))
+			So(err, ShouldBeNil)
+			So(tag.Const, ShouldEqual, `"a,\",b"`)
+		})
+		Convey("Should ignore unknown options, unless they are rejected", func() {
+			tag, err := parser.Parse("length:2,unknown", typeOf(""))
+			So(err, ShouldBeNil)
+			So(tag.Length, ShouldEqual, 2)
+			_, err = (&Parser{RejectUnknown: true}).Parse("length:2,unknown", typeOf(""))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should check charsets and constants with callbacks", func() {
+			parser := &Parser{
+				Charset: func(name string) (int, error) { return 2, nil },
+				Const: func(literal string, t Type) error {
+					if literal != "1" {
+						return errors.New("invalid constant")
+					}
+					return nil
+				},
+			}
+			_, err := parser.Parse("length:3,lenunit:bytes,charset:test", typeOf(""))
+			So(err, ShouldNotBeNil)
+			_, err = parser.Parse("length:4,lenunit:bytes,charset:test", typeOf(""))
+			So(err, ShouldBeNil)
+			_, err = parser.Parse("const:2", typeOf(uint8(0)))
+			So(err, ShouldNotBeNil)
+			tag, err := parser.Parse("const:1", typeOf(uint8(0)))
+			So(err, ShouldBeNil)
+			So(tag.Const, ShouldEqual, "1")
+		})
+	})
+}
+
+func TestParseStruct(t *testing.T) {
+	Convey("Test parsing of structs", t, func() {
+		parser := new(Parser)
+		Convey("Should find length fields by their promoted names", func() {
+			type Inner struct {
+				N uint8
+				M uint8
+			}
+			type Shadowed struct {
+				Inner
+				M uint16
+				A []uint8 `d2b:"lengthfrom:N"`
+				B []uint8 `d2b:"lengthfrom:M,autofill"`
+			}
+			s, err := parser.ParseStruct(typeOf(Shadowed{}))
+			So(err, ShouldBeNil)
+			So(len(s.Fields), ShouldEqual, 5)
+			So(s.Tags[3].LengthFromIndex, ShouldEqual, 0)
+			So(s.Tags[4].LengthFromIndex, ShouldEqual, 2)
+			So(s.Tags[2].LengthOf, ShouldResemble, []int{4})
+
+			type Other struct {
+				N uint8
+			}
+			type Ambiguous struct {
+				Inner
+				Other
+				A []uint8 `d2b:"lengthfrom:N"`
+			}
+			_, err = parser.ParseStruct(typeOf(Ambiguous{}))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should check, that unexported fields can be skipped", func() {
+			type Struct struct {
+				A uint8
+				b uint8
+				_ uint8 `d2b:"bits:4"`
+				c uint8 `d2b:"bits:4"`
+				n uint8
+				D []uint8 `d2b:"lengthfrom:n"`
+			}
+			s, err := parser.ParseStruct(typeOf(Struct{}))
+			So(err, ShouldBeNil)
+			skips := []bool{false, true, false, false, false, false}
+			errs := []bool{false, false, false, true, true, false}
+			for i := range s.Fields {
+				skip, err := s.SkipUnexported(i)
+				So(skip, ShouldEqual, skips[i])
+				So(err != nil, ShouldEqual, errs[i])
+			}
+		})
+	})
+}
@@ -0,0 +1,539 @@
+package schema
+
+import (
+	"encoding/binary"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var lenPrefixSizes = map[string]int{"uint8": 1, "uint16": 2, "uint32": 4, "uint64": 8, "varint": LenPrefixVarint}
+
+var byteOrders = map[string]binary.ByteOrder{"big": binary.BigEndian, "little": binary.LittleEndian}
+
+var padBytes = map[string]byte{"zero": 0, "space": ' '}
+
+// Tag contains options of struct field or of it's elements
+type Tag struct {
+	Length int
+	// LenPrefix is the size of length prefix in bytes or LenPrefixVarint
+	LenPrefix int
+	Skip      bool
+	// LengthFrom is the name of the field, which contains length of this field
+	LengthFrom      string
+	LengthFromIndex int
+	// AutoFill makes encoder write this field's length to LengthFrom field
+	AutoFill bool
+	// LengthOf contains indexes of AutoFill fields, which length is stored in this field
+	LengthOf []int
+	// Bits is the width of bit field
+	Bits int
+	// BitOrder is struct level option, which sets order of bit fields in bytes: BitOrderMSB (default) or BitOrderLSB
+	BitOrder string
+	// BitGroupLength is the length in bytes of bit fields group, which starts from this field
+	// Zero for bit fields, which are not first in group
+	BitGroupLength int
+	// BitGroupEnd is the index of the field next to the last field of group
+	BitGroupEnd int
+	// Endian overrides byte order of field and all of it's nested values
+	Endian binary.ByteOrder
+	// Float16 makes float field to be stored as IEEE 754 half-precision number
+	Float16 bool
+	// StrictBool makes decoder return error if bool field's byte isn't 0 or 1
+	StrictBool bool
+	// Size is the size in bytes of int or uint field
+	Size int
+	// CString makes string to be terminated by zero byte
+	CString bool
+	// Pad is the byte, which fills the rest of fixed length string
+	Pad byte
+	// TrimRight makes decoder strip Pad bytes from the end of fixed length string
+	TrimRight bool
+	// Strict makes encoder return error if string is longer than it's length instead of truncating it
+	Strict bool
+	// Charset is the name of text encoding of string
+	Charset string
+	// Replace is the policy for characters, which can't be represented in Charset: ReplaceDefault, ReplaceError or ReplaceSkip
+	Replace string
+	// Elem contains options of array/slice elements or map values, Key - of map keys. They are nil if not set
+	Elem *Tag
+	Key  *Tag
+	// Sorted makes encoder write map entries in ascending order of their keys
+	Sorted bool
+	// LengthInBytes makes lengths of strings with Charset to be counted in bytes instead of code units
+	LengthInBytes bool
+	// Varint makes integer to be stored as unsigned LEB128 varint, ZigZag - as zigzag encoded signed varint
+	Varint bool
+	ZigZag bool
+	// Align is the alignment of field's offset from the start of struct
+	Align int
+	// Layout is struct level option, which sets alignment of fields: LayoutPack (default) or LayoutNatural
+	Layout string
+	// Reserved is the count of bytes before field, which are filled with Fill byte and skipped while decoding
+	// StrictReserved makes decoder check, that they contain Fill byte
+	Reserved       int
+	Fill           byte
+	HasFill        bool
+	StrictReserved bool
+	// Offset is the position of field from the start of struct, it's set if HasOffset is true
+	Offset    int
+	HasOffset bool
+	// Const is the literal of value, which is always encoded instead of field's value and is expected while decoding
+	// It's empty if const option isn't set
+	Const string
+}
+
+// Parser parses d2b tags. Options, which depend on the user of schema, are checked by it's callbacks
+type Parser struct {
+	// Charset returns code unit size of charset with name. Charsets aren't checked, if it's nil
+	Charset func(name string) (int, error)
+	// Const checks, that literal is a constant of type t. Constants aren't checked, if it's nil
+	Const func(literal string, t Type) error
+	// RejectUnknown makes unknown options errors. By default they are ignored
+	RejectUnknown bool
+}
+
+// ParseField parses d2b tag of field
+func (p *Parser) ParseField(field Field) (*Tag, error) {
+	return p.Parse(field.Tag.Get("d2b"), field.Type)
+}
+
+// Parse parses options of value with type t
+// Options with "elem." prefix are pushed down to elements of arrays, slices and values of maps,
+// options with "key." prefix - to keys of maps
+func (p *Parser) Parse(tag string, t Type) (*Tag, error) {
+	result := new(Tag)
+	var elemParts, keyParts []string
+	parts := splitTag(tag)
+	k := Indirect(t).Kind()
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "elem.") {
+			elemParts = append(elemParts, strings.TrimPrefix(part, "elem."))
+			continue
+		}
+		if strings.HasPrefix(part, "key.") {
+			keyParts = append(keyParts, strings.TrimPrefix(part, "key."))
+			continue
+		}
+		if part == "" {
+			continue
+		}
+		if part == "-" {
+			result.Skip = true
+			continue
+		}
+		if part == "autofill" {
+			result.AutoFill = true
+			continue
+		}
+		if part == "bool:strict" {
+			if k != reflect.Bool {
+				return nil, errors.Errorf("bool:strict can't be used with %v", k)
+			}
+			result.StrictBool = true
+			continue
+		}
+		if part == "float16" {
+			if k != reflect.Float32 && k != reflect.Float64 {
+				return nil, errors.Errorf("float16 can't be used with %v", k)
+			}
+			result.Float16 = true
+			continue
+		}
+		if strings.HasPrefix(part, "length:") {
+			length, err := strconv.Atoi(strings.TrimPrefix(part, "length:"))
+			if err != nil {
+				return nil, err
+			}
+			result.Length = length
+			continue
+		}
+		if strings.HasPrefix(part, "lenprefix:") {
+			sType := strings.TrimPrefix(part, "lenprefix:")
+			size, ok := lenPrefixSizes[sType]
+			if !ok {
+				return nil, errors.Errorf("unsupported length prefix type %s", sType)
+			}
+			if k != reflect.String && k != reflect.Slice && k != reflect.Map {
+				return nil, errors.Errorf("length prefix can't be used with %v", k)
+			}
+			result.LenPrefix = size
+			continue
+		}
+		if strings.HasPrefix(part, "size:") {
+			size, err := strconv.Atoi(strings.TrimPrefix(part, "size:"))
+			if err != nil {
+				return nil, err
+			}
+			if !IsValidIntSize(size) {
+				return nil, errors.Errorf("invalid int size %d", size)
+			}
+			if k != reflect.Int && k != reflect.Uint {
+				return nil, errors.Errorf("size can't be used with %v", k)
+			}
+			result.Size = size
+			continue
+		}
+		if strings.HasPrefix(part, "endian:") {
+			name := strings.TrimPrefix(part, "endian:")
+			endian, ok := byteOrders[name]
+			if !ok {
+				return nil, errors.Errorf("unsupported byte order %s", name)
+			}
+			result.Endian = endian
+			continue
+		}
+		if strings.HasPrefix(part, "bits:") {
+			bits, err := strconv.Atoi(strings.TrimPrefix(part, "bits:"))
+			if err != nil {
+				return nil, err
+			}
+			if err := checkBitFieldType(t, bits); err != nil {
+				return nil, err
+			}
+			result.Bits = bits
+			continue
+		}
+		if strings.HasPrefix(part, "bitorder:") {
+			order := strings.TrimPrefix(part, "bitorder:")
+			if order != BitOrderMSB && order != BitOrderLSB {
+				return nil, errors.Errorf("unsupported bit order %s", order)
+			}
+			result.BitOrder = order
+			continue
+		}
+		if part == "sorted" {
+			if k != reflect.Map {
+				return nil, errors.Errorf("sorted can't be used with %v", k)
+			}
+			result.Sorted = true
+			continue
+		}
+		if part == LayoutPack || part == LayoutNatural {
+			if result.Layout != "" && result.Layout != part {
+				return nil, errors.New("pack and natural can't be used together")
+			}
+			result.Layout = part
+			continue
+		}
+		if strings.HasPrefix(part, "align:") {
+			align, err := strconv.Atoi(strings.TrimPrefix(part, "align:"))
+			if err != nil {
+				return nil, err
+			}
+			if err := checkAlign(align); err != nil {
+				return nil, err
+			}
+			result.Align = align
+			continue
+		}
+		if part == "varint" {
+			result.Varint = true
+			continue
+		}
+		if part == "zigzag" {
+			result.ZigZag = true
+			continue
+		}
+		if part == "cstring" {
+			result.CString = true
+			continue
+		}
+		if part == "strict" {
+			result.Strict = true
+			continue
+		}
+		if part == "trim:right" {
+			result.TrimRight = true
+			continue
+		}
+		if strings.HasPrefix(part, "pad:") {
+			name := strings.TrimPrefix(part, "pad:")
+			pad, ok := padBytes[name]
+			if !ok {
+				return nil, errors.Errorf("unsupported padding %s", name)
+			}
+			result.Pad = pad
+			continue
+		}
+		if part == "reserved:strict" {
+			result.StrictReserved = true
+			continue
+		}
+		if strings.HasPrefix(part, "reserved:") {
+			reserved, err := strconv.Atoi(strings.TrimPrefix(part, "reserved:"))
+			if err != nil {
+				return nil, err
+			}
+			if err := checkReserved(reserved); err != nil {
+				return nil, err
+			}
+			result.Reserved = reserved
+			continue
+		}
+		if strings.HasPrefix(part, "const:") {
+			literal := strings.TrimPrefix(part, "const:")
+			if literal == "" {
+				return nil, errors.New("invalid constant")
+			}
+			if p.Const != nil {
+				if err := p.Const(literal, t); err != nil {
+					return nil, err
+				}
+			}
+			result.Const = literal
+			continue
+		}
+		if strings.HasPrefix(part, "offset:") {
+			offset, err := strconv.ParseInt(strings.TrimPrefix(part, "offset:"), 0, 32)
+			if err != nil || offset < 0 {
+				return nil, errors.Errorf("invalid offset %s", strings.TrimPrefix(part, "offset:"))
+			}
+			result.Offset = int(offset)
+			result.HasOffset = true
+			continue
+		}
+		if strings.HasPrefix(part, "fill:") {
+			fill, err := strconv.ParseUint(strings.TrimPrefix(part, "fill:"), 0, 8)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid fill byte")
+			}
+			result.Fill = byte(fill)
+			result.HasFill = true
+			continue
+		}
+		if strings.HasPrefix(part, "charset:") {
+			result.Charset = strings.TrimPrefix(part, "charset:")
+			continue
+		}
+		if strings.HasPrefix(part, "replace:") {
+			policy := strings.TrimPrefix(part, "replace:")
+			if policy != ReplaceDefault && policy != ReplaceError && policy != ReplaceSkip {
+				return nil, errors.Errorf("unsupported replacement policy %s", policy)
+			}
+			result.Replace = policy
+			continue
+		}
+		if strings.HasPrefix(part, "lenunit:") {
+			unit := strings.TrimPrefix(part, "lenunit:")
+			if unit != "bytes" && unit != "units" {
+				return nil, errors.Errorf("unsupported length unit %s", unit)
+			}
+			result.LengthInBytes = unit == "bytes"
+			continue
+		}
+		if strings.HasPrefix(part, "lengthfrom:") {
+			if k != reflect.String && k != reflect.Slice && k != reflect.Map {
+				return nil, errors.Errorf("length from another field can't be used with %v", k)
+			}
+			result.LengthFrom = strings.TrimPrefix(part, "lengthfrom:")
+			continue
+		}
+		if p.RejectUnknown {
+			return nil, errors.Errorf("unknown tag option %s", part)
+		}
+	}
+	if result.LenPrefix != 0 && result.LengthFrom != "" {
+		return nil, errors.New("lenprefix and lengthfrom can't be used together")
+	}
+	if result.AutoFill && result.LengthFrom == "" {
+		return nil, errors.New("autofill can be used only with lengthfrom")
+	}
+	if (result.HasFill || result.StrictReserved) && result.Reserved == 0 {
+		return nil, errors.New("fill and reserved:strict can be used only with reserved bytes")
+	}
+	if result.HasOffset && (result.Align != 0 || result.Reserved != 0) {
+		return nil, errors.New("offset can't be used with align or reserved")
+	}
+	if err := checkStringOptions(result, k); err != nil {
+		return nil, err
+	}
+	if err := p.checkCharsetOptions(result, k); err != nil {
+		return nil, err
+	}
+	if err := checkVarintOptions(result, k); err != nil {
+		return nil, err
+	}
+	if err := checkConstOptions(result, k); err != nil {
+		return nil, err
+	}
+	var err error
+	if len(elemParts) > 0 {
+		if result.Elem, err = p.parseNested(elemParts, t, "elem"); err != nil {
+			return nil, err
+		}
+	}
+	if len(keyParts) > 0 {
+		if result.Key, err = p.parseNested(keyParts, t, "key"); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// parseNested parses options of elements (kind "elem") or keys (kind "key") of value with type t
+func (p *Parser) parseNested(parts []string, t Type, kind string) (*Tag, error) {
+	t = Indirect(t)
+	k := t.Kind()
+	var nested Type
+	switch {
+	case kind == "key" && k == reflect.Map:
+		nested = t.Key()
+	case kind == "elem" && (k == reflect.Array || k == reflect.Slice || k == reflect.Map):
+		nested = t.Elem()
+	default:
+		return nil, errors.Errorf("%s options can't be used with %v", kind, k)
+	}
+	tag, err := p.Parse(strings.Join(parts, ","), nested)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s options error", kind)
+	}
+	if tag.Skip || tag.LengthFrom != "" || tag.AutoFill || tag.Bits != 0 || tag.BitOrder != "" || tag.Endian != nil ||
+		tag.Align != 0 || tag.Layout != "" || tag.Reserved != 0 || tag.HasOffset {
+		return nil, errors.Errorf("-, lengthfrom, autofill, bits, bitorder, endian, align, pack, natural, reserved and offset can't be used with %s options", kind)
+	}
+	return tag, nil
+}
+
+// checkCharsetOptions checks, that charset is used with strings and that options, which depend on it, are set with it
+func (p *Parser) checkCharsetOptions(tag *Tag, k reflect.Kind) error {
+	if tag.Charset == "" {
+		if tag.Replace != "" || tag.LengthInBytes {
+			return errors.New("replace and lenunit can be used only with charset")
+		}
+		return nil
+	}
+	if p.Charset == nil {
+		return nil
+	}
+	unit, err := p.Charset(tag.Charset)
+	if err != nil {
+		return err
+	}
+	if k != reflect.String {
+		return errors.Errorf("charset can't be used with %v", k)
+	}
+	if unit < 1 {
+		return errors.Errorf("%s code unit size %d is invalid", tag.Charset, unit)
+	}
+	if tag.LengthInBytes && tag.LenPrefix == 0 && tag.LengthFrom == "" && tag.Length%unit != 0 {
+		return errors.Errorf("length %d isn't multiple of %s code unit size %d", tag.Length, tag.Charset, unit)
+	}
+	return nil
+}
+
+// checkStringOptions checks, that cstring, pad, trim:right and strict options are used with strings,
+// which can have them
+func checkStringOptions(tag *Tag, k reflect.Kind) error {
+	if !tag.CString && tag.Pad == 0 && !tag.TrimRight && !tag.Strict {
+		return nil
+	}
+	if k != reflect.String {
+		return errors.Errorf("string options can't be used with %v", k)
+	}
+	if tag.CString && (tag.LenPrefix != 0 || tag.LengthFrom != "") {
+		return errors.New("cstring can't be used with lenprefix or lengthfrom")
+	}
+	if tag.CString && tag.Pad != 0 {
+		return errors.New("cstring can't be padded with non zero bytes")
+	}
+	if (tag.Pad != 0 || tag.TrimRight || tag.Strict) && (tag.Length == 0 || tag.LenPrefix != 0 || tag.LengthFrom != "") {
+		return errors.New("pad, trim:right and strict can be used only with fixed length strings")
+	}
+	return nil
+}
+
+// checkVarintOptions checks, that varint and zigzag are used with integers, which size isn't set by other options
+func checkVarintOptions(tag *Tag, k reflect.Kind) error {
+	if !tag.Varint && !tag.ZigZag {
+		return nil
+	}
+	if tag.Varint && tag.ZigZag {
+		return errors.New("varint and zigzag can't be used together")
+	}
+	if tag.ZigZag && !IsSignedKind(k) {
+		return errors.Errorf("zigzag can't be used with %v", k)
+	}
+	if !IsIntegerKind(k) {
+		return errors.Errorf("varint can't be used with %v", k)
+	}
+	if tag.Size != 0 || tag.Bits != 0 {
+		return errors.New("varint and zigzag can't be used with size or bits")
+	}
+	return nil
+}
+
+// checkConstOptions checks, that const isn't used with options, which set length of strings and bytes
+func checkConstOptions(tag *Tag, k reflect.Kind) error {
+	if tag.Const == "" {
+		return nil
+	}
+	if tag.Bits != 0 {
+		return errors.New("const can't be used with bits")
+	}
+	if k != reflect.String && k != reflect.Slice {
+		return nil
+	}
+	if tag.Length != 0 || tag.LenPrefix != 0 || tag.LengthFrom != "" || tag.CString || tag.Charset != "" || tag.Pad != 0 || tag.TrimRight {
+		return errors.New("length of constant is set by it's value, so length and string options can't be used with it")
+	}
+	return nil
+}
+
+// checkBitFieldType checks, that field of type t can hold bits
+func checkBitFieldType(t Type, bits int) error {
+	switch k := t.Kind(); k {
+	case reflect.Bool, reflect.Int, reflect.Uint:
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if bits > t.Bits() {
+			return errors.Errorf("%d bits don't fit in %v", bits, k)
+		}
+	default:
+		return errors.Errorf("bit field can't be %v", k)
+	}
+	if bits < 1 || bits > 64 {
+		return errors.Errorf("invalid bit field width %d", bits)
+	}
+	return nil
+}
+
+// checkAlign checks value of align option
+func checkAlign(align int) error {
+	if align < 1 || align > MaxAlign || align&(align-1) != 0 {
+		return errors.Errorf("invalid alignment %d, it should be power of two up to %d", align, MaxAlign)
+	}
+	return nil
+}
+
+// checkReserved checks count of reserved bytes, set by reserved option
+func checkReserved(n int) error {
+	if n < 1 || n > MaxReserved {
+		return errors.Errorf("invalid count of reserved bytes %d", n)
+	}
+	return nil
+}
+
+// splitTag splits tag options by commas, which are not in quoted values
+func splitTag(tag string) []string {
+	var parts []string
+	quoted, escaped := false, false
+	start := 0
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && tag[i] == '\\':
+			escaped = true
+		case tag[i] == '"':
+			quoted = !quoted
+		case !quoted && tag[i] == ',':
+			parts = append(parts, tag[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, tag[start:])
+}
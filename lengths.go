@@ -0,0 +1,640 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/saturn4er/go-data-to-bytes.v2/internal/schema"
+)
+
+// elemsCodec encodes string bytes or slice elements, which count is stored outside of them
+type elemsCodec interface {
+	encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error)
+	decode(in *input, v reflect.Value, length int, endian binary.ByteOrder) error
+	// valueSize returns length of bytes representation of v
+	valueSize(v reflect.Value) (int, error)
+	// count returns length of v, which is stored outside of it
+	count(v reflect.Value) (int, error)
+}
+
+// stringElemsCodec encodes all bytes of string without any padding
+// Strings with charset are converted by text, their length is counted in code units or in bytes if inBytes is set
+type stringElemsCodec struct {
+	text    *textCodec
+	inBytes bool
+}
+
+func (c stringElemsCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	if c.text == nil {
+		return append(buf, v.String()...), nil
+	}
+	buf, _, err := c.text.appendString(buf, v.String(), -1)
+	return buf, err
+}
+
+func (c stringElemsCodec) valueSize(v reflect.Value) (int, error) {
+	if c.text == nil {
+		return v.Len(), nil
+	}
+	return c.text.size(v.String())
+}
+
+func (c stringElemsCodec) count(v reflect.Value) (int, error) {
+	if c.text == nil {
+		return v.Len(), nil
+	}
+	size, err := c.text.size(v.String())
+	if err != nil || c.inBytes {
+		return size, err
+	}
+	return size / c.text.unit, nil
+}
+
+func (c stringElemsCodec) decode(in *input, v reflect.Value, length int, endian binary.ByteOrder) error {
+	if c.text == nil {
+		bytes, err := in.next(length)
+		if err != nil {
+			return err
+		}
+		setStringBytes(v, bytes)
+		return nil
+	}
+	unit := c.text.unit
+	if c.inBytes && length%unit != 0 {
+		return errors.Errorf("length %d isn't multiple of %s code unit size %d", length, c.text.name, unit)
+	}
+	if !c.inBytes {
+		if length > maxInt/unit {
+			return errors.Errorf("length %d is too big", length)
+		}
+		length *= unit
+	}
+	bytes, err := in.next(length)
+	if err != nil {
+		return err
+	}
+	s, err := c.text.decode(bytes)
+	if err != nil {
+		return err
+	}
+	if v.String() != s {
+		v.SetString(s)
+	}
+	return nil
+}
+
+// sliceElemsCodec encodes all elements of slice without any padding
+type sliceElemsCodec struct {
+	t    reflect.Type
+	elem codec
+}
+
+func (c sliceElemsCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	var err error
+	for i := 0; i < v.Len(); i++ {
+		buf, err = c.elem.encode(buf, v.Index(i), endian)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't convert slice element to bytes")
+		}
+	}
+	return buf, nil
+}
+
+func (c sliceElemsCodec) count(v reflect.Value) (int, error) {
+	return v.Len(), nil
+}
+
+func (c sliceElemsCodec) valueSize(v reflect.Value) (int, error) {
+	if elemSize := c.elem.size(); elemSize >= 0 {
+		return v.Len() * elemSize, nil
+	}
+	var result int
+	for i := 0; i < v.Len(); i++ {
+		size, err := valueSize(c.elem, v.Index(i))
+		if err != nil {
+			return 0, errors.Wrap(err, "can't calculate slice element length")
+		}
+		result += size
+	}
+	return result, nil
+}
+
+func (c sliceElemsCodec) decode(in *input, v reflect.Value, length int, endian binary.ByteOrder) error {
+	// check, that all elements are present before allocating them
+	if elemLength := c.elem.size(); elemLength > 0 {
+		needed := maxInt
+		if length <= maxInt/elemLength {
+			needed = length * elemLength
+		}
+		if err := in.need(needed); err != nil {
+			return err
+		}
+	}
+	capacity := length
+	if available := len(in.rest()); capacity > available {
+		capacity = available
+	}
+	slice := reflect.MakeSlice(c.t, capacity, capacity)
+	var err error
+	for i := 0; i < length; i++ {
+		if i == slice.Len() {
+			slice = reflect.Append(slice, reflect.Zero(c.t.Elem()))
+		}
+		err = c.elem.decode(in, slice.Index(i), endian)
+		if err != nil {
+			if pErr, ok := err.(pathError); ok {
+				return prependIndexPath(pErr, i)
+			}
+			return err
+		}
+	}
+	v.Set(slice.Slice(0, length))
+	return nil
+}
+
+// ptrElemsCodec encodes string or slice, pointer points to. Nil pointers have no elements
+type ptrElemsCodec struct {
+	elemType reflect.Type
+	elem     elemsCodec
+}
+
+func (c ptrElemsCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	if v.IsNil() {
+		return buf, nil
+	}
+	return c.elem.encode(buf, v.Elem(), endian)
+}
+
+func (c ptrElemsCodec) valueSize(v reflect.Value) (int, error) {
+	if v.IsNil() {
+		return 0, nil
+	}
+	return c.elem.valueSize(v.Elem())
+}
+
+func (c ptrElemsCodec) count(v reflect.Value) (int, error) {
+	if v.IsNil() {
+		return 0, nil
+	}
+	return c.elem.count(v.Elem())
+}
+
+func (c ptrElemsCodec) decode(in *input, v reflect.Value, length int, endian binary.ByteOrder) error {
+	if v.IsNil() {
+		v.Set(reflect.New(c.elemType))
+	}
+	return c.elem.decode(in, v.Elem(), length, endian)
+}
+
+// fixedStringCodec encodes string to length bytes, padded with pad bytes
+type fixedStringCodec struct {
+	length int
+	pad    byte
+	// trim makes decoder strip pad bytes from the end, otherwise zero padded strings end before the first zero byte
+	trim bool
+	// strict makes encoder return error for long strings instead of truncating them
+	strict bool
+	// cstring reserves the last byte for zero terminator
+	cstring bool
+	// text converts strings with charset, padUnit is pad character in it
+	text    *textCodec
+	padUnit []byte
+}
+
+func (c fixedStringCodec) size() int {
+	return c.length
+}
+
+func (c fixedStringCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	if c.text != nil {
+		return c.encodeText(buf, v.String())
+	}
+	s := v.String()
+	max := c.length
+	if c.cstring {
+		max--
+	}
+	if len(s) > max {
+		if c.strict {
+			return nil, errors.Errorf("string length %d is greater than %d", len(s), max)
+		}
+		s = s[:max]
+	}
+	buf, b := extend(buf, c.length)
+	n := copy(b, s)
+	if c.pad != 0 {
+		for i := n; i < len(b); i++ {
+			b[i] = c.pad
+		}
+	}
+	return buf, nil
+}
+
+func (c fixedStringCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	b, err := in.next(c.length)
+	if err != nil {
+		return err
+	}
+	if c.text != nil {
+		return c.decodeText(b, v)
+	}
+	switch {
+	case c.trim:
+		b = trimRight(b, c.pad)
+	case c.pad == 0:
+		b = bytesBeforeZero(b)
+	}
+	setStringBytes(v, b)
+	return nil
+}
+
+func (c fixedStringCodec) encodeText(buf []byte, s string) ([]byte, error) {
+	max := c.length
+	if c.cstring {
+		max -= c.text.unit
+	}
+	start := len(buf)
+	buf, truncated, err := c.text.appendString(buf, s, max)
+	if err != nil {
+		return nil, err
+	}
+	if truncated && c.strict {
+		return nil, errors.Errorf("string doesn't fit in %d bytes", max)
+	}
+	for len(buf)-start+len(c.padUnit) <= c.length {
+		buf = append(buf, c.padUnit...)
+	}
+	buf, _ = extend(buf, c.length-(len(buf)-start))
+	return buf, nil
+}
+
+func (c fixedStringCodec) decodeText(b []byte, v reflect.Value) error {
+	unit := c.text.unit
+	switch {
+	case c.trim:
+		for len(b) >= unit && string(b[len(b)-unit:]) == string(c.padUnit) {
+			b = b[:len(b)-unit]
+		}
+	case c.pad == 0:
+		if end := zeroUnitIndex(b, unit); end >= 0 {
+			b = b[:end]
+		}
+	}
+	s, err := c.text.decode(b)
+	if err != nil {
+		return err
+	}
+	if v.String() != s {
+		v.SetString(s)
+	}
+	return nil
+}
+
+// cstringCodec encodes string followed by zero byte or by zero code unit of charset
+type cstringCodec struct {
+	text *textCodec
+}
+
+func (c cstringCodec) size() int {
+	return -1
+}
+
+func (c cstringCodec) valueSize(v reflect.Value) (int, error) {
+	if c.text == nil {
+		return v.Len() + 1, nil
+	}
+	size, err := c.text.size(v.String())
+	return size + c.text.unit, err
+}
+
+func (c cstringCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	s := v.String()
+	if strings.IndexByte(s, 0) >= 0 {
+		return nil, errors.New("cstring can't contain zero byte")
+	}
+	if c.text == nil {
+		buf = append(buf, s...)
+		return append(buf, 0), nil
+	}
+	buf, _, err := c.text.appendString(buf, s, -1)
+	if err != nil {
+		return nil, err
+	}
+	buf, _ = extend(buf, c.text.unit)
+	return buf, nil
+}
+
+func (c cstringCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	unit := c.unit()
+	end := c.indexTerminator(in.rest())
+	// terminator isn't read yet, input reads code units from the stream or returns ShortBufferError
+	// Only the new unit is checked, so bytes before it are scanned once
+	for scanned := len(in.rest()) / unit * unit; end < 0; scanned += unit {
+		if err := in.need(scanned + unit); err != nil {
+			return err
+		}
+		if c.indexTerminator(in.rest()[scanned:scanned+unit]) == 0 {
+			end = scanned
+		}
+	}
+	bytes := in.rest()[:end]
+	in.pos += end + unit
+	if c.text == nil {
+		setStringBytes(v, bytes)
+		return nil
+	}
+	s, err := c.text.decode(bytes)
+	if err != nil {
+		return err
+	}
+	if v.String() != s {
+		v.SetString(s)
+	}
+	return nil
+}
+
+// unit returns size of terminator
+func (c cstringCodec) unit() int {
+	if c.text == nil {
+		return 1
+	}
+	return c.text.unit
+}
+
+// indexTerminator returns index of terminator in bytes or -1 if there's no terminator
+func (c cstringCodec) indexTerminator(bytes []byte) int {
+	if c.text == nil {
+		return indexZero(bytes)
+	}
+	return zeroUnitIndex(bytes, c.text.unit)
+}
+
+// fixedSliceCodec encodes length elements of slice, missing elements are filled with zeros
+type fixedSliceCodec struct {
+	length   int
+	elemType reflect.Type
+	elem     codec
+}
+
+func (c fixedSliceCodec) size() int {
+	elemSize := c.elem.size()
+	if elemSize < 0 {
+		return -1
+	}
+	return c.length * elemSize
+}
+
+func (c fixedSliceCodec) valueSize(v reflect.Value) (int, error) {
+	handleLength := v.Len()
+	if handleLength > c.length {
+		handleLength = c.length
+	}
+	var result int
+	for i := 0; i < handleLength; i++ {
+		size, err := valueSize(c.elem, v.Index(i))
+		if err != nil {
+			return 0, errors.Wrap(err, "can't calculate slice element length")
+		}
+		result += size
+	}
+	if handleLength < c.length {
+		return 0, errors.Errorf("can't calculate slice element type length: type %v has variable length", c.elemType)
+	}
+	return result, nil
+}
+
+func (c fixedSliceCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	handleLength := v.Len()
+	if handleLength > c.length {
+		handleLength = c.length
+	}
+	var err error
+	for i := 0; i < handleLength; i++ {
+		buf, err = c.elem.encode(buf, v.Index(i), endian)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't convert slice element to bytes")
+		}
+	}
+	if handleLength < c.length {
+		typeLen := c.elem.size()
+		if typeLen < 0 {
+			return nil, errors.Errorf("can't calculate slice element type length: type %v has variable length", c.elemType)
+		}
+		buf, _ = extend(buf, typeLen*(c.length-handleLength))
+	}
+	return buf, nil
+}
+
+func (c fixedSliceCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	var err error
+	l := v.Len()
+	for i := 0; i < l; i++ {
+		err = c.elem.decode(in, v.Index(i), endian)
+		if err != nil {
+			if pErr, ok := err.(pathError); ok {
+				return prependIndexPath(pErr, i)
+			}
+			return err
+		}
+	}
+	if l >= c.length {
+		return nil
+	}
+	slice := reflect.MakeSlice(v.Type(), c.length, c.length)
+	reflect.Copy(slice, v)
+	for i := l; i < c.length; i++ {
+		err = c.elem.decode(in, slice.Index(i), endian)
+		if err != nil {
+			if pErr, ok := err.(pathError); ok {
+				return prependIndexPath(pErr, i)
+			}
+			return err
+		}
+	}
+	v.Set(slice)
+	return nil
+}
+
+// prefixedCodec encodes string or slice after it's length, which takes prefix bytes or is a varint
+type prefixedCodec struct {
+	// prefix is the size of length in bytes or schema.LenPrefixVarint
+	prefix int
+	// max is the max length, zero means that length is not limited
+	max   int
+	elems elemsCodec
+}
+
+func (c prefixedCodec) size() int {
+	return -1
+}
+
+func (c prefixedCodec) valueSize(v reflect.Value) (int, error) {
+	size, err := c.elems.valueSize(v)
+	if err != nil {
+		return 0, err
+	}
+	if c.prefix != schema.LenPrefixVarint {
+		return c.prefix + size, nil
+	}
+	length, err := c.elems.count(v)
+	if err != nil {
+		return 0, err
+	}
+	return uvarintSize(uint64(length)) + size, nil
+}
+
+func (c prefixedCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	length, err := c.elems.count(v)
+	if err != nil {
+		return nil, err
+	}
+	if c.max != 0 && length > c.max {
+		return nil, errors.Errorf("length %d is greater than max length %d", length, c.max)
+	}
+	if c.prefix == schema.LenPrefixVarint {
+		buf = appendUvarint(buf, uint64(length))
+	} else {
+		buf, err = appendUint(buf, uint64(length), c.prefix, endian)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.elems.encode(buf, v, endian)
+}
+
+func (c prefixedCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	var length uint64
+	var err error
+	if c.prefix == schema.LenPrefixVarint {
+		length, err = readUvarint(in)
+	} else {
+		length, err = readUint(in, c.prefix, endian)
+	}
+	if err != nil {
+		return err
+	}
+	if c.max != 0 && length > uint64(c.max) {
+		return errors.Errorf("length %d is greater than max length %d", length, c.max)
+	}
+	if length > uint64(maxInt) {
+		return errors.Errorf("length %d is too big", length)
+	}
+	return c.elems.decode(in, v, int(length), endian)
+}
+
+// lengthFromCodec encodes field, which length is stored in another field. It works with the whole struct value
+type lengthFromCodec struct {
+	index    []int
+	from     []int
+	fromName string
+	autoFill bool
+	max      int
+	elems    elemsCodec
+}
+
+func (c *lengthFromCodec) size() int {
+	return -1
+}
+
+func (c *lengthFromCodec) valueSize(v reflect.Value) (int, error) {
+	return c.elems.valueSize(structField(v, c.index))
+}
+
+func (c *lengthFromCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	fv := structField(v, c.index)
+	length, err := c.elems.count(fv)
+	if err != nil {
+		return nil, err
+	}
+	if !c.autoFill {
+		expected, err := lengthFieldValue(structField(v, c.from))
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't get length from %v field", c.fromName)
+		}
+		if length != expected {
+			return nil, errors.Errorf("length %d doesn't match %v field value %d", length, c.fromName, expected)
+		}
+	}
+	if c.max != 0 && length > c.max {
+		return nil, errors.Errorf("length %d is greater than max length %d", length, c.max)
+	}
+	return c.elems.encode(buf, fv, endian)
+}
+
+func (c *lengthFromCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	length, err := lengthFieldValue(structField(v, c.from))
+	if err != nil {
+		return errors.Wrapf(err, "can't get length from %v field", c.fromName)
+	}
+	if c.max != 0 && length > c.max {
+		return errors.Errorf("length %d is greater than max length %d", length, c.max)
+	}
+	return c.elems.decode(in, structField(v, c.index), length, endian)
+}
+
+// lengthFieldCodec encodes length of autofill fields, which refer to it, instead of it's value
+// It works with the whole struct value
+type lengthFieldCodec struct {
+	index      []int
+	dependents [][]int
+	names      []string
+	// counts return lengths of dependents
+	counts    []elemsCodec
+	valueType reflect.Type
+	// value encodes length, field decodes field as usual
+	value codec
+	field codec
+}
+
+func (c *lengthFieldCodec) size() int {
+	return c.field.size()
+}
+
+// valueSize returns length of the filled value, because size of varints depends on it
+func (c *lengthFieldCodec) valueSize(v reflect.Value) (int, error) {
+	if size := c.field.size(); size >= 0 {
+		return size, nil
+	}
+	value, err := c.lengthValue(v)
+	if err != nil {
+		return 0, err
+	}
+	return valueSize(c.value, value)
+}
+
+func (c *lengthFieldCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	value, err := c.lengthValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return c.value.encode(buf, value, endian)
+}
+
+// lengthValue returns value of length field with length of dependents
+func (c *lengthFieldCodec) lengthValue(v reflect.Value) (reflect.Value, error) {
+	length, err := c.counts[0].count(structField(v, c.dependents[0]))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	for k, j := range c.dependents[1:] {
+		l, err := c.counts[k+1].count(structField(v, j))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if l != length {
+			return reflect.Value{}, errors.Errorf("%v and %v fields have different length: %d and %d",
+				c.names[0], c.names[k+1], length, l)
+		}
+	}
+	value := reflect.New(c.valueType).Elem()
+	if err := setLengthFieldValue(value, length); err != nil {
+		return reflect.Value{}, err
+	}
+	return value, nil
+}
+
+func (c *lengthFieldCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	return c.field.decode(in, structField(v, c.index), endian)
+}
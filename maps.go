@@ -0,0 +1,201 @@
+package d2b
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// mapElemsCodec encodes map entries as key/value pairs without any padding
+// If sorted is set, entries are written in order of their keys, so the same map is always encoded to the same bytes
+type mapElemsCodec struct {
+	t      reflect.Type
+	key    codec
+	value  codec
+	sorted bool
+}
+
+func (c mapElemsCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	if !c.sorted {
+		var err error
+		for _, key := range v.MapKeys() {
+			buf, err = c.encodeEntry(buf, key, v.MapIndex(key), endian)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+	keys, err := c.sortedKeys(v, endian)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		buf, err = c.encodeEntry(buf, key, v.MapIndex(key), endian)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+func (c mapElemsCodec) encodeEntry(buf []byte, key, value reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	buf, err := c.key.encode(buf, key, endian)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't convert map key to bytes")
+	}
+	buf, err = c.value.encode(buf, value, endian)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't convert map value to bytes")
+	}
+	return buf, nil
+}
+
+// sortedKeys returns keys of map in ascending order
+// Keys, which have no natural order (arrays, structs), are ordered by their bytes representation
+func (c mapElemsCodec) sortedKeys(v reflect.Value, endian binary.ByteOrder) ([]reflect.Value, error) {
+	keys := v.MapKeys()
+	if less := keyLess(c.t.Key().Kind()); less != nil {
+		sort.Sort(keysByLess{keys: keys, less: less})
+		return keys, nil
+	}
+	encoded := make([][]byte, len(keys))
+	for i, key := range keys {
+		b, err := c.key.encode(nil, key, endian)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't convert map key to bytes")
+		}
+		encoded[i] = b
+	}
+	sort.Sort(keysByBytes{keys: keys, encoded: encoded})
+	return keys, nil
+}
+
+// keyLess returns function, which compares map keys of kind k, or nil, if such keys have no natural order
+func keyLess(k reflect.Kind) func(a, b reflect.Value) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b reflect.Value) bool { return a.Int() < b.Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b reflect.Value) bool { return a.Uint() < b.Uint() }
+	case reflect.Float32, reflect.Float64:
+		// NaN keys go first, so their order doesn't depend on the order of iteration
+		return func(a, b reflect.Value) bool {
+			x, y := a.Float(), b.Float()
+			return x < y || math.IsNaN(x) && !math.IsNaN(y)
+		}
+	case reflect.String:
+		return func(a, b reflect.Value) bool { return a.String() < b.String() }
+	case reflect.Bool:
+		return func(a, b reflect.Value) bool { return !a.Bool() && b.Bool() }
+	}
+	return nil
+}
+
+// keysByLess sorts map keys, which have natural order
+type keysByLess struct {
+	keys []reflect.Value
+	less func(a, b reflect.Value) bool
+}
+
+func (s keysByLess) Len() int {
+	return len(s.keys)
+}
+
+func (s keysByLess) Less(i, j int) bool {
+	return s.less(s.keys[i], s.keys[j])
+}
+
+func (s keysByLess) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+// keysByBytes sorts map keys by their bytes representation
+type keysByBytes struct {
+	keys    []reflect.Value
+	encoded [][]byte
+}
+
+func (s keysByBytes) Len() int {
+	return len(s.keys)
+}
+
+func (s keysByBytes) Less(i, j int) bool {
+	return bytes.Compare(s.encoded[i], s.encoded[j]) < 0
+}
+
+func (s keysByBytes) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.encoded[i], s.encoded[j] = s.encoded[j], s.encoded[i]
+}
+
+func (c mapElemsCodec) count(v reflect.Value) (int, error) {
+	return v.Len(), nil
+}
+
+// entrySize returns length of key/value pair or -1, if it's variable
+func (c mapElemsCodec) entrySize() int {
+	keySize, valueSize := c.key.size(), c.value.size()
+	if keySize < 0 || valueSize < 0 {
+		return -1
+	}
+	return keySize + valueSize
+}
+
+func (c mapElemsCodec) valueSize(v reflect.Value) (int, error) {
+	if entrySize := c.entrySize(); entrySize >= 0 {
+		return v.Len() * entrySize, nil
+	}
+	var result int
+	for _, key := range v.MapKeys() {
+		keySize, err := valueSize(c.key, key)
+		if err != nil {
+			return 0, errors.Wrap(err, "can't calculate map key length")
+		}
+		size, err := valueSize(c.value, v.MapIndex(key))
+		if err != nil {
+			return 0, errors.Wrap(err, "can't calculate map value length")
+		}
+		result += keySize + size
+	}
+	return result, nil
+}
+
+func (c mapElemsCodec) decode(in *input, v reflect.Value, length int, endian binary.ByteOrder) error {
+	// check, that all entries are present before allocating them
+	if entrySize := c.entrySize(); entrySize > 0 {
+		needed := maxInt
+		if length <= maxInt/entrySize {
+			needed = length * entrySize
+		}
+		if err := in.need(needed); err != nil {
+			return err
+		}
+	}
+	m := reflect.MakeMap(c.t)
+	var err error
+	for i := 0; i < length; i++ {
+		key := reflect.New(c.t.Key()).Elem()
+		value := reflect.New(c.t.Elem()).Elem()
+		err = c.key.decode(in, key, endian)
+		if err == nil {
+			if m.MapIndex(key).IsValid() {
+				return errors.Errorf("duplicate map key %v", key.Interface())
+			}
+			err = c.value.decode(in, value, endian)
+		}
+		if err != nil {
+			if pErr, ok := err.(pathError); ok {
+				return prependIndexPath(pErr, i)
+			}
+			return err
+		}
+		m.SetMapIndex(key, value)
+	}
+	v.Set(m)
+	return nil
+}
@@ -0,0 +1,151 @@
+package d2b
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMaps(t *testing.T) {
+	Convey("Test maps", t, func() {
+		Convey("Should encode sorted map entries after their count", func() {
+			type Struct struct {
+				A map[uint16]uint32 `d2b:"lenprefix:uint16,sorted"`
+			}
+			data := Struct{A: map[uint16]uint32{3: 30, 0xFFFF: 0xDEADBEEF, 1: 10, 0x8000: 0x18000, 2: 20}}
+			expected := []byte{
+				5, 0,
+				1, 0, 10, 0, 0, 0,
+				2, 0, 20, 0, 0, 0,
+				3, 0, 30, 0, 0, 0,
+				0x00, 0x80, 0x00, 0x80, 0x01, 0x00,
+				0xFF, 0xFF, 0xEF, 0xBE, 0xAD, 0xDE,
+			}
+			for i := 0; i < 10; i++ {
+				encoded, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldBeNil)
+				So(encoded, ShouldResemble, expected)
+			}
+			size, err := Size(data)
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, len(expected))
+
+			var result Struct
+			So(Decode(expected, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+		Convey("Should encode unsorted map entries in any order", func() {
+			type Struct struct {
+				A map[uint8]int8 `d2b:"lenprefix:uint8"`
+			}
+			data := Struct{A: map[uint8]int8{1: -1, 2: -2, 3: -3}}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldHaveLength, 7)
+			So(encoded[0], ShouldEqual, 3)
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+		Convey("Should sort keys of different types", func() {
+			type Key struct {
+				A, B uint8
+			}
+			type Struct struct {
+				A map[int16]bool   `d2b:"lenprefix:uint8,sorted"`
+				B map[float32]int8 `d2b:"lenprefix:uint8,sorted"`
+				C map[Key]uint8    `d2b:"lenprefix:uint8,sorted"`
+				D map[bool]uint8   `d2b:"lenprefix:uint8,sorted"`
+			}
+			data := Struct{
+				A: map[int16]bool{1: true, -1: false},
+				B: map[float32]int8{2: 1, -0.5: 2},
+				C: map[Key]uint8{{2, 1}: 1, {1, 2}: 2},
+				D: map[bool]uint8{true: 1, false: 0},
+			}
+			encoded, err := Encode(data, binary.BigEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{
+				2, 0xFF, 0xFF, 0, 0, 1, 1,
+				2, 0xBF, 0, 0, 0, 2, 0x40, 0, 0, 0, 1,
+				2, 1, 2, 2, 2, 1, 1,
+				2, 0, 0, 1, 1,
+			})
+			var result Struct
+			So(Decode(encoded, binary.BigEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+		Convey("Should encode maps with length from another field", func() {
+			type Struct struct {
+				N uint8
+				A map[uint8]uint8  `d2b:"lengthfrom:N,autofill,sorted"`
+				B *map[uint8]uint8 `d2b:"lenprefix:uint8,sorted"`
+			}
+			encoded, err := Encode(Struct{A: map[uint8]uint8{2: 3, 1: 2}}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{2, 1, 2, 2, 3, 0})
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result.N, ShouldEqual, 2)
+			So(result.A, ShouldResemble, map[uint8]uint8{1: 2, 2: 3})
+			So(*result.B, ShouldResemble, map[uint8]uint8{})
+		})
+		Convey("Should decode maps of variable size values", func() {
+			type Value struct {
+				S []uint8 `d2b:"lenprefix:uint8"`
+			}
+			type Struct struct {
+				A map[uint8]Value `d2b:"lenprefix:uint8,sorted"`
+			}
+			data := Struct{A: map[uint8]Value{1: {S: []uint8{1, 2}}, 2: {S: []uint8{}}}}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{2, 1, 2, 1, 2, 2, 0})
+			size, err := Size(data)
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, len(encoded))
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+
+			err = Decode(encoded[:4], binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "A[0].S", Needed: 2, Available: 1})
+
+			var streamed Struct
+			So(NewDecoder(bytes.NewReader(encoded), binary.LittleEndian).Decode(&streamed), ShouldBeNil)
+			So(streamed, ShouldResemble, data)
+		})
+		Convey("Should return error if map can't be decoded", func() {
+			type Struct struct {
+				A map[uint8]uint16 `d2b:"lenprefix:uint8,length:2"`
+			}
+			var result Struct
+			err := Decode([]byte{2, 1, 0, 0}, binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "A", Needed: 6, Available: 3})
+			So(Decode([]byte{2, 1, 0, 0, 1, 0, 0}, binary.LittleEndian, &result), ShouldNotBeNil)
+			So(Decode([]byte{3, 1, 0, 0, 2, 0, 0, 3, 0, 0}, binary.LittleEndian, &result), ShouldNotBeNil)
+			_, err = Encode(Struct{A: map[uint8]uint16{1: 1, 2: 2, 3: 3}}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should return error if map options are invalid", func() {
+			type WithoutPrefix struct {
+				A map[uint8]uint8 `d2b:"length:2"`
+			}
+			type SortedSlice struct {
+				A []uint8 `d2b:"lenprefix:uint8,sorted"`
+			}
+			type StringKey struct {
+				A map[string]uint8 `d2b:"lenprefix:uint8"`
+			}
+			for _, data := range []interface{}{WithoutPrefix{}, SortedSlice{}, StringKey{}} {
+				_, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -0,0 +1,174 @@
+package d2b
+
+import (
+	"encoding"
+	"encoding/binary"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Marshaler is the interface implemented by types that can encode themselves to bytes
+type Marshaler interface {
+	MarshalD2B(endian binary.ByteOrder) ([]byte, error)
+}
+
+// Unmarshaler is the interface implemented by types that can decode themselves from bytes
+// UnmarshalD2B receives all bytes left in input and returns count of bytes it has used
+// Decoder calls it again with more bytes from the stream, if it returns ShortBufferError
+type Unmarshaler interface {
+	UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error)
+}
+
+// FixedSizer is the interface implemented by custom types, which bytes representation has fixed length
+// It's required to encode nil pointers to such types, to decode them with Decoder and to decode
+// types which implement only encoding.BinaryUnmarshaler
+type FixedSizer interface {
+	SizeD2B() int
+}
+
+var (
+	marshalerType         = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType       = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	binaryMarshalerType   = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	fixedSizerType        = reflect.TypeOf((*FixedSizer)(nil)).Elem()
+)
+
+// implements checks if type or pointer to type implements interface
+func implements(t reflect.Type, iface reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		return false
+	}
+	return t.Implements(iface) || reflect.PtrTo(t).Implements(iface)
+}
+
+// isCustomType checks if type has it's own bytes representation
+func isCustomType(t reflect.Type) bool {
+	return implements(t, marshalerType) || implements(t, unmarshalerType) ||
+		implements(t, binaryMarshalerType) || implements(t, binaryUnmarshalerType)
+}
+
+// interfaceOf returns v or pointer to v as interface{}, depending on which one implements iface
+func interfaceOf(v reflect.Value, iface reflect.Type) interface{} {
+	if v.Type().Implements(iface) {
+		return v.Interface()
+	}
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	return ptr.Interface()
+}
+
+// getCustomTypeBytesLength returns length of custom type, reported by FixedSizer
+func getCustomTypeBytesLength(t reflect.Type) (int, error) {
+	if !implements(t, fixedSizerType) {
+		return 0, errors.Errorf("type %v doesn't implement FixedSizer", t)
+	}
+	return interfaceOf(reflect.New(t).Elem(), fixedSizerType).(FixedSizer).SizeD2B(), nil
+}
+
+// customCodec encodes values, which implement Marshaler or encoding.BinaryMarshaler
+type customCodec struct {
+	t      reflect.Type
+	length int
+}
+
+func newCustomCodec(t reflect.Type) customCodec {
+	length, err := getCustomTypeBytesLength(t)
+	if err != nil {
+		length = -1
+	}
+	return customCodec{t: t, length: length}
+}
+
+func (c customCodec) size() int {
+	return c.length
+}
+
+func (c customCodec) valueSize(v reflect.Value) (int, error) {
+	b, err := c.encode(nil, v, binary.LittleEndian)
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (c customCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	var b []byte
+	var err error
+	switch {
+	case implements(c.t, marshalerType):
+		b, err = interfaceOf(v, marshalerType).(Marshaler).MarshalD2B(endian)
+	case implements(c.t, binaryMarshalerType):
+		b, err = interfaceOf(v, binaryMarshalerType).(encoding.BinaryMarshaler).MarshalBinary()
+	default:
+		return nil, errors.Errorf("type %v doesn't implement Marshaler", c.t)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't marshal %v", c.t)
+	}
+	if c.length >= 0 && len(b) != c.length {
+		return nil, errors.Errorf("%v marshaled to %d bytes, but it's size is %d", c.t, len(b), c.length)
+	}
+	return append(buf, b...), nil
+}
+
+func (c customCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	if c.length >= 0 {
+		if err := in.need(c.length); err != nil {
+			return err
+		}
+	}
+	switch {
+	case implements(c.t, unmarshalerType):
+		unmarshaler := interfaceOf(v, unmarshalerType).(Unmarshaler)
+		data := c.data(in)
+		n, err := unmarshaler.UnmarshalD2B(data, endian)
+		// values with variable length are decoded again, when input reads missing bytes from the stream
+		for in.stream != nil {
+			sbErr, ok := err.(*ShortBufferError)
+			if !ok || sbErr.Needed <= sbErr.Available {
+				break
+			}
+			if err = in.need(len(data) + sbErr.Needed - sbErr.Available); err != nil {
+				return err
+			}
+			data = c.data(in)
+			n, err = unmarshaler.UnmarshalD2B(data, endian)
+		}
+		if err != nil {
+			if pErr, ok := err.(pathError); ok {
+				return pErr
+			}
+			return errors.Wrapf(err, "can't unmarshal %v", c.t)
+		}
+		if n < 0 || n > len(data) {
+			return errors.Errorf("%v unmarshaler used %d bytes of %d", c.t, n, len(data))
+		}
+		in.pos += n
+		return nil
+	case implements(c.t, binaryUnmarshalerType):
+		if c.length < 0 {
+			return errors.Errorf("type %v should implement FixedSizer to be decoded with UnmarshalBinary", c.t)
+		}
+		data := c.data(in)
+		err := interfaceOf(v, binaryUnmarshalerType).(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+		if err != nil {
+			return errors.Wrapf(err, "can't unmarshal %v", c.t)
+		}
+		in.pos += len(data)
+		return nil
+	}
+	return errors.Errorf("type %v doesn't implement Unmarshaler", c.t)
+}
+
+// data returns bytes of value at the input position. Values with variable length get all available bytes
+func (c customCodec) data(in *input) []byte {
+	if c.length >= 0 {
+		return in.rest()[:c.length]
+	}
+	return in.rest()
+}
@@ -0,0 +1,160 @@
+package d2b
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testIPv4 uint32
+
+func (ip testIPv4) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(ip))
+	return b, nil
+}
+
+func (ip *testIPv4) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	*ip = testIPv4(binary.BigEndian.Uint32(data))
+	return 4, nil
+}
+
+func (ip testIPv4) SizeD2B() int {
+	return 4
+}
+
+// testBCDTime is hours and minutes stored as two BCD bytes
+type testBCDTime struct {
+	Hours   int
+	Minutes int
+}
+
+func (t testBCDTime) MarshalBinary() ([]byte, error) {
+	if t.Hours > 99 || t.Minutes > 99 {
+		return nil, errors.New("value is too big")
+	}
+	return []byte{byte(t.Hours/10<<4 | t.Hours%10), byte(t.Minutes/10<<4 | t.Minutes%10)}, nil
+}
+
+func (t *testBCDTime) UnmarshalBinary(data []byte) error {
+	t.Hours = int(data[0]>>4)*10 + int(data[0]&0xF)
+	t.Minutes = int(data[1]>>4)*10 + int(data[1]&0xF)
+	return nil
+}
+
+func (t *testBCDTime) SizeD2B() int {
+	return 2
+}
+
+// testPascalString is a string prefixed by one byte length
+type testPascalString string
+
+func (s testPascalString) MarshalD2B(endian binary.ByteOrder) ([]byte, error) {
+	return append([]byte{byte(len(s))}, s...), nil
+}
+
+func (s *testPascalString) UnmarshalD2B(data []byte, endian binary.ByteOrder) (int, error) {
+	if len(data) < 1 || len(data) < int(data[0])+1 {
+		return 0, errors.New("not enough data")
+	}
+	*s = testPascalString(data[1 : data[0]+1])
+	return int(data[0]) + 1, nil
+}
+
+type testBinaryOnly [3]byte
+
+func (b testBinaryOnly) MarshalBinary() ([]byte, error) {
+	return b[:], nil
+}
+
+func (b *testBinaryOnly) UnmarshalBinary(data []byte) error {
+	copy(b[:], data)
+	return nil
+}
+
+func TestMarshaler(t *testing.T) {
+	Convey("Test custom Marshaler/Unmarshaler types", t, func() {
+		type Frame struct {
+			Addr  testIPv4
+			Time  testBCDTime
+			Name  testPascalString
+			Addrs [2]testIPv4
+			Last  *testIPv4
+		}
+		frame := Frame{
+			Addr:  testIPv4(0xC0A80001),
+			Time:  testBCDTime{Hours: 12, Minutes: 59},
+			Name:  "abc",
+			Addrs: [2]testIPv4{1, 2},
+		}
+		frameBytes := []byte{
+			192, 168, 0, 1,
+			0x12, 0x59,
+			3, 'a', 'b', 'c',
+			0, 0, 0, 1, 0, 0, 0, 2,
+			0, 0, 0, 0,
+		}
+		Convey("Should encode custom types", func() {
+			b, err := Encode(frame, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, frameBytes)
+			b, err = Encode(&frame, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, frameBytes)
+		})
+		Convey("Should decode custom types", func() {
+			var result Frame
+			err := Decode(frameBytes, binary.LittleEndian, &result)
+			So(err, ShouldBeNil)
+			So(*result.Last, ShouldEqual, 0)
+			result.Last = nil
+			So(result, ShouldResemble, frame)
+		})
+		Convey("Should calculate length of fixed size custom types", func() {
+			type Fixed struct {
+				Addr testIPv4
+				Time *testBCDTime
+			}
+			length, err := getTypeBytesLength(reflect.TypeOf(Fixed{}), Options{})
+			So(err, ShouldBeNil)
+			So(length, ShouldEqual, 6)
+
+			var fixed Fixed
+			d := NewDecoder(bytes.NewReader([]byte{1, 2, 3, 4, 0x23, 0x01, 5}), binary.LittleEndian)
+			So(d.Decode(&fixed), ShouldBeNil)
+			So(fixed.Addr, ShouldEqual, 0x01020304)
+			So(*fixed.Time, ShouldResemble, testBCDTime{Hours: 23, Minutes: 1})
+		})
+		Convey("Should return error if length of custom type is unknown", func() {
+			_, err := getTypeBytesLength(reflect.TypeOf(testPascalString("")), Options{})
+			So(err, ShouldNotBeNil)
+			var name *testPascalString
+			_, err = Encode(name, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should return marshaling errors", func() {
+			_, err := Encode(testBCDTime{Hours: 100}, binary.LittleEndian)
+			So(err, ShouldNotBeNil)
+			var name testPascalString
+			err = Decode([]byte{5, 'a'}, binary.LittleEndian, &name)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should return ShortBufferError for fixed size custom types", func() {
+			var result Frame
+			err := Decode(frameBytes[:5], binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "Time", Needed: 2, Available: 1})
+		})
+		Convey("Should return error if encoding.BinaryUnmarshaler has no fixed size", func() {
+			var result testBinaryOnly
+			b, err := Encode(testBinaryOnly{1, 2, 3}, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, []byte{1, 2, 3})
+			err = Decode(b, binary.LittleEndian, &result)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
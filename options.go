@@ -0,0 +1,23 @@
+package d2b
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/saturn4er/go-data-to-bytes.v2/internal/schema"
+)
+
+// Options configures encoding and decoding
+type Options struct {
+	// IntSize is the size in bytes (1, 2, 4 or 8) of int and uint values, which don't have size tag
+	// Zero means, that such values are not supported
+	IntSize int
+	// SkipUnexported makes unexported struct fields to be skipped while encoding and decoding
+	// By default they are encoded and decoded like exported ones
+	SkipUnexported bool
+}
+
+func (o Options) validate() error {
+	if o.IntSize != 0 && !schema.IsValidIntSize(o.IntSize) {
+		return errors.Errorf("invalid int size %d", o.IntSize)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package d2b
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOptions(t *testing.T) {
+	Convey("Test Options", t, func() {
+		type Struct struct {
+			A int
+			B uint `d2b:"size:1"`
+			C [2]int
+		}
+		opts := Options{IntSize: 2}
+		Convey("Should use default int size for untagged int fields", func() {
+			b, err := EncodeWithOptions(Struct{A: -2, B: 3, C: [2]int{4, 5}}, binary.BigEndian, opts)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, []byte{255, 254, 3, 0, 4, 0, 5})
+
+			var result Struct
+			So(DecodeWithOptions(b, binary.BigEndian, &result, opts), ShouldBeNil)
+			So(result, ShouldResemble, Struct{A: -2, B: 3, C: [2]int{4, 5}})
+		})
+		Convey("Should use options in Encoder and Decoder", func() {
+			var buf bytes.Buffer
+			e := NewEncoder(&buf, binary.LittleEndian)
+			So(e.Encode(Struct{A: 1}), ShouldNotBeNil)
+			buf.Reset()
+			e.SetOptions(opts)
+			So(e.Encode(Struct{A: 1, B: 2}), ShouldBeNil)
+			So(buf.Bytes(), ShouldResemble, []byte{1, 0, 2, 0, 0, 0, 0})
+
+			d := NewDecoder(&buf, binary.LittleEndian)
+			d.SetOptions(opts)
+			var result Struct
+			So(d.Decode(&result), ShouldBeNil)
+			So(result, ShouldResemble, Struct{A: 1, B: 2})
+			So(buf.Len(), ShouldEqual, 0)
+		})
+		Convey("Should check range of untagged int values", func() {
+			_, err := EncodeWithOptions(Struct{A: 1 << 15}, binary.BigEndian, opts)
+			So(err, ShouldNotBeNil)
+			var i uint
+			err = DecodeWithOptions([]byte{1, 0, 0, 0, 0, 0, 0, 0}, binary.BigEndian, &i, Options{IntSize: 8})
+			So(err, ShouldBeNil)
+			So(i, ShouldEqual, 1<<56)
+		})
+		Convey("Should return error if options are invalid", func() {
+			_, err := EncodeWithOptions(Struct{}, binary.BigEndian, Options{IntSize: 3})
+			So(err, ShouldNotBeNil)
+			err = DecodeWithOptions([]byte{}, binary.BigEndian, &Struct{}, Options{IntSize: 3})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,75 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSize(t *testing.T) {
+	Convey("Test SizeOf and Size", t, func() {
+		Convey("Should return size of fixed length types", func() {
+			type Struct struct {
+				A uint16
+				B string  `d2b:"length:5"`
+				C []int32 `d2b:"length:2"`
+				D *[2]bool
+				E uint8 `d2b:"-"`
+			}
+			size, err := SizeOf(reflect.TypeOf(Struct{}))
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, 17)
+			size, err = Size(&Struct{})
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, 17)
+		})
+		Convey("Should return error for types with variable length", func() {
+			type Struct struct {
+				A string `d2b:"lenprefix:uint8"`
+			}
+			_, err := SizeOf(reflect.TypeOf(Struct{}))
+			So(err, ShouldNotBeNil)
+			_, err = SizeOf(reflect.TypeOf(int(0)))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Should calculate size of values with variable length fields", func() {
+			type Item struct {
+				ID   uint8
+				Name string `d2b:"lenprefix:uint16"`
+			}
+			type Struct struct {
+				Count   uint8
+				Items   []Item  `d2b:"lengthfrom:Count,autofill"`
+				Comment *string `d2b:"lenprefix:uint8"`
+				Custom  testPascalString
+			}
+			comment := "hello"
+			data := Struct{
+				Items:   []Item{{ID: 1, Name: "a"}, {ID: 2, Name: "bcd"}},
+				Comment: &comment,
+				Custom:  "xy",
+			}
+			bytes, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			size, err := Size(data)
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, len(bytes))
+			size, err = Size(&Struct{})
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, 3)
+		})
+		Convey("Should return error if size can't be calculated", func() {
+			type Struct struct {
+				A []testPascalString `d2b:"length:2"`
+			}
+			_, err := Size(Struct{A: []testPascalString{"a"}})
+			So(err, ShouldNotBeNil)
+			_, err = Size(nil)
+			So(err, ShouldNotBeNil)
+			_, err = Size(int(1))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -2,63 +2,50 @@ package d2b
 
 import (
 	"reflect"
-	"strconv"
-	"strings"
-
 	"sync"
 
 	"github.com/pkg/errors"
+	"gopkg.in/saturn4er/go-data-to-bytes.v2/internal/schema"
 )
 
 var structsTagsMx sync.RWMutex
-var structsTags = make(map[reflect.Type][]*structFieldTag)
-
-type structFieldTag struct {
-	Length int
-	Skip   bool
-}
+var structsTags = make(map[reflect.Type]*structFields)
 
-func parseStructFieldTag(field reflect.StructField) (*structFieldTag, error) {
-	result := new(structFieldTag)
-	tag := field.Tag.Get("d2b")
-	parts := strings.Split(tag, ",")
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "-" {
-			result.Skip = true
-			continue
+// tagParser parses tags of structs, it checks charsets and constants, which d2b can encode
+var tagParser = &schema.Parser{
+	Charset: func(name string) (int, error) {
+		charset, ok := LookupCharset(name)
+		if !ok {
+			return 0, errors.Errorf("unknown charset %s", name)
 		}
-		if strings.HasPrefix(part, "length:") {
-			sLength := strings.TrimPrefix(part, "length:")
-			length, err := strconv.Atoi(sLength)
-			if err != nil {
-				return nil, err
-			}
-			result.Length = length
-			continue
-		}
-	}
-	return result, nil
+		return charset.UnitSize(), nil
+	},
+	Const: func(literal string, t schema.Type) error {
+		_, err := parseConst(literal, t.(reflectType).Type)
+		return err
+	},
 }
 
-func getStructTags(structType reflect.Type) ([]*structFieldTag, error) {
+// getStructFields returns fields of struct with their tags
+func getStructFields(structType reflect.Type) (*structFields, error) {
 	structsTagsMx.RLock()
-	if tags, ok := structsTags[structType]; ok {
+	if result, ok := structsTags[structType]; ok {
 		structsTagsMx.RUnlock()
-		return tags, nil
+		return result, nil
 	}
 	structsTagsMx.RUnlock()
 	structsTagsMx.Lock()
 	defer structsTagsMx.Unlock()
-	tags := make([]*structFieldTag, structType.NumField())
-	for i := 0; i < structType.NumField(); i++ {
-		ft := structType.Field(i)
-		tag, err := parseStructFieldTag(structType.Field(i))
-		if err != nil {
-			return nil, errors.Wrapf(err, "%v field tag error", ft.Name)
-		}
-		tags[i] = tag
+	parsed, err := tagParser.ParseStruct(reflectType{structType})
+	if err != nil {
+		return nil, err
 	}
-	structsTags[structType] = tags
-	return structsTags[structType], nil
+	fields := make([]reflect.StructField, len(parsed.Fields))
+	for i, field := range parsed.Fields {
+		fields[i] = structType.FieldByIndex(field.Index)
+		fields[i].Index = field.Index
+	}
+	result := &structFields{fields: fields, tags: parsed.Tags, parsed: parsed}
+	structsTags[structType] = result
+	return result, nil
 }
@@ -0,0 +1,107 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestElemOptions(t *testing.T) {
+	Convey("Test options of elements", t, func() {
+		Convey("Should encode arrays and slices of strings", func() {
+			type Struct struct {
+				A [2]string  `d2b:"elem.length:3"`
+				B []string   `d2b:"length:2,elem.length:2,elem.pad:space,elem.trim:right"`
+				C []string   `d2b:"lenprefix:uint8,elem.lenprefix:uint8"`
+				D *[]string  `d2b:"lenprefix:uint8,elem.cstring"`
+				E [][]uint16 `d2b:"lenprefix:uint8,elem.lenprefix:uint8"`
+				F [][]string `d2b:"length:1,elem.length:2,elem.elem.length:1"`
+			}
+			d := []string{"a", "bc"}
+			data := Struct{
+				A: [2]string{"ab", "c"},
+				B: []string{"x", "yz"},
+				C: []string{"", "abc"},
+				D: &d,
+				E: [][]uint16{{1}, {}},
+				F: [][]string{{"a", "b"}},
+			}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{
+				'a', 'b', 0, 'c', 0, 0,
+				'x', ' ', 'y', 'z',
+				2, 0, 3, 'a', 'b', 'c',
+				2, 'a', 0, 'b', 'c', 0,
+				2, 1, 1, 0, 0,
+				'a', 'b',
+			})
+			size, err := Size(data)
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, len(encoded))
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+
+			err = Decode(encoded[:20], binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "D[1]", Needed: 2, Available: 1})
+		})
+		Convey("Should push options down to map keys and values", func() {
+			type Struct struct {
+				A map[string]int     `d2b:"lenprefix:uint8,sorted,key.lenprefix:uint8,elem.size:2"`
+				B map[uint8][]string `d2b:"lenprefix:uint8,elem.length:2,elem.elem.length:1"`
+			}
+			data := Struct{A: map[string]int{"b": 2, "a": -1}, B: map[uint8][]string{7: {"x", "y"}}}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{2, 1, 'a', 0xFF, 0xFF, 1, 'b', 2, 0, 1, 7, 'x', 'y'})
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+		Convey("Should apply element options to types of elements", func() {
+			type Struct struct {
+				A []float32 `d2b:"lenprefix:uint8,elem.float16"`
+				B [2]bool   `d2b:"elem.bool:strict"`
+				C []string  `d2b:"length:1,elem.length:2,elem.charset:utf16le"`
+			}
+			data := Struct{A: []float32{1}, B: [2]bool{true, false}, C: []string{"é"}}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(encoded, ShouldResemble, []byte{1, 0, 0x3C, 1, 0, 0xE9, 0, 0, 0})
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+			So(Decode([]byte{1, 0, 0x3C, 2, 0, 0, 0, 0, 0}, binary.LittleEndian, &result), ShouldNotBeNil)
+		})
+		Convey("Should return error if element options are invalid", func() {
+			type NotContainer struct {
+				A string `d2b:"length:2,elem.length:2"`
+			}
+			type KeyOfSlice struct {
+				A []string `d2b:"lenprefix:uint8,key.length:2"`
+			}
+			type LengthFrom struct {
+				N uint8
+				A [][]uint8 `d2b:"lenprefix:uint8,elem.lengthfrom:N"`
+			}
+			type Endian struct {
+				A []uint16 `d2b:"lenprefix:uint8,elem.endian:big"`
+			}
+			type WrongType struct {
+				A []uint8 `d2b:"lenprefix:uint8,elem.cstring"`
+			}
+			type WithoutLength struct {
+				A [2]string
+			}
+			for _, data := range []interface{}{NotContainer{}, KeyOfSlice{}, LengthFrom{}, Endian{}, WrongType{}, WithoutLength{}} {
+				_, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
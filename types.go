@@ -0,0 +1,391 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// numberCodec encodes fixed size integers and floats
+type numberCodec struct {
+	kind   reflect.Kind
+	length int
+}
+
+func (c numberCodec) size() int {
+	return c.length
+}
+
+func (c numberCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	buf, b := extend(buf, c.length)
+	switch c.kind {
+	case reflect.Int8:
+		b[0] = byte(v.Int())
+	case reflect.Int16:
+		endian.PutUint16(b, uint16(v.Int()))
+	case reflect.Int32:
+		endian.PutUint32(b, uint32(v.Int()))
+	case reflect.Int64:
+		endian.PutUint64(b, uint64(v.Int()))
+	case reflect.Uint8:
+		b[0] = byte(v.Uint())
+	case reflect.Uint16:
+		endian.PutUint16(b, uint16(v.Uint()))
+	case reflect.Uint32:
+		endian.PutUint32(b, uint32(v.Uint()))
+	case reflect.Uint64:
+		endian.PutUint64(b, v.Uint())
+	case reflect.Float32:
+		endian.PutUint32(b, math.Float32bits(float32(v.Float())))
+	case reflect.Float64:
+		endian.PutUint64(b, math.Float64bits(v.Float()))
+	}
+	return buf, nil
+}
+
+func (c numberCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	bytes, err := in.next(c.length)
+	if err != nil {
+		return err
+	}
+	switch c.kind {
+	case reflect.Int8:
+		v.SetInt(int64(int8(bytes[0])))
+	case reflect.Int16:
+		v.SetInt(int64(int16(endian.Uint16(bytes))))
+	case reflect.Int32:
+		v.SetInt(int64(int32(endian.Uint32(bytes))))
+	case reflect.Int64:
+		v.SetInt(int64(endian.Uint64(bytes)))
+	case reflect.Uint8:
+		v.SetUint(uint64(bytes[0]))
+	case reflect.Uint16:
+		v.SetUint(uint64(endian.Uint16(bytes)))
+	case reflect.Uint32:
+		v.SetUint(uint64(endian.Uint32(bytes)))
+	case reflect.Uint64:
+		v.SetUint(endian.Uint64(bytes))
+	case reflect.Float32:
+		v.SetFloat(float64(math.Float32frombits(endian.Uint32(bytes))))
+	case reflect.Float64:
+		v.SetFloat(math.Float64frombits(endian.Uint64(bytes)))
+	}
+	return nil
+}
+
+// sizedIntCodec encodes int and uint values, which take length bytes
+type sizedIntCodec struct {
+	length int
+}
+
+func (c sizedIntCodec) size() int {
+	return c.length
+}
+
+func (c sizedIntCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	return appendSizedInt(buf, v, c.length, endian)
+}
+
+func (c sizedIntCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	return updateSizedInt(v, in, c.length, endian)
+}
+
+// boolCodec encodes bool as one byte
+type boolCodec struct {
+	strict bool
+}
+
+func (c boolCodec) size() int {
+	return 1
+}
+
+func (c boolCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	if v.Bool() {
+		return append(buf, 1), nil
+	}
+	return append(buf, 0), nil
+}
+
+func (c boolCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	bytes, err := in.next(1)
+	if err != nil {
+		return err
+	}
+	if c.strict && bytes[0] > 1 {
+		return errors.Errorf("invalid bool value %d", bytes[0])
+	}
+	v.SetBool(bytes[0] != 0)
+	return nil
+}
+
+// float16Codec encodes float32 and float64 values as IEEE 754 half-precision numbers
+type float16Codec struct{}
+
+func (c float16Codec) size() int {
+	return 2
+}
+
+func (c float16Codec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	return appendUint(buf, uint64(Float16Bits(float32(v.Float()))), 2, endian)
+}
+
+func (c float16Codec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	val, err := readUint(in, 2, endian)
+	if err != nil {
+		return err
+	}
+	v.SetFloat(float64(Float16FromBits(uint16(val))))
+	return nil
+}
+
+// ptrCodec encodes value, pointer points to. Nil pointers are encoded as zero value
+type ptrCodec struct {
+	elemType reflect.Type
+	elem     codec
+	zero     reflect.Value
+	// custom is true if elemType has it's own bytes representation, so nil pointer is encoded as zero bytes
+	custom bool
+}
+
+func newPtrCodec(t reflect.Type, elem codec) *ptrCodec {
+	return &ptrCodec{
+		elemType: t.Elem(),
+		elem:     elem,
+		zero:     reflect.Zero(t.Elem()),
+		custom:   isCustomType(t.Elem()),
+	}
+}
+
+func (c *ptrCodec) size() int {
+	return c.elem.size()
+}
+
+func (c *ptrCodec) valueSize(v reflect.Value) (int, error) {
+	if !v.IsNil() {
+		return valueSize(c.elem, v.Elem())
+	}
+	if !c.custom {
+		return valueSize(c.elem, c.zero)
+	}
+	return getCustomTypeBytesLength(c.elemType)
+}
+
+func (c *ptrCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	if !v.IsNil() {
+		return c.elem.encode(buf, v.Elem(), endian)
+	}
+	if !c.custom {
+		return c.elem.encode(buf, c.zero, endian)
+	}
+	typeLen, err := getCustomTypeBytesLength(c.elemType)
+	if err != nil {
+		return nil, err
+	}
+	buf, _ = extend(buf, typeLen)
+	return buf, nil
+}
+
+func (c *ptrCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	if v.IsNil() {
+		v.Set(reflect.New(c.elemType))
+	}
+	return c.elem.decode(in, v.Elem(), endian)
+}
+
+// arrayCodec encodes all elements of array
+type arrayCodec struct {
+	length int
+	elem   codec
+}
+
+func (c arrayCodec) size() int {
+	elemSize := c.elem.size()
+	if elemSize < 0 {
+		return -1
+	}
+	return c.length * elemSize
+}
+
+func (c arrayCodec) valueSize(v reflect.Value) (int, error) {
+	var result int
+	for i := 0; i < c.length; i++ {
+		size, err := valueSize(c.elem, v.Index(i))
+		if err != nil {
+			return 0, errors.Wrap(err, "can't calculate array element length")
+		}
+		result += size
+	}
+	return result, nil
+}
+
+func (c arrayCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	var err error
+	for i := 0; i < c.length; i++ {
+		buf, err = c.elem.encode(buf, v.Index(i), endian)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't convert array element to bytes")
+		}
+	}
+	return buf, nil
+}
+
+func (c arrayCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	var err error
+	for i := 0; i < c.length; i++ {
+		err = c.elem.decode(in, v.Index(i), endian)
+		if err != nil {
+			if pErr, ok := err.(pathError); ok {
+				return prependIndexPath(pErr, i)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// structCodec encodes all struct fields, which are not skipped
+type structCodec struct {
+	t      reflect.Type
+	fields []structFieldCodec
+	length int
+	// unexported is true if some of fields are unexported, so struct should be addressable to access them
+	unexported bool
+	// align is the alignment of struct with natural layout, which it's length is padded to. It's 1 for packed structs
+	align int
+	// widest is the alignment of the widest field, which struct is aligned by, when it's nested in natural struct
+	widest int
+}
+
+type structFieldCodec struct {
+	// index is the path of field, which is longer than one for fields of embedded structs
+	index  []int
+	name   string
+	endian binary.ByteOrder
+	codec  codec
+	// whole is true if codec works with the whole struct value, e.g. to encode group of bit fields
+	whole bool
+	// blank is type of "_" field, which is decoded to temporary value, because it can't be read. nil for other fields
+	blank reflect.Type
+	// align is the alignment of field's offset from the start of struct. Zero bytes are added before field to align it
+	align int
+	// reserved is the count of fill bytes before field. If strictReserved is set, decoder checks them
+	reserved       int
+	fill           byte
+	strictReserved bool
+	// offset is the position of field from the start of struct, if hasOffset is set. Zero bytes are added before field to place it there
+	offset    int
+	hasOffset bool
+}
+
+func (c *structCodec) size() int {
+	return c.length
+}
+
+func (c *structCodec) valueSize(v reflect.Value) (int, error) {
+	if c.unexported {
+		v = addressable(v)
+	}
+	var result int
+	for _, field := range c.fields {
+		fv := v
+		if !field.whole {
+			fv = structField(v, field.index)
+		}
+		size, err := valueSize(field.codec, fv)
+		if err == nil && field.hasOffset {
+			var pad int
+			pad, err = offsetPadding(result, field.offset)
+			result += pad
+		}
+		if err != nil {
+			return 0, errors.Wrapf(err, "can't calculate %v.%v field length", c.t.Name(), field.name)
+		}
+		result += field.reserved
+		result += alignPadding(result, field.align) + size
+	}
+	return result + alignPadding(result, c.align), nil
+}
+
+func (c *structCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	if c.unexported {
+		v = addressable(v)
+	}
+	var err error
+	start := len(buf)
+	for _, field := range c.fields {
+		fieldEndian := endian
+		if field.endian != nil {
+			fieldEndian = field.endian
+		}
+		fv := v
+		if !field.whole {
+			fv = structField(v, field.index)
+		}
+		if field.reserved > 0 {
+			buf = appendFill(buf, field.fill, field.reserved)
+		}
+		if field.align > 1 {
+			buf, _ = extend(buf, alignPadding(len(buf)-start, field.align))
+		}
+		if err == nil && field.hasOffset {
+			var pad int
+			if pad, err = offsetPadding(len(buf)-start, field.offset); err == nil {
+				buf, _ = extend(buf, pad)
+			}
+		}
+		if err == nil {
+			buf, err = field.codec.encode(buf, fv, fieldEndian)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't encode %v.%v field to bytes", c.t.Name(), field.name)
+		}
+	}
+	if c.align > 1 {
+		buf, _ = extend(buf, alignPadding(len(buf)-start, c.align))
+	}
+	return buf, nil
+}
+
+func (c *structCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	var err error
+	start := in.pos
+	for _, field := range c.fields {
+		fieldEndian := endian
+		if field.endian != nil {
+			fieldEndian = field.endian
+		}
+		fv := v
+		if field.blank != nil {
+			fv = reflect.New(field.blank).Elem()
+		} else if !field.whole {
+			fv = structField(v, field.index)
+		}
+		if field.reserved > 0 {
+			err = skipReserved(in, field.reserved, field.fill, field.strictReserved)
+		}
+		if err == nil && field.align > 1 {
+			err = in.skip(alignPadding(in.pos-start, field.align))
+		}
+		if err == nil && field.hasOffset {
+			var pad int
+			if pad, err = offsetPadding(in.pos-start, field.offset); err == nil {
+				err = in.skip(pad)
+			}
+		}
+		if err == nil {
+			err = field.codec.decode(in, fv, fieldEndian)
+		}
+		if err != nil {
+			if pErr, ok := err.(pathError); ok {
+				return prependFieldPath(pErr, field.name)
+			}
+			return errors.Wrapf(err, "can't update struct field %s.%s", c.t.Name(), field.name)
+		}
+	}
+	if c.align > 1 {
+		return in.skip(alignPadding(in.pos-start, c.align))
+	}
+	return nil
+}
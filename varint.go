@@ -0,0 +1,102 @@
+package d2b
+
+import (
+	"encoding/binary"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// varintCodec encodes integers as unsigned LEB128 varints, like binary.PutUvarint
+// Signed values are written as their two's complement bits, unless zigzag is set. Then they are encoded like binary.PutVarint
+type varintCodec struct {
+	zigzag bool
+}
+
+func (c varintCodec) size() int {
+	return -1
+}
+
+// bits returns value, which is written as unsigned varint
+func (c varintCodec) bits(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		if c.zigzag {
+			return uint64(n<<1) ^ uint64(n>>63)
+		}
+		return uint64(n)
+	}
+	return v.Uint()
+}
+
+func (c varintCodec) valueSize(v reflect.Value) (int, error) {
+	return uvarintSize(c.bits(v)), nil
+}
+
+func (c varintCodec) encode(buf []byte, v reflect.Value, endian binary.ByteOrder) ([]byte, error) {
+	return appendUvarint(buf, c.bits(v)), nil
+}
+
+func (c varintCodec) decode(in *input, v reflect.Value, endian binary.ByteOrder) error {
+	val, err := readUvarint(in)
+	if err != nil {
+		return err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := int64(val)
+		if c.zigzag {
+			n = int64(val>>1) ^ -int64(val&1)
+		}
+		if v.OverflowInt(n) {
+			return errors.Errorf("value %d overflows %v", n, v.Type())
+		}
+		v.SetInt(n)
+	default:
+		if v.OverflowUint(val) {
+			return errors.Errorf("value %d overflows %v", val, v.Type())
+		}
+		v.SetUint(val)
+	}
+	return nil
+}
+
+// appendUvarint appends value as unsigned LEB128 varint to buf
+func appendUvarint(buf []byte, value uint64) []byte {
+	for value >= 0x80 {
+		buf = append(buf, byte(value)|0x80)
+		value >>= 7
+	}
+	return append(buf, byte(value))
+}
+
+// uvarintSize returns length of value, encoded as unsigned varint
+func uvarintSize(value uint64) int {
+	size := 1
+	for value >= 0x80 {
+		value >>= 7
+		size++
+	}
+	return size
+}
+
+// readUvarint reads unsigned LEB128 varint
+// Returns ShortBufferError, which needs one more byte, if bytes end in the middle of varint
+func readUvarint(in *input) (uint64, error) {
+	value, n := binary.Uvarint(in.rest())
+	for n == 0 {
+		// varint isn't read yet, input reads the next byte from the stream or returns ShortBufferError
+		if err := in.need(len(in.rest()) + 1); err != nil {
+			return 0, err
+		}
+		value, n = binary.Uvarint(in.rest())
+	}
+	if n < 0 {
+		return 0, errors.New("varint overflows 64 bits")
+	}
+	in.pos += n
+	return value, nil
+}
+
+// checkVarintOptions checks, that varint and zigzag are used with integers and without options,
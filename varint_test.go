@@ -0,0 +1,148 @@
+package d2b
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVarint(t *testing.T) {
+	Convey("Test varint integers", t, func() {
+		Convey("Should encode integers like encoding/binary", func() {
+			type Struct struct {
+				A uint64 `d2b:"varint"`
+				B int64  `d2b:"zigzag"`
+				C uint16 `d2b:"varint"`
+				D int    `d2b:"zigzag"`
+				E *int32 `d2b:"varint"`
+			}
+			e := int32(-1)
+			for _, data := range []Struct{
+				{A: 0, B: 0, C: 0, D: 0, E: &e},
+				{A: 300, B: -1, C: 128, D: 63, E: &e},
+				{A: math.MaxUint64, B: math.MinInt64, C: math.MaxUint16, D: -64, E: &e},
+			} {
+				expected := make([]byte, 0, 64)
+				expected = append(expected, uvarint(data.A)...)
+				expected = append(expected, varint(data.B)...)
+				expected = append(expected, uvarint(uint64(data.C))...)
+				expected = append(expected, varint(int64(data.D))...)
+				expected = append(expected, uvarint(uint64(*data.E))...)
+
+				encoded, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldBeNil)
+				So(encoded, ShouldResemble, expected)
+				size, err := Size(data)
+				So(err, ShouldBeNil)
+				So(size, ShouldEqual, len(encoded))
+
+				var result Struct
+				So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+				So(result, ShouldResemble, data)
+			}
+		})
+		Convey("Should encode varint length prefixes", func() {
+			type Struct struct {
+				A string   `d2b:"lenprefix:varint"`
+				B []uint16 `d2b:"lenprefix:varint,elem.varint"`
+			}
+			data := Struct{A: string(make([]byte, 200)), B: []uint16{1, 1000}}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(len(encoded), ShouldEqual, 2+200+1+1+2)
+			So(encoded[:2], ShouldResemble, []byte{0xC8, 0x01})
+			So(encoded[202:], ShouldResemble, []byte{2, 1, 0xE8, 0x07})
+			size, err := Size(data)
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, len(encoded))
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+
+			err = Decode(encoded[:1], binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "A", Needed: 2, Available: 1})
+			err = Decode(encoded[:205], binary.LittleEndian, &result)
+			So(err, ShouldResemble, &ShortBufferError{Path: "B[1]", Needed: 2, Available: 1})
+		})
+		Convey("Should fill varint length fields", func() {
+			type Struct struct {
+				N uint32 `d2b:"varint"`
+				A []byte `d2b:"lengthfrom:N,autofill"`
+			}
+			data := Struct{A: make([]byte, 130)}
+			size, err := Size(data)
+			So(err, ShouldBeNil)
+			So(size, ShouldEqual, 132)
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			So(len(encoded), ShouldEqual, 132)
+			So(encoded[:2], ShouldResemble, []byte{0x82, 0x01})
+
+			var result Struct
+			So(Decode(encoded, binary.LittleEndian, &result), ShouldBeNil)
+			So(result, ShouldResemble, Struct{N: 130, A: data.A})
+		})
+		Convey("Should read varints from stream", func() {
+			type Struct struct {
+				A int32  `d2b:"zigzag"`
+				B string `d2b:"lenprefix:varint"`
+				C uint8
+			}
+			data := Struct{A: -100000, B: "hello", C: 7}
+			encoded, err := Encode(data, binary.LittleEndian)
+			So(err, ShouldBeNil)
+			var result Struct
+			So(NewDecoder(bytes.NewReader(encoded), binary.LittleEndian).Decode(&result), ShouldBeNil)
+			So(result, ShouldResemble, data)
+		})
+		Convey("Should return error if varint is invalid", func() {
+			type Small struct {
+				A uint8 `d2b:"varint"`
+			}
+			type Signed struct {
+				A int8 `d2b:"zigzag"`
+			}
+			So(Decode([]byte{0x80, 0x02}, binary.LittleEndian, &Small{}), ShouldNotBeNil)
+			So(Decode([]byte{0x80, 0x02}, binary.LittleEndian, &Signed{}), ShouldNotBeNil)
+			tooLong := bytes.Repeat([]byte{0xFF}, 11)
+			So(Decode(tooLong, binary.LittleEndian, &Small{}), ShouldNotBeNil)
+			err := Decode([]byte{0x80}, binary.LittleEndian, &Small{})
+			So(err, ShouldResemble, &ShortBufferError{Path: "A", Needed: 2, Available: 1})
+		})
+		Convey("Should return error if varint options are invalid", func() {
+			type NotInteger struct {
+				A float32 `d2b:"varint"`
+			}
+			type Unsigned struct {
+				A uint32 `d2b:"zigzag"`
+			}
+			type Both struct {
+				A int32 `d2b:"varint,zigzag"`
+			}
+			type WithSize struct {
+				A int `d2b:"varint,size:4"`
+			}
+			type WithBits struct {
+				A uint8 `d2b:"varint,bits:8"`
+			}
+			for _, data := range []interface{}{NotInteger{}, Unsigned{}, Both{}, WithSize{}, WithBits{}} {
+				_, err := Encode(data, binary.LittleEndian)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func uvarint(x uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutUvarint(buf, x)]
+}
+
+func varint(x int64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutVarint(buf, x)]
+}